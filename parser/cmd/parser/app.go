@@ -123,6 +123,44 @@ func (a *App) Run() error {
 				continue
 			}
 		case "4":
+			err := a.parserManager.GetBatchVacancyDetails(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка получения деталей пачки вакансий: %v\n", err)
+				continue
+			}
+		case "5":
+			err := a.parserManager.ManageWatchlist(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка отслеживания вакансий: %v\n", err)
+				continue
+			}
+		case "6":
+			a.parserManager.PrintSystemStatus()
+		case "7":
+			err := a.parserManager.RefineLastSearch(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка уточнения результатов: %v\n", err)
+				continue
+			}
+		case "8":
+			err := a.parserManager.ManageSavedSearches(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка сохранённых поисков: %v\n", err)
+				continue
+			}
+		case "9":
+			err := a.parserManager.ManageDeadLetters(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка dead-letter: %v\n", err)
+				continue
+			}
+		case "10":
+			err := a.parserManager.CancelJob(a.scanner)
+			if err != nil {
+				fmt.Printf("Ошибка отмены джобы: %v\n", err)
+				continue
+			}
+		case "0":
 			a.parserManager.Shutdown()
 			fmt.Println("👋 До свидания!")
 			return nil
@@ -142,5 +180,12 @@ func printMenu() {
 	fmt.Println("1. Поиск вакансий (расширенный)")
 	fmt.Println("2. Получить описание вакансии по ID ")
 	fmt.Println("3. Получить полное описание вакансии по ID ")
-	fmt.Println("4. Выход")
+	fmt.Println("4. Получить полные описания нескольких вакансий")
+	fmt.Println("5. Отслеживание вакансий")
+	fmt.Println("6. Состояние системы")
+	fmt.Println("7. Уточнить результаты последнего поиска (фасеты)")
+	fmt.Println("8. Сохранённые поиски")
+	fmt.Println("9. Неудавшиеся фоновые джобы (dead-letter)")
+	fmt.Println("10. Отменить джобу по ID")
+	fmt.Println("0. Выход")
 }
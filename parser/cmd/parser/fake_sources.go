@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"parser/internal/fakesource"
+	"syscall"
+	"time"
+)
+
+// runFakeSources запускает локальные фейковые API HH.ru и SuperJob (команда `parser fake-sources`)
+// работает до Ctrl+C, при остановке печатает статистику запросов
+func runFakeSources(args []string) error {
+	defaults := fakesource.DefaultConfig()
+
+	flags := flag.NewFlagSet("fake-sources", flag.ContinueOnError)
+	hhAddr := flags.String("hh-addr", "127.0.0.1:8081", "адрес фейкового HH.ru")
+	sjAddr := flags.String("sj-addr", "127.0.0.1:8082", "адрес фейкового SuperJob")
+	vacancies := flags.Int("vacancies", defaults.Vacancies, "количество сгенерированных вакансий в каждом источнике")
+	seed := flags.Int64("seed", defaults.Seed, "зерно генератора данных и сбоев")
+	rate429 := flags.Float64("rate-429", 0, "доля ответов 429 Too Many Requests")
+	rate5xx := flags.Float64("rate-5xx", 0, "доля ответов 500/502/503")
+	slowRate := flags.Float64("slow-rate", 0, "доля медленных ответов")
+	slowDelay := flags.Duration("slow-delay", defaults.SlowDelay, "задержка медленного ответа")
+	retryAfter := flags.Duration("retry-after", defaults.RetryAfter, "значение Retry-After в ответах 429")
+	archivedRate := flags.Float64("archived-rate", 0, "доля вакансий в архиве (детали с archived / is_archive)")
+	removedRate := flags.Float64("removed-rate", 0, "доля удалённых вакансий (детали отвечают 404)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config := fakesource.Config{
+		Vacancies:  *vacancies,
+		Seed:       *seed,
+		Rate429:    *rate429,
+		Rate5xx:    *rate5xx,
+		SlowRate:   *slowRate,
+		SlowDelay:  *slowDelay,
+		RetryAfter: *retryAfter,
+
+		ArchivedRate: *archivedRate,
+		RemovedRate:  *removedRate,
+	}
+
+	hhConfig := config
+	hhConfig.Addr = *hhAddr
+	sjConfig := config
+	sjConfig.Addr = *sjAddr
+
+	servers := []*fakesource.Server{
+		fakesource.NewHHServer(hhConfig),
+		fakesource.NewSJServer(sjConfig),
+	}
+	for _, server := range servers {
+		if err := server.Start(); err != nil {
+			return err
+		}
+	}
+
+	hh, sj := servers[0], servers[1]
+	fmt.Printf("🧪 %s: %s\n", hh.Name(), hh.BaseURL())
+	fmt.Printf("🧪 %s: %s\n", sj.Name(), sj.BaseURL())
+	fmt.Println("\nЧтобы парсеры ходили в фейковые источники, укажите в parsersConfig.yml:")
+	fmt.Printf("  hh:\n    base_url: '%s'\n    health_endpoint: '%s?per_page=1'\n", hh.BaseURL(), hh.BaseURL())
+	fmt.Printf("  superjob:\n    base_url: '%s'\n    health_endpoint: '%s?count=1'\n", sj.BaseURL(), sj.BaseURL())
+	fmt.Printf("\nПринудительный сбой для запроса: заголовок %s или параметр fake_error (429, 500, 502, 503, slow)\n", fakesource.FaultHeader)
+	fmt.Println("Остановка: Ctrl+C")
+
+	// ждём сигнала остановки
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, server := range servers {
+		stats := server.Stats()
+		fmt.Printf("📊 %s: запросов %d, имитированных ошибок %d\n", server.Name(), stats.Requests, stats.Faults)
+		if err := server.Close(ctx); err != nil {
+			fmt.Printf("⚠️  %s: %v\n", server.Name(), err)
+		}
+	}
+
+	return nil
+}
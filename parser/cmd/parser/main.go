@@ -2,9 +2,18 @@ package main
 
 import (
 	"fmt"
+	"os"
 )
 
 func main() {
+	// подкоманды, которым не нужно основное приложение
+	if len(os.Args) > 1 && os.Args[1] == "fake-sources" {
+		if err := runFakeSources(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "fake-sources: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// инициализируем приложение
 	app, err := initApp()
@@ -10,7 +10,7 @@ type CachesConfig struct {
 	SearchCacheConfig         SearchCacheConfig
 	VacancyCacheConfig        VacancyCacheConfig
 	VacancyDetailsCacheConfig VacancyDetailsCacheConfig
-	MaxMemoryUsageMB          int
+	MaxMemoryUsageMB          int `yaml:"max_memory_usage_mb"` // лимит памяти процесса, от которого считаются пороги контроля ресурсов (0 - память не учитывается)
 }
 
 // структура конфига для кэша поиска
@@ -46,5 +46,6 @@ func DefaultCacheConfig() *CachesConfig {
 			VacDetCacheTTL:     60 * time.Second,
 			VacDetCacheCleanUp: 30 * time.Second,
 		},
+		MaxMemoryUsageMB: 512,
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"parser/internal/cassette"
 	"strconv"
 	"time"
 
@@ -18,6 +19,7 @@ type Config struct {
 	Parsers     *ParsersConfig
 	Manager     *ParserManagerConfig
 	HealthChech *HealthCheckConfig
+	Cassette    *cassette.Config // запись/воспроизведение HTTP взаимодействий (общий для всех клиентов)
 }
 
 type APIConfig struct {
@@ -57,6 +59,23 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
 	}
 
+	cassetteConfig, err := LoadYAMLConfig[cassette.Config](os.Getenv("CASSETTE_CONFIG_ADDRESS_STRING"), cassette.DefaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error during loading config: %s\n", err.Error())
+	}
+	// режим можно переопределить переменной окружения, не правя yml (например, CASSETTE_MODE=replay в CI)
+	if mode := os.Getenv("CASSETTE_MODE"); mode != "" {
+		cassetteConfig.Mode = mode
+	}
+
+	// кассеты включаются для всего приложения сразу: проставляем общий конфиг всем HTTP клиентам
+	for _, parserConfig := range []*ParserInstanceConfig{parsersConfig.HH, parsersConfig.SuperJob} {
+		if parserConfig != nil {
+			parserConfig.Cassette = cassetteConfig
+		}
+	}
+	healthCheckConfig.HealthCheckClientConfig.Cassette = cassetteConfig
+
 	return &Config{
 		API: APIConfig{
 			ConcSearchTimeout: time.Duration(concSearchTimeOut) * time.Second,
@@ -65,6 +84,7 @@ func LoadConfig() (*Config, error) {
 		Parsers:     parsersConfig,
 		Manager:     parsersManagerConfig,
 		HealthChech: healthCheckConfig,
+		Cassette:    cassetteConfig,
 	}, nil
 }
 
@@ -1,6 +1,10 @@
 package configs
 
-import "time"
+import (
+	"parser/internal/cassette"
+	"parser/internal/proxy"
+	"time"
+)
 
 type HealthCheckConfig struct {
 	RequestTimeOut          time.Duration           `yaml:"request_timeout"`        // Таймаут на один health check запрос
@@ -10,12 +14,14 @@ type HealthCheckConfig struct {
 }
 
 type HealthCheckClientConfig struct {
-	TimeOut               time.Duration `yaml:"timeout"`                 // Общий таймаут клиента
-	MaxIdleConns          int           `yaml:"max_idle_conns"`          // максимальное количество бездействующих (keep-alive) соединений для http клиента (экономия ресурсов)
-	IdleConnTimeout       time.Duration `yaml:"idle_conn_timeout"`       // интервал, через сколько закрывать неиспользуемое соединение
-	TLSHandshakeTimeout   time.Duration `yaml:"tls_handshake_timeout"`   // максимальное время ожидания завершения TLS handshake
-	ExpectContinueTimeout time.Duration `yaml:"expect_continue_timeout"` // интервал, оптимизация для сценариев загрузки больших данных
-	MaxConnPerHost        int           `yaml:"max_conns_per_host"`
+	TimeOut               time.Duration    `yaml:"timeout"`                 // Общий таймаут клиента
+	MaxIdleConns          int              `yaml:"max_idle_conns"`          // максимальное количество бездействующих (keep-alive) соединений для http клиента (экономия ресурсов)
+	IdleConnTimeout       time.Duration    `yaml:"idle_conn_timeout"`       // интервал, через сколько закрывать неиспользуемое соединение
+	TLSHandshakeTimeout   time.Duration    `yaml:"tls_handshake_timeout"`   // максимальное время ожидания завершения TLS handshake
+	ExpectContinueTimeout time.Duration    `yaml:"expect_continue_timeout"` // интервал, оптимизация для сценариев загрузки больших данных
+	MaxConnPerHost        int              `yaml:"max_conns_per_host"`
+	Proxy                 proxy.Config     `yaml:"proxy"` // прокси для health check запросов
+	Cassette              *cassette.Config `yaml:"-"`     // общий конфиг кассет, проставляется при загрузке конфига
 }
 
 func DefaultHealthCheckConfig() *HealthCheckConfig {
@@ -30,6 +36,7 @@ func DefaultHealthCheckConfig() *HealthCheckConfig {
 			TLSHandshakeTimeout:   3 * time.Second,
 			ExpectContinueTimeout: time.Second,
 			MaxConnPerHost:        2,
+			Proxy:                 proxy.DefaultConfig(),
 		},
 	}
 }
@@ -1,7 +1,18 @@
 package configs
 
 import (
+	"parser/internal/balancer"
 	"parser/internal/circuitbreaker"
+	"parser/internal/deadletter"
+	"parser/internal/dedup"
+	"parser/internal/facets"
+	"parser/internal/notify"
+	"parser/internal/queue"
+	"parser/internal/ranking"
+	"parser/internal/resources"
+	"parser/internal/retry"
+	"parser/internal/savedsearch"
+	"parser/internal/watchlist"
 	"time"
 )
 
@@ -10,6 +21,30 @@ type ParserManagerConfig struct {
 	MaxConcurrentParsers int                                 `yaml:"max_concurrent_parsers"` // глобальный семафор на использование парсеров
 	CircuitBreakerCfg    circuitbreaker.CircuitBreakerConfig `yaml:"circuit_breaker"`        // глобальный circuit breaker
 	HealthCheckInterval  time.Duration                       `yaml:"health_check_interval"`  // интервал проверки систояния менеджера парсеров
+	BatchDetails         BatchDetailsConfig                  `yaml:"batch_details"`          // получение деталей пачки вакансий
+	Watchlist            watchlist.Config                    `yaml:"watchlist"`              // отслеживание изменений конкретных вакансий
+	Queue                queue.PriorityQueueConfig           `yaml:"queue"`                  // очередь джоб с классами приоритета
+	Balancer             balancer.Config                     `yaml:"balancer"`               // выбор источников для джоб
+	Resources            resources.Config                    `yaml:"resources"`              // контроль памяти / CPU и допуск джоб под нагрузкой
+	Dedup                dedup.Config                        `yaml:"dedup"`                  // объединение одинаковых вакансий из разных источников
+	Ranking              ranking.Config                      `yaml:"ranking"`                // ранжирование общей выдачи мульти-поиска
+	Facets               facets.Config                       `yaml:"facets"`                 // фасеты общей выдачи и её уточнение без новых запросов
+	SavedSearches        savedsearch.Config                  `yaml:"saved_searches"`         // сохранённые поиски с запуском по расписанию
+	Notify               notify.Config                       `yaml:"notify"`                 // уведомления во внешние каналы (webhook, email, Telegram)
+	BackgroundJobs       BackgroundJobsConfig                `yaml:"background_jobs"`        // повторы фоновых джоб и dead-letter для исчерпавших повторы
+}
+
+// конфиг получения деталей пачки вакансий за одну джобу
+type BatchDetailsConfig struct {
+	MaxItems             int           `yaml:"max_items"`              // максимальный размер пачки
+	PerSourceConcurrency int           `yaml:"per_source_concurrency"` // сколько вакансий одного источника запрашивается одновременно
+	Timeout              time.Duration `yaml:"timeout"`                // общий таймаут на всю пачку
+}
+
+// конфиг повторов фоновых джоб (запуски сохранённых поисков) и хранилища джоб, исчерпавших повторы
+type BackgroundJobsConfig struct {
+	Retry      retry.RetryConfig `yaml:"retry"`       // используются max_attempts, initial_backoff, max_backoff, multiplier и jitter
+	DeadLetter deadletter.Config `yaml:"dead_letter"` // джобы, исчерпавшие повторы
 }
 
 // функция, которая возвращает указатель на дэфолтный конфиг мэнеджера парсеров
@@ -22,5 +57,31 @@ func DefaultParsersManagerConfig() *ParserManagerConfig {
 			ResetTimeout:        10 * time.Second,
 			WindowDuration:      10 * time.Second,
 		},
+		BatchDetails: BatchDetailsConfig{
+			MaxItems:             100,
+			PerSourceConcurrency: 2,
+			Timeout:              3 * time.Minute,
+		},
+		Watchlist: watchlist.DefaultConfig(),
+		Queue: queue.PriorityQueueConfig{
+			AgingInterval: 5 * time.Second,
+		},
+		Balancer:      balancer.DefaultConfig(),
+		Resources:     resources.DefaultConfig(),
+		Dedup:         dedup.DefaultConfig(),
+		Ranking:       ranking.DefaultConfig(),
+		Facets:        facets.DefaultConfig(),
+		SavedSearches: savedsearch.DefaultConfig(),
+		Notify:        notify.DefaultConfig(),
+		BackgroundJobs: BackgroundJobsConfig{
+			Retry: retry.RetryConfig{
+				MaxAttempts:    3,
+				InitialBackoff: 5 * time.Second,
+				MaxBackoff:     30 * time.Second,
+				Multiplier:     2,
+				Jitter:         0.2,
+			},
+			DeadLetter: deadletter.DefaultConfig(),
+		},
 	}
 }
@@ -1,7 +1,13 @@
 package configs
 
 import (
+	"parser/internal/cassette"
 	"parser/internal/circuitbreaker"
+	"parser/internal/httpcache"
+	"parser/internal/pipeline"
+	"parser/internal/proxy"
+	"parser/internal/retry"
+	"parser/internal/schemadrift"
 	"time"
 )
 
@@ -25,6 +31,17 @@ type ParserInstanceConfig struct {
 	TLSHandshakeTimeout   time.Duration                       `yaml:"tls_handshake_timeout"`
 	ResponseHeaderTimeout time.Duration                       `yaml:"response_header_timeout"`
 	ExpectContinueTimeout time.Duration                       `yaml:"expect_continue_timeout"`
+	Retry                 retry.RetryConfig                   `yaml:"retry"`
+	MaxResponseBytes      int64                               `yaml:"max_response_bytes"`
+	MaxErrorBodyBytes     int                                 `yaml:"max_error_body_bytes"`
+	ResponseCache         httpcache.Config                    `yaml:"response_cache"`
+	Proxy                 proxy.Config                        `yaml:"proxy"`
+	Headers               map[string]string                   `yaml:"headers"` // шаблоны заголовков, поддерживают ${ENV_VAR} и ${ENV_VAR:-default}
+	Cassette              *cassette.Config                    `yaml:"-"`       // общий конфиг кассет, проставляется при загрузке конфига
+	SchemaDrift           schemadrift.Config                  `yaml:"schema_drift"`
+	Middleware            []string                            `yaml:"middleware"`      // порядок middleware запросов к источнику (первая - внешняя)
+	FaultInjection        pipeline.FaultInjectionConfig       `yaml:"fault_injection"` // параметры middleware fault_injection
+	Hedge                 pipeline.HedgeConfig                `yaml:"hedge"`           // параметры middleware hedge (дублирующие запросы к медленному источнику)
 }
 
 // DefaultParsersConfig возвращает конфигурацию по умолчанию
@@ -48,6 +65,19 @@ func DefaultParsersConfig() *ParsersConfig {
 			TLSHandshakeTimeout:   10 * time.Second,
 			ResponseHeaderTimeout: 5 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
+			Retry:                 retry.DefaultRetryConfig(),
+			MaxResponseBytes:      10 << 20,
+			MaxErrorBodyBytes:     512,
+			ResponseCache:         httpcache.DefaultConfig(),
+			Proxy:                 proxy.DefaultConfig(),
+			SchemaDrift:           schemadrift.DefaultConfig(),
+			Middleware:            pipeline.DefaultMiddleware(),
+			Hedge:                 pipeline.DefaultHedgeConfig(),
+			Headers: map[string]string{
+				"User-Agent":      "JobParser/1.0 (${HH_CONTACT_EMAIL:-job-parser@example.com})",
+				"HH-User-Agent":   "JobParser/1.0 (${HH_CONTACT_EMAIL:-job-parser@example.com})",
+				"Accept-Language": "ru-RU,ru;q=0.9",
+			},
 		},
 		SuperJob: &ParserInstanceConfig{
 			Enabled:       true,
@@ -67,6 +97,18 @@ func DefaultParsersConfig() *ParsersConfig {
 			TLSHandshakeTimeout:   10 * time.Second,
 			ResponseHeaderTimeout: 5 * time.Second,
 			ExpectContinueTimeout: 1 * time.Second,
+			Retry:                 retry.DefaultRetryConfig(),
+			MaxResponseBytes:      10 << 20,
+			MaxErrorBodyBytes:     512,
+			ResponseCache:         httpcache.DefaultConfig(),
+			Proxy:                 proxy.DefaultConfig(),
+			SchemaDrift:           schemadrift.DefaultConfig(),
+			Middleware:            pipeline.DefaultMiddleware(),
+			Hedge:                 pipeline.DefaultHedgeConfig(),
+			Headers: map[string]string{
+				"X-Api-App-Id":    "${API_KEY}",
+				"Accept-Language": "ru-RU,ru;q=0.9",
+			},
 		},
 	}
 }
@@ -0,0 +1,233 @@
+// балансировщик источников: по наблюдаемым задержке, доле ошибок, нагрузке и состоянию circuit breaker
+// решает, в какие источники отправлять джобу и в каком порядке
+package balancer
+
+import (
+	"math/rand"
+	"parser/internal/circuitbreaker"
+	"parser/internal/queue"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Candidate - источник, среди которых выбирает балансировщик
+type Candidate struct {
+	Name    string
+	Healthy bool                 // по данным менеджера статусов парсеров
+	Breaker circuitbreaker.State // состояние circuit breaker источника
+}
+
+// SourceStats - наблюдаемые показатели источника
+type SourceStats struct {
+	InFlight     int           // запросов в работе
+	Requests     int64         // всего завершённых запросов
+	LatencyEWMA  time.Duration // сглаженная задержка (0 - ещё не было наблюдений)
+	ErrorRateEMA float64       // сглаженная доля ошибок (0..1)
+}
+
+// Balancer - балансировщик источников
+type Balancer struct {
+	config   Config
+	strategy string
+
+	mu    sync.Mutex
+	stats map[string]*SourceStats
+	rnd   *rand.Rand
+}
+
+// New создаёт балансировщик
+func New(config Config) (*Balancer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Balancer{
+		config:   config,
+		strategy: strings.ToLower(config.Strategy),
+		stats:    make(map[string]*SourceStats),
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Track отмечает начало запроса к источнику, возвращает функцию, которую нужно вызвать по завершении запроса
+func (b *Balancer) Track(source string) func(err error) {
+	start := time.Now()
+
+	b.mu.Lock()
+	b.statsFor(source).InFlight++
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() {
+			b.observe(source, time.Since(start), err)
+		})
+	}
+}
+
+// метод учёта завершённого запроса
+func (b *Balancer) observe(source string, latency time.Duration, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.statsFor(source)
+	stats.InFlight--
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+
+	alpha := b.config.EWMAAlpha
+	if stats.Requests == 0 {
+		// первое наблюдение берём как есть, иначе сглаженная задержка долго ползёт от нуля
+		stats.LatencyEWMA = latency
+		stats.ErrorRateEMA = failed
+	} else {
+		stats.LatencyEWMA = time.Duration(alpha*float64(latency) + (1-alpha)*float64(stats.LatencyEWMA))
+		stats.ErrorRateEMA = alpha*failed + (1-alpha)*stats.ErrorRateEMA
+	}
+	stats.Requests++
+}
+
+// Select возвращает имена источников, в которые нужно отправить джобу, в порядке предпочтения
+// источники с открытым circuit breaker и нездоровые пропускаются, пока есть другие (если других нет - пробуем все);
+// фоновые джобы отправляются только в слабо нагруженные источники и могут не получить ни одного
+// limited - часть доступных источников отброшена из-за класса приоритета (результат неполный, его не стоит кэшировать как полный)
+func (b *Balancer) Select(candidates []Candidate, priority queue.Priority) (names []string, limited bool) {
+	eligible := filterCandidates(candidates, func(c Candidate) bool { return c.Breaker != circuitbreaker.StateOpen })
+	if len(eligible) == 0 {
+		eligible = candidates
+	}
+	if healthy := filterCandidates(eligible, func(c Candidate) bool { return c.Healthy }); len(healthy) > 0 {
+		eligible = healthy
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	available := len(eligible)
+	if priority == queue.PriorityLow && b.config.LowPriorityMaxInFlight > 0 {
+		eligible = filterCandidates(eligible, func(c Candidate) bool {
+			return b.statsFor(c.Name).InFlight < b.config.LowPriorityMaxInFlight
+		})
+	}
+
+	names = make([]string, len(eligible))
+	for i, candidate := range eligible {
+		names[i] = candidate.Name
+	}
+	b.order(names)
+
+	if fanout := b.fanout(priority); fanout > 0 && fanout < len(names) {
+		names = names[:fanout]
+	}
+	return names, len(names) < available
+}
+
+// Stats возвращает копию показателей источников
+func (b *Balancer) Stats() map[string]SourceStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := make(map[string]SourceStats, len(b.stats))
+	for name, s := range b.stats {
+		stats[name] = *s
+	}
+	return stats
+}
+
+// метод упорядочивания источников согласно стратегии (вызывается под мьютексом)
+func (b *Balancer) order(names []string) {
+	switch b.strategy {
+	case StrategyLeastLoaded:
+		sort.SliceStable(names, func(i, j int) bool {
+			left, right := b.statsFor(names[i]), b.statsFor(names[j])
+			if left.InFlight != right.InFlight {
+				return left.InFlight < right.InFlight
+			}
+			return left.ErrorRateEMA < right.ErrorRateEMA
+		})
+	case StrategyLatencyEWMA:
+		// источник без наблюдений получает оценку 0 и идёт первым: так он быстрее получит свою оценку
+		sort.SliceStable(names, func(i, j int) bool {
+			return b.latencyScore(names[i]) < b.latencyScore(names[j])
+		})
+	case StrategyWeighted:
+		b.weightedShuffle(names)
+	}
+}
+
+// метод оценки источника для стратегии latency_ewma (меньше - лучше)
+func (b *Balancer) latencyScore(name string) float64 {
+	stats := b.statsFor(name)
+	return float64(stats.LatencyEWMA) * (1 + b.config.ErrorPenalty*stats.ErrorRateEMA)
+}
+
+// метод случайного упорядочивания с вероятностью, пропорциональной весу источника (без возвращения)
+func (b *Balancer) weightedShuffle(names []string) {
+	weights := make([]float64, len(names))
+	for i, name := range names {
+		weight, ok := b.config.Weights[name]
+		if !ok {
+			weight = 1
+		}
+		// ошибки снижают вес, но не до нуля: источник должен получать трафик, чтобы восстановить оценку
+		weights[i] = max(weight/(1+b.config.ErrorPenalty*b.statsFor(name).ErrorRateEMA), 0.01)
+	}
+
+	for i := range names {
+		total := 0.0
+		for _, weight := range weights[i:] {
+			total += weight
+		}
+
+		pick := b.rnd.Float64() * total
+		chosen := len(names) - 1
+		for j := i; j < len(names); j++ {
+			pick -= weights[j]
+			if pick < 0 {
+				chosen = j
+				break
+			}
+		}
+
+		names[i], names[chosen] = names[chosen], names[i]
+		weights[i], weights[chosen] = weights[chosen], weights[i]
+	}
+}
+
+// метод получения ограничения количества источников для класса приоритета
+func (b *Balancer) fanout(priority queue.Priority) int {
+	switch priority {
+	case queue.PriorityHigh:
+		return b.config.Fanout.High
+	case queue.PriorityNormal:
+		return b.config.Fanout.Normal
+	default:
+		return b.config.Fanout.Low
+	}
+}
+
+// метод получения показателей источника (вызывается под мьютексом)
+func (b *Balancer) statsFor(name string) *SourceStats {
+	stats, ok := b.stats[name]
+	if !ok {
+		stats = &SourceStats{}
+		b.stats[name] = stats
+	}
+	return stats
+}
+
+// функция фильтрации кандидатов
+func filterCandidates(candidates []Candidate, keep func(Candidate) bool) []Candidate {
+	filtered := make([]Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if keep(candidate) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
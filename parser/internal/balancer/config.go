@@ -0,0 +1,57 @@
+package balancer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// стратегии выбора источников
+const (
+	StrategyAll         = "all"          // все доступные источники в исходном порядке (поведение до балансировщика)
+	StrategyWeighted    = "weighted"     // случайный порядок с учётом весов из конфига и доли ошибок
+	StrategyLeastLoaded = "least_loaded" // сначала источники с наименьшим количеством запросов в работе
+	StrategyLatencyEWMA = "latency_ewma" // сначала источники с наименьшей сглаженной задержкой (с штрафом за ошибки)
+)
+
+// FanoutConfig - в сколько источников отправлять джобу каждого класса приоритета (0 - во все выбранные)
+type FanoutConfig struct {
+	High   int `yaml:"high"`
+	Normal int `yaml:"normal"`
+	Low    int `yaml:"low"`
+}
+
+// Config - конфигурация балансировщика источников
+type Config struct {
+	Strategy               string             `yaml:"strategy"`                   // all | weighted | least_loaded | latency_ewma
+	EWMAAlpha              float64            `yaml:"ewma_alpha"`                 // вес нового наблюдения в сглаженных задержке и доле ошибок (0..1)
+	ErrorPenalty           float64            `yaml:"error_penalty"`              // во сколько раз доля ошибок 100% ухудшает оценку источника
+	Weights                map[string]float64 `yaml:"weights"`                    // веса источников для стратегии weighted (по имени парсера, по умолчанию 1)
+	Fanout                 FanoutConfig       `yaml:"fanout"`                     // ограничение количества источников по классам приоритета
+	LowPriorityMaxInFlight int                `yaml:"low_priority_max_in_flight"` // фоновые джобы идут только в источники с меньшим числом запросов в работе (0 - без ограничения)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+// интерактивные джобы идут во все доступные источники, фоновые - в один наименее нагруженный
+func DefaultConfig() Config {
+	return Config{
+		Strategy:               StrategyLatencyEWMA,
+		EWMAAlpha:              0.3,
+		ErrorPenalty:           4,
+		Fanout:                 FanoutConfig{High: 0, Normal: 0, Low: 1},
+		LowPriorityMaxInFlight: 2,
+	}
+}
+
+// Validate проверяет конфиг
+func (c Config) Validate() error {
+	switch strings.ToLower(c.Strategy) {
+	case StrategyAll, StrategyWeighted, StrategyLeastLoaded, StrategyLatencyEWMA:
+	default:
+		return fmt.Errorf("balancer: unknown strategy %q (expected %s, %s, %s or %s)",
+			c.Strategy, StrategyAll, StrategyWeighted, StrategyLeastLoaded, StrategyLatencyEWMA)
+	}
+	if c.EWMAAlpha <= 0 || c.EWMAAlpha > 1 {
+		return fmt.Errorf("balancer: ewma_alpha must be in (0, 1], got %v", c.EWMAAlpha)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cassette
+
+// режимы работы кассет
+const (
+	ModeOff            = "off"              // кассеты не используются, запросы идут в сеть
+	ModeRecord         = "record"           // запросы идут в сеть, пары запрос/ответ записываются в кассету
+	ModeReplay         = "replay"           // запросы в сеть не уходят, ответы берутся только из кассеты
+	ModeReplayOrRecord = "replay_or_record" // ответ из кассеты, а если его нет - запрос в сеть с записью
+)
+
+// Config - конфигурация записи/воспроизведения HTTP взаимодействий
+type Config struct {
+	Mode              string   `yaml:"mode"`                // off | record | replay | replay_or_record
+	Dir               string   `yaml:"dir"`                 // каталог с файлами кассет (по одному файлу на клиента)
+	RedactHeaders     []string `yaml:"redact_headers"`      // заголовки, значения которых не попадают в кассету
+	RedactQueryParams []string `yaml:"redact_query_params"` // параметры запроса, которые вырезаются из записи и не участвуют в сопоставлении
+}
+
+// DefaultConfig возвращает конфигурацию кассет по умолчанию (выключены)
+func DefaultConfig() *Config {
+	return &Config{
+		Mode: ModeOff,
+		Dir:  "cassettes",
+		RedactHeaders: []string{
+			"Authorization",
+			"Proxy-Authorization",
+			"Cookie",
+			"Set-Cookie",
+			"X-Api-App-Id",
+		},
+		RedactQueryParams: []string{
+			"api_key",
+			"app_key",
+			"access_token",
+			"token",
+		},
+	}
+}
+
+// IsEnabled сообщает, включены ли кассеты
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.Mode != "" && c.Mode != ModeOff
+}
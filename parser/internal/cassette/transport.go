@@ -0,0 +1,434 @@
+// запись и воспроизведение HTTP взаимодействий ("кассеты")
+// позволяет запускать приложение целиком (менеджер, кэши, circuit breakers) без доступа к HH.ru и SuperJob
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// значение, которым заменяются секреты в кассете
+const redacted = "REDACTED"
+
+// ErrNoInteraction - в режиме воспроизведения для запроса не нашлось записи в кассете
+var ErrNoInteraction = errors.New("cassette: no recorded interaction for request")
+
+// ErrResponseTooLarge - при записи тело ответа превысило лимит клиента (такой ответ не записывается)
+var ErrResponseTooLarge = errors.New("cassette: response body exceeds size limit")
+
+// хвост файла кассеты после последней записи (формат json.Encoder с отступом "  ")
+const fileTail = "\n  ]\n}\n"
+
+// Interaction - записанная пара запрос/ответ
+type Interaction struct {
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+// RecordedRequest - записанный запрос (секреты вырезаны)
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+}
+
+// RecordedResponse - записанный ответ
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// формат файла кассеты
+type cassetteFile struct {
+	Name         string        `json:"name"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Transport - http.RoundTripper, который записывает или воспроизводит взаимодействия
+type Transport struct {
+	next         http.RoundTripper
+	name         string
+	mode         string
+	path         string
+	redactHeader map[string]struct{}
+	redactQuery  map[string]struct{}
+	secrets      []string
+	maxBodyBytes int64 // максимальный размер записываемого тела ответа (0 - без ограничения)
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayCursor map[string]int // ключ сопоставления -> сколько раз уже воспроизводили
+	fileSize     int64          // размер файла кассеты, записанного этим транспортом (0 - файл ещё не переписывался)
+}
+
+// Wrap оборачивает транспорт кассетой, если кассеты включены в конфиге
+// name - имя клиента (определяет файл кассеты), maxBodyBytes - лимит тела записываемого ответа (0 - без ограничения),
+// secrets - значения, которые нужно вырезать из записи (API ключи)
+// при ошибке загрузки кассеты выводится предупреждение и возвращается исходный транспорт
+func Wrap(next http.RoundTripper, name string, config *Config, maxBodyBytes int64, secrets ...string) http.RoundTripper {
+	if !config.IsEnabled() {
+		return next
+	}
+
+	transport, err := NewTransport(next, name, config, maxBodyBytes, secrets...)
+	if err != nil {
+		fmt.Printf("⚠️  [%s] кассета не подключена: %v\n", name, err)
+		return next
+	}
+	return transport
+}
+
+// конструктор транспорта кассеты
+func NewTransport(next http.RoundTripper, name string, config *Config, maxBodyBytes int64, secrets ...string) (*Transport, error) {
+	switch config.Mode {
+	case ModeRecord, ModeReplay, ModeReplayOrRecord:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode: %s", config.Mode)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &Transport{
+		next:         next,
+		name:         name,
+		mode:         config.Mode,
+		path:         filepath.Join(config.Dir, fileName(name)),
+		redactHeader: make(map[string]struct{}, len(config.RedactHeaders)),
+		redactQuery:  make(map[string]struct{}, len(config.RedactQueryParams)),
+		replayCursor: make(map[string]int),
+		maxBodyBytes: maxBodyBytes,
+	}
+
+	for _, header := range config.RedactHeaders {
+		t.redactHeader[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+	for _, param := range config.RedactQueryParams {
+		t.redactQuery[strings.ToLower(param)] = struct{}{}
+	}
+	for _, secret := range secrets {
+		// короткие "секреты" (пустые, плейсхолдеры) не вырезаем - иначе испортим тела ответов
+		if len(secret) >= 8 {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// RoundTrip записывает или воспроизводит запрос согласно режиму
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == ModeReplay || t.mode == ModeReplayOrRecord {
+		if interaction, ok := t.find(req); ok {
+			return interaction.Response.toResponse(req), nil
+		}
+		if t.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, t.redactURL(req.URL))
+		}
+	}
+
+	return t.record(req)
+}
+
+// метод выполнения запроса в сеть с записью взаимодействия
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// тело читается целиком, поэтому лимит клиента (max_response_bytes) соблюдается и здесь:
+	// иначе "неадекватный" ответ источника попал бы в память (и в кассету) в обход лимита парсера
+	if t.maxBodyBytes > 0 && resp.ContentLength > t.maxBodyBytes {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w: Content-Length %d > %d", ErrResponseTooLarge, resp.ContentLength, t.maxBodyBytes)
+	}
+	reader := io.Reader(resp.Body)
+	if t.maxBodyBytes > 0 {
+		reader = io.LimitReader(resp.Body, t.maxBodyBytes+1)
+	}
+	body, err := io.ReadAll(reader)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if t.maxBodyBytes > 0 && int64(len(body)) > t.maxBodyBytes {
+		return nil, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, t.maxBodyBytes)
+	}
+
+	// длина тела после вырезания секретов может измениться - при воспроизведении она выставляется заново
+	responseHeaders := t.redactHeaders(resp.Header)
+	responseHeaders.Del("Content-Length")
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     t.redactURL(req.URL),
+			Headers: t.redactHeaders(req.Header),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    responseHeaders,
+			Body:       t.redactSecrets(string(body)),
+		},
+		RecordedAt: time.Now().UTC(),
+	}
+
+	if err := t.append(interaction); err != nil {
+		fmt.Printf("⚠️  [%s] не удалось записать кассету: %v\n", t.name, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+// метод поиска записи для запроса (по методу, пути и нормализованной строке запроса)
+// если записей с одним ключом несколько - отдаются по порядку, последняя повторяется
+func (t *Transport) find(req *http.Request) (Interaction, bool) {
+	key := t.matchKey(req.Method, req.URL)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var matches []Interaction
+	for _, interaction := range t.interactions {
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if t.matchKey(interaction.Request.Method, recordedURL) == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return Interaction{}, false
+	}
+
+	index := min(t.replayCursor[key], len(matches)-1)
+	t.replayCursor[key]++
+	return matches[index], true
+}
+
+// метод формирования ключа сопоставления: метод + путь + отсортированные параметры (без секретных)
+// хост в ключ не входит, поэтому кассету можно воспроизводить и для локального фейкового источника
+func (t *Transport) matchKey(method string, u *url.URL) string {
+	query := u.Query()
+	for param := range query {
+		if _, secret := t.redactQuery[strings.ToLower(param)]; secret {
+			query.Del(param)
+		}
+	}
+
+	keys := make([]string, 0, len(query))
+	for param := range query {
+		keys = append(keys, param)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, param := range keys {
+		values := append([]string(nil), query[param]...)
+		sort.Strings(values)
+		for _, value := range values {
+			builder.WriteString(url.QueryEscape(param))
+			builder.WriteByte('=')
+			builder.WriteString(url.QueryEscape(value))
+			builder.WriteByte('&')
+		}
+	}
+
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	return strings.ToUpper(method) + " " + path + "?" + builder.String()
+}
+
+// метод удаления секретов из URL
+func (t *Transport) redactURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+
+	query := clean.Query()
+	for param := range query {
+		if _, secret := t.redactQuery[strings.ToLower(param)]; secret {
+			query.Set(param, redacted)
+		}
+	}
+	clean.RawQuery = query.Encode()
+
+	return t.redactSecrets(clean.String())
+}
+
+// метод удаления секретов из заголовков
+func (t *Transport) redactHeaders(headers http.Header) http.Header {
+	clean := make(http.Header, len(headers))
+	for key, values := range headers {
+		if _, secret := t.redactHeader[http.CanonicalHeaderKey(key)]; secret {
+			clean[key] = []string{redacted}
+			continue
+		}
+		for _, value := range values {
+			clean.Add(key, t.redactSecrets(value))
+		}
+	}
+	return clean
+}
+
+// метод удаления известных секретов (API ключей) из произвольного текста
+func (t *Transport) redactSecrets(text string) string {
+	for _, secret := range t.secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return text
+}
+
+// метод загрузки кассеты из файла (если файла нет - начинаем с пустой кассеты)
+func (t *Transport) load() error {
+	data, err := os.ReadFile(t.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if t.mode == ModeReplay {
+			fmt.Printf("⚠️  [%s] кассета %s не найдена, все запросы будут завершаться ошибкой\n", t.name, t.path)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parse cassette %s: %w", t.path, err)
+	}
+	t.interactions = file.Interactions
+	return nil
+}
+
+// метод добавления записи и сохранения кассеты на диск
+// первая запись переписывает файл целиком (через временный файл, чтобы не получить "половину" кассеты),
+// следующие дописываются в конец файла на место хвоста "]}" - без перезаписи всей кассеты на каждый запрос
+func (t *Transport) append(interaction Interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.interactions = append(t.interactions, interaction)
+
+	if t.fileSize > 0 {
+		return t.appendToFile(interaction)
+	}
+	return t.rewriteFile()
+}
+
+// метод записи кассеты целиком (вызывается под мьютексом)
+func (t *Transport) rewriteFile() error {
+	data, err := encodeJSON(cassetteFile{Name: t.name, Interactions: t.interactions}, "")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, t.path); err != nil {
+		return err
+	}
+
+	// дописывать можно, только если файл заканчивается ожидаемым хвостом (в пустой кассете массив записей - "[]")
+	t.fileSize = 0
+	if bytes.HasSuffix(data, []byte(fileTail)) {
+		t.fileSize = int64(len(data))
+	}
+	return nil
+}
+
+// метод дописывания записи в конец файла кассеты (вызывается под мьютексом)
+// если файл изменили снаружи (размер не совпал) - кассета переписывается целиком
+func (t *Transport) appendToFile(interaction Interaction) error {
+	data, err := encodeJSON(interaction, "    ")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(t.path, os.O_WRONLY, 0o644)
+	if err != nil {
+		return t.rewriteFile()
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.Size() != t.fileSize {
+		return t.rewriteFile()
+	}
+
+	chunk := make([]byte, 0, len(data)+len(fileTail)+8)
+	chunk = append(chunk, ",\n    "...)
+	chunk = append(chunk, bytes.TrimSuffix(data, []byte("\n"))...)
+	chunk = append(chunk, fileTail...)
+
+	offset := t.fileSize - int64(len(fileTail))
+	if _, err := file.WriteAt(chunk, offset); err != nil {
+		t.fileSize = 0
+		return err
+	}
+	t.fileSize = offset + int64(len(chunk))
+	return nil
+}
+
+// функция кодирования JSON в формате кассеты
+// кассеты читают люди (в ревью и при отладке), поэтому без экранирования &, < и > в URL и телах
+func encodeJSON(value any, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent(prefix, "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// метод сборки http.Response из записи
+func (r RecordedResponse) toResponse(req *http.Request) *http.Response {
+	body := []byte(r.Body)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// символы, недопустимые в имени файла кассеты
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// функция формирования имени файла кассеты по имени клиента
+func fileName(name string) string {
+	return strings.ToLower(unsafeFileChars.ReplaceAllString(name, "_")) + ".json"
+}
@@ -15,6 +15,20 @@ const (
 	StateHalfOpen
 )
 
+// String возвращает имя состояния ("closed", "open", "half-open")
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
 var (
 	ErrCircuitOpen     = errors.New("circuit breaker is open")
 	ErrTooManyRequests = errors.New("too many requests in half-open state")
@@ -42,8 +56,14 @@ type CircuitBreaker struct {
 	totalRequests  uint
 	totalSuccesses uint
 	totalFailures  uint
+
+	// подписчики на смену состояния
+	handlers []StateHandler
 }
 
+// StateHandler - обработчик смены состояния Circuit Breaker
+type StateHandler = func(from, to State)
+
 func NewCircutBreaker(config CircuitBreakerConfig) *CircuitBreaker {
 	if config.FailureThreshold == 0 {
 		config.FailureThreshold = 5
@@ -6,6 +6,7 @@ import "time"
 func (cb *CircuitBreaker) Execute(fn func() error) error {
 	// так как этот метод будут использовать асинхронно, делаем дальнейшие операции из-под мьютекса
 	cb.mu.Lock()
+	halfOpened := false // breaker перешёл из Open в Half-Open на этом запросе
 
 	// проеряем состояние (или идём дальше, или возвращаем ошибку)
 	switch cb.state {
@@ -22,6 +23,7 @@ func (cb *CircuitBreaker) Execute(fn func() error) error {
 		cb.state = StateHalfOpen // меняем состояние на полу-открытое
 		cb.halfOpenAttempts = 0  // утсанавливаем счётчик попыток в полу-открытом состоянии в 0
 		cb.successes = 0         // утсанавливаем счётчик успешных попыток попыток в полу-открытом состоянии в 0
+		halfOpened = true
 		//// случай - полу-открытого circut breaker
 	case StateHalfOpen:
 		if cb.halfOpenAttempts >= cb.halfOpenMaxRequests {
@@ -36,23 +38,56 @@ func (cb *CircuitBreaker) Execute(fn func() error) error {
 	cb.totalRequests++
 	cb.mu.Unlock()
 
+	if halfOpened {
+		cb.notify(StateOpen, StateHalfOpen)
+	}
+	return cb.run(fn)
+}
+
+// run выполняет операцию, пропущенную Circuit Breaker, и учитывает её результат
+func (cb *CircuitBreaker) run(fn func() error) error {
 	// Выполняем операцию
 	err := fn()
 
 	// вызываем мьютекс, так как меняем статус circut breaker асинхронно
 	cb.mu.Lock()
-	defer cb.mu.Unlock()
+	from := cb.state
 
 	// Обрабатываем результат
 	if err != nil {
 		cb.totalFailures++
 		cb.onFailure()
-		return err
+	} else {
+		cb.totalSuccesses++
+		cb.onSuccess()
 	}
 
-	cb.totalSuccesses++
-	cb.onSuccess()
-	return nil
+	to := cb.state
+	cb.mu.Unlock()
+
+	// подписчиков вызываем без мьютекса: обработчик может сам читать состояние breaker
+	if from != to {
+		cb.notify(from, to)
+	}
+	return err
+}
+
+// Subscribe добавляет обработчик смены состояния (closed -> open, open -> half-open, half-open -> closed и т.д.)
+func (cb *CircuitBreaker) Subscribe(handler StateHandler) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.handlers = append(cb.handlers, handler)
+}
+
+// notify сообщает подписчикам о смене состояния (вызывается без мьютекса)
+func (cb *CircuitBreaker) notify(from, to State) {
+	cb.mu.RLock()
+	handlers := append([]StateHandler(nil), cb.handlers...)
+	cb.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(from, to)
+	}
 }
 
 // onFailure обрабатывает неудачное выполнение
@@ -98,9 +133,14 @@ func (cb *CircuitBreaker) onSuccess() {
 }
 
 // GetState возвращает текущее состояние
-func (cb *CircuitBreaker) getState() State {
+// открытый breaker, у которого истёк resetTimeout, считается полу-открытым: следующий запрос пропустит пробный вызов
+func (cb *CircuitBreaker) GetState() State {
 	cb.mu.RLock()
 	defer cb.mu.RUnlock()
+
+	if cb.state == StateOpen && time.Since(cb.lastFailureTime) >= cb.resetTimeout {
+		return StateHalfOpen
+	}
 	return cb.state
 }
 
@@ -0,0 +1,14 @@
+package deadletter
+
+// Config - конфигурация хранилища джоб, исчерпавших повторы
+type Config struct {
+	MaxEntries  int    `yaml:"max_entries"`  // сколько записей хранится (при переполнении удаляются самые старые, 0 - без ограничения)
+	StoragePath string `yaml:"storage_path"` // файл, в котором записи переживают перезапуск ("" - только в памяти)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		MaxEntries: 200,
+	}
+}
@@ -0,0 +1,175 @@
+// dead-letter: фоновые джобы, исчерпавшие повторы, вместе с историей попыток и последней ошибкой;
+// записи можно просмотреть, повторить или удалить
+package deadletter
+
+import (
+	"errors"
+	"parser/internal/domain/models"
+	"parser/pkg"
+	"sync"
+	"time"
+)
+
+// виды джоб в dead-letter
+const (
+	KindSearch  = "search"  // поиск вакансий
+	KindDetails = "details" // получение деталей отслеживаемой вакансии
+)
+
+var ErrNotFound = errors.New("deadletter: entry not found")
+
+// Attempt - попытка выполнения джобы
+type Attempt struct {
+	At     time.Time `json:"at"`
+	Error  string    `json:"error"`
+	Replay bool      `json:"replay,omitempty"` // повтор из dead-letter (а не автоматический)
+}
+
+// Entry - джоба, исчерпавшая повторы
+type Entry struct {
+	ID        string              `json:"id"`
+	Kind      string              `json:"kind"`
+	Origin    string              `json:"origin"`               // кто поставил джобу (например, сохранённый поиск)
+	Params    models.SearchParams `json:"params"`               // параметры поиска (для KindSearch)
+	Source    string              `json:"source,omitempty"`     // источник вакансии (для KindDetails)
+	VacancyID string              `json:"vacancy_id,omitempty"` // ID вакансии (для KindDetails)
+	Attempts  []Attempt           `json:"attempts"`
+	FailedAt  time.Time           `json:"failed_at"` // время последней неудачной попытки
+}
+
+// LastError возвращает ошибку последней попытки
+func (e Entry) LastError() string {
+	if len(e.Attempts) == 0 {
+		return ""
+	}
+	return e.Attempts[len(e.Attempts)-1].Error
+}
+
+// Store - хранилище джоб, исчерпавших повторы
+type Store struct {
+	config  Config
+	mu      sync.Mutex
+	entries []*Entry // от старых к новым
+	saveMu  sync.Mutex
+}
+
+// New создаёт хранилище и загружает записи из файла (если он задан в конфиге)
+func New(config Config) (*Store, error) {
+	s := &Store{config: config}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add сохраняет джобу, исчерпавшую повторы; при переполнении удаляются самые старые записи
+func (s *Store) Add(entry Entry) Entry {
+	entry.ID = pkg.QuickUUID()
+	entry.Attempts = append([]Attempt(nil), entry.Attempts...)
+	if entry.FailedAt.IsZero() {
+		entry.FailedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	s.entries = append(s.entries, &entry)
+	if s.config.MaxEntries > 0 && len(s.entries) > s.config.MaxEntries {
+		s.entries = append([]*Entry(nil), s.entries[len(s.entries)-s.config.MaxEntries:]...)
+	}
+	copied := entry.clone()
+	s.mu.Unlock()
+
+	s.persist()
+	return copied
+}
+
+// List возвращает копию записей, от старых к новым
+func (s *Store) List() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, len(s.entries))
+	for i, entry := range s.entries {
+		entries[i] = entry.clone()
+	}
+	return entries
+}
+
+// Len возвращает количество записей
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// Get возвращает запись по ID
+func (s *Store) Get(id string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.index(id); i >= 0 {
+		return s.entries[i].clone(), nil
+	}
+	return Entry{}, ErrNotFound
+}
+
+// Remove удаляет запись (например, после успешного повтора)
+func (s *Store) Remove(id string) error {
+	s.mu.Lock()
+	i := s.index(id)
+	if i < 0 {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	s.entries = append(s.entries[:i], s.entries[i+1:]...)
+	s.mu.Unlock()
+
+	s.persist()
+	return nil
+}
+
+// Purge удаляет все записи, возвращает их количество
+func (s *Store) Purge() int {
+	s.mu.Lock()
+	count := len(s.entries)
+	s.entries = nil
+	s.mu.Unlock()
+
+	if count > 0 {
+		s.persist()
+	}
+	return count
+}
+
+// RecordAttempt добавляет в историю записи неудачную попытку повтора
+func (s *Store) RecordAttempt(id string, attempt Attempt) error {
+	s.mu.Lock()
+	i := s.index(id)
+	if i < 0 {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	s.entries[i].Attempts = append(s.entries[i].Attempts, attempt)
+	s.entries[i].FailedAt = attempt.At
+	s.mu.Unlock()
+
+	s.persist()
+	return nil
+}
+
+// метод поиска записи по ID (вызывается под мьютексом), -1 - не найдена
+func (s *Store) index(id string) int {
+	for i, entry := range s.entries {
+		if entry.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// метод копирования записи (наружу отдаём копии, чтобы не держать мьютекс)
+func (e *Entry) clone() Entry {
+	copied := *e
+	copied.Params.Sources = append([]string(nil), e.Params.Sources...)
+	copied.Attempts = append([]Attempt(nil), e.Attempts...)
+	return copied
+}
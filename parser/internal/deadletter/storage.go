@@ -0,0 +1,78 @@
+package deadletter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// формат файла dead-letter
+type storageFile struct {
+	Entries []Entry `json:"entries"`
+}
+
+// метод загрузки записей из файла (отсутствующий файл - пустой список)
+func (s *Store) load() error {
+	if s.config.StoragePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.config.StoragePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deadletter: read %s: %w", s.config.StoragePath, err)
+	}
+
+	var file storageFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("deadletter: parse %s: %w", s.config.StoragePath, err)
+	}
+
+	for i := range file.Entries {
+		s.entries = append(s.entries, &file.Entries[i])
+	}
+	return nil
+}
+
+// метод сохранения записей в файл, ошибка сохранения не мешает работе в памяти
+func (s *Store) persist() {
+	if s.config.StoragePath == "" {
+		return
+	}
+
+	if err := s.save(); err != nil {
+		fmt.Printf("⚠️  Не удалось записать файл dead-letter: %v\n", err)
+	}
+}
+
+// метод записи файла: сначала во временный, затем переименование, чтобы не оставить обрезанный файл
+func (s *Store) save() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	file := storageFile{Entries: s.List()}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.config.StoragePath), 0o755); err != nil {
+		return err
+	}
+
+	tmp := s.config.StoragePath + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.config.StoragePath)
+}
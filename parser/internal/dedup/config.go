@@ -0,0 +1,30 @@
+package dedup
+
+import "fmt"
+
+// Config - конфигурация объединения одинаковых вакансий из разных источников
+type Config struct {
+	Enabled         bool    `yaml:"enabled"`          // объединять ли дубли в выдаче мульти-поиска
+	Threshold       float64 `yaml:"threshold"`        // минимальное сходство (0..1), при котором публикации считаются одной вакансией
+	SalaryTolerance float64 `yaml:"salary_tolerance"` // допустимое относительное расхождение зарплат (0.15 - 15%)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Enabled:         true,
+		Threshold:       0.8,
+		SalaryTolerance: 0.15,
+	}
+}
+
+// Validate проверяет конфиг
+func (c Config) Validate() error {
+	if c.Threshold <= 0 || c.Threshold > 1 {
+		return fmt.Errorf("dedup: threshold must be in (0, 1], got %v", c.Threshold)
+	}
+	if c.SalaryTolerance < 0 {
+		return fmt.Errorf("dedup: salary_tolerance must not be negative, got %v", c.SalaryTolerance)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// объединение одинаковых вакансий из разных источников: одна и та же вакансия часто опубликована и на HH.ru, и на SuperJob
+// публикации сравниваются по нормализованным работодателю, названию, городу и зарплате, похожие объединяются в одну запись
+package dedup
+
+import (
+	"parser/internal/domain/models"
+)
+
+// веса признаков в итоговом сходстве
+const (
+	weightCompany = 0.35
+	weightTitle   = 0.35
+	weightCity    = 0.15
+	weightSalary  = 0.15
+
+	// сходство признака, значение которого неизвестно в одной из публикаций
+	unknownSimilarity = 0.5
+	// разные работодатели не объединяются, как бы ни были похожи остальные признаки
+	minCompanySimilarity = 0.5
+)
+
+// нормализованные признаки публикации
+type features struct {
+	source   string
+	company  map[string]struct{}
+	title    map[string]struct{}
+	city     string
+	currency string
+	salary   float64 // характерная зарплата (середина вилки или её известная граница), 0 - не указана
+}
+
+// кластер публикаций одной вакансии
+type cluster struct {
+	merged  models.MergedVacancy
+	members []features
+	sources map[string]struct{}
+}
+
+// Merge объединяет вакансии из результатов поиска по источникам (результаты с ошибкой пропускаются)
+// порядок сохраняется: основная публикация - первая встретившаяся; в одной записи не бывает двух публикаций одного источника
+func Merge(results []models.SearchVacanciesResult, config Config) []models.MergedVacancy {
+	var clusters []*cluster
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		for _, vacancy := range result.Vacancies {
+			f := extract(vacancy)
+
+			var best *cluster
+			bestScore := 0.0
+			for _, c := range clusters {
+				if _, taken := c.sources[f.source]; taken {
+					continue
+				}
+				// полная связь: публикация должна быть похожа на каждую публикацию кластера
+				score := 1.0
+				for _, member := range c.members {
+					score = min(score, similarity(f, member, config))
+				}
+				if score >= config.Threshold && score > bestScore {
+					best, bestScore = c, score
+				}
+			}
+
+			if best == nil {
+				clusters = append(clusters, &cluster{
+					merged: models.MergedVacancy{
+						Vacancy:    vacancy,
+						Postings:   []models.VacancyPosting{posting(vacancy)},
+						Similarity: 1,
+					},
+					members: []features{f},
+					sources: map[string]struct{}{f.source: {}},
+				})
+				continue
+			}
+
+			best.merged.Postings = append(best.merged.Postings, posting(vacancy))
+			best.merged.Similarity = min(best.merged.Similarity, bestScore)
+			best.members = append(best.members, f)
+			best.sources[f.source] = struct{}{}
+		}
+	}
+
+	merged := make([]models.MergedVacancy, len(clusters))
+	for i, c := range clusters {
+		merged[i] = c.merged
+	}
+	return merged
+}
+
+// Singles сводит вакансии из результатов поиска в общий список без объединения: каждая публикация - отдельная запись
+// (используется, когда объединение дублей выключено)
+func Singles(results []models.SearchVacanciesResult) []models.MergedVacancy {
+	var singles []models.MergedVacancy
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		for _, vacancy := range result.Vacancies {
+			singles = append(singles, models.MergedVacancy{
+				Vacancy:    vacancy,
+				Postings:   []models.VacancyPosting{posting(vacancy)},
+				Similarity: 1,
+			})
+		}
+	}
+	return singles
+}
+
+// функция получения публикации вакансии
+func posting(vacancy models.Vacancy) models.VacancyPosting {
+	salary := ""
+	if vacancy.Salary != nil {
+		salary = *vacancy.Salary
+	}
+	return models.VacancyPosting{Source: vacancy.Seeker, ID: vacancy.ID, URL: vacancy.URL, Salary: salary}
+}
+
+// функция извлечения нормализованных признаков вакансии
+func extract(vacancy models.Vacancy) features {
+	f := features{
+		source:   vacancy.Seeker,
+		company:  normalizeCompany(vacancy.Company),
+		title:    normalizeTitle(vacancy.Job),
+		city:     normalizeCity(vacancy.Area),
+		currency: normalizeCurrency(vacancy.Currency),
+	}
+
+	switch from, to := vacancy.SalaryFrom, vacancy.SalaryTo; {
+	case from > 0 && to > 0:
+		f.salary = float64(from+to) / 2
+	case from > 0:
+		f.salary = float64(from)
+	case to > 0:
+		f.salary = float64(to)
+	}
+	return f
+}
+
+// функция сходства двух публикаций (0..1)
+func similarity(a, b features, config Config) float64 {
+	company := dice(a.company, b.company)
+	if company < minCompanySimilarity {
+		return 0
+	}
+
+	return weightCompany*company +
+		weightTitle*dice(a.title, b.title) +
+		weightCity*citySimilarity(a.city, b.city) +
+		weightSalary*salarySimilarity(a, b, config.SalaryTolerance)
+}
+
+// функция сходства городов
+func citySimilarity(a, b string) float64 {
+	switch {
+	case a == "" || b == "":
+		return unknownSimilarity
+	case a == b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// функция сходства зарплат: в пределах допуска - 1, дальше линейно падает до 0 при тройном допуске
+func salarySimilarity(a, b features, tolerance float64) float64 {
+	if a.salary == 0 || b.salary == 0 {
+		return unknownSimilarity
+	}
+	if a.currency != "" && b.currency != "" && a.currency != b.currency {
+		return 0
+	}
+
+	diff := abs(a.salary-b.salary) / max(a.salary, b.salary)
+	switch {
+	case diff <= tolerance:
+		return 1
+	case tolerance <= 0 || diff >= 3*tolerance:
+		return 0
+	default:
+		return 1 - (diff-tolerance)/(2*tolerance)
+	}
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,190 @@
+package dedup
+
+import (
+	"errors"
+	"math"
+	"parser/internal/domain/models"
+	"reflect"
+	"testing"
+)
+
+// функция результата поиска одного источника (источник проставляется каждой вакансии)
+func result(source string, vacancies ...models.Vacancy) models.SearchVacanciesResult {
+	for i := range vacancies {
+		vacancies[i].Seeker = source
+	}
+	return models.SearchVacanciesResult{ParserName: source, Vacancies: vacancies}
+}
+
+func vacancy(id, company, job, area string, from, to int, currency string) models.Vacancy {
+	return models.Vacancy{ID: id, Company: company, Job: job, Area: area, SalaryFrom: from, SalaryTo: to, Currency: currency}
+}
+
+// функция публикаций записи в виде "источник:ID"
+func postings(merged models.MergedVacancy) []string {
+	keys := make([]string, len(merged.Postings))
+	for i, posting := range merged.Postings {
+		keys[i] = posting.Source + ":" + posting.ID
+	}
+	return keys
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name           string
+		results        []models.SearchVacanciesResult
+		want           [][]string // публикации каждой записи выдачи
+		wantSimilarity []float64  // сходство каждой записи
+	}{
+		{
+			name: "exact match after normalization",
+			results: []models.SearchVacanciesResult{
+				result("hh", vacancy("1", "ООО «Яндекс»", "Golang developer", "Москва", 200000, 300000, "RUR")),
+				result("superjob", vacancy("2", "Яндекс", "Go разработчик", "москва", 200000, 300000, "rub")),
+			},
+			want:           [][]string{{"hh:1", "superjob:2"}},
+			wantSimilarity: []float64{1},
+		},
+		{
+			// название: {ведущий, go, разработчик} и {go, разработчик} - 0.8, зарплаты в пределах допуска
+			name: "near duplicate",
+			results: []models.SearchVacanciesResult{
+				result("hh", vacancy("1", "Яндекс", "Senior Go developer", "Москва", 250000, 0, "RUR")),
+				result("superjob", vacancy("2", "Яндекс", "Go разработчик", "Москва", 270000, 0, "rub")),
+			},
+			want:           [][]string{{"hh:1", "superjob:2"}},
+			wantSimilarity: []float64{0.93},
+		},
+		{
+			// 0.35 (работодатель) + 0.35*0.5 (название) + 0 (город) + 0.15*0.5 (зарплата неизвестна) = 0.6
+			name: "below threshold",
+			results: []models.SearchVacanciesResult{
+				result("hh", vacancy("1", "Яндекс", "Go разработчик", "Москва", 0, 0, "")),
+				result("superjob", vacancy("2", "Яндекс", "Java разработчик", "Санкт-Петербург", 0, 0, "")),
+			},
+			want:           [][]string{{"hh:1"}, {"superjob:2"}},
+			wantSimilarity: []float64{1, 1},
+		},
+		{
+			name: "different employers are never merged",
+			results: []models.SearchVacanciesResult{
+				result("hh", vacancy("1", "Яндекс", "Go разработчик", "Москва", 200000, 0, "RUR")),
+				result("superjob", vacancy("2", "Ozon", "Go разработчик", "Москва", 200000, 0, "rub")),
+			},
+			want:           [][]string{{"hh:1"}, {"superjob:2"}},
+			wantSimilarity: []float64{1, 1},
+		},
+		{
+			name: "salary in another currency",
+			results: []models.SearchVacanciesResult{
+				result("hh", vacancy("1", "Яндекс", "Go разработчик", "", 3000, 0, "USD")),
+				result("superjob", vacancy("2", "Яндекс", "Go разработчик", "", 3000, 0, "rub")),
+			},
+			// 0.35 + 0.35 + 0.15*0.5 (город неизвестен) + 0 = 0.775
+			want:           [][]string{{"hh:1"}, {"superjob:2"}},
+			wantSimilarity: []float64{1, 1},
+		},
+		{
+			name: "postings of one source are not merged",
+			results: []models.SearchVacanciesResult{
+				result("hh",
+					vacancy("1", "Яндекс", "Go разработчик", "Москва", 200000, 0, "RUR"),
+					vacancy("2", "Яндекс", "Go разработчик", "Москва", 200000, 0, "RUR"),
+				),
+			},
+			want:           [][]string{{"hh:1"}, {"hh:2"}},
+			wantSimilarity: []float64{1, 1},
+		},
+		{
+			name: "merged source list keeps the order of sources",
+			results: []models.SearchVacanciesResult{
+				result("hh",
+					vacancy("h1", "Яндекс", "Go разработчик", "Москва", 200000, 0, "RUR"),
+					vacancy("h2", "Ozon", "Java разработчик", "Москва", 0, 0, ""),
+				),
+				result("superjob",
+					vacancy("s1", "Ozon", "Java developer", "Москва", 0, 0, ""),
+					vacancy("s2", "Яндекс", "Golang разработчик", "Москва", 210000, 0, "rub"),
+				),
+				result("habr", vacancy("r1", "ООО Яндекс", "Go программист", "Москва", 200000, 0, "rub")),
+			},
+			want:           [][]string{{"hh:h1", "superjob:s2", "habr:r1"}, {"hh:h2", "superjob:s1"}},
+			wantSimilarity: []float64{1, 0.925},
+		},
+		{
+			name: "failed source is skipped",
+			results: []models.SearchVacanciesResult{
+				{ParserName: "hh", Error: errors.New("timeout"), Vacancies: []models.Vacancy{vacancy("1", "Яндекс", "Go", "", 0, 0, "")}},
+				result("superjob", vacancy("2", "Яндекс", "Go разработчик", "Москва", 0, 0, "")),
+			},
+			want:           [][]string{{"superjob:2"}},
+			wantSimilarity: []float64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := Merge(tt.results, DefaultConfig())
+
+			got := make([][]string, len(merged))
+			for i, m := range merged {
+				got[i] = postings(m)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("postings = %v, want %v", got, tt.want)
+			}
+
+			for i, m := range merged {
+				if math.Abs(m.Similarity-tt.wantSimilarity[i]) > 1e-9 {
+					t.Errorf("record %d similarity = %v, want %v", i, m.Similarity, tt.wantSimilarity[i])
+				}
+				// основная публикация - первая в списке
+				if m.ID != m.Postings[0].ID || m.Seeker != m.Postings[0].Source {
+					t.Errorf("record %d main vacancy = %s:%s, want the first posting %s", i, m.Seeker, m.ID, got[i][0])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeThreshold(t *testing.T) {
+	// сходство пары - 0.93 (см. near duplicate в TestMerge)
+	results := []models.SearchVacanciesResult{
+		result("hh", vacancy("1", "Яндекс", "Senior Go developer", "Москва", 250000, 0, "RUR")),
+		result("superjob", vacancy("2", "Яндекс", "Go разработчик", "Москва", 270000, 0, "rub")),
+	}
+
+	tests := []struct {
+		threshold float64
+		wantCount int
+	}{
+		{threshold: 0.9, wantCount: 1},
+		{threshold: 0.8, wantCount: 1},
+		{threshold: 0.95, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		config := DefaultConfig()
+		config.Threshold = tt.threshold
+		if got := len(Merge(results, config)); got != tt.wantCount {
+			t.Errorf("threshold %v: %d records, want %d", tt.threshold, got, tt.wantCount)
+		}
+	}
+}
+
+func TestSingles(t *testing.T) {
+	results := []models.SearchVacanciesResult{
+		result("hh", vacancy("1", "Яндекс", "Go разработчик", "Москва", 0, 0, "")),
+		{ParserName: "habr", Error: errors.New("timeout")},
+		result("superjob", vacancy("2", "Яндекс", "Go разработчик", "Москва", 0, 0, "")),
+	}
+
+	singles := Singles(results)
+	got := make([][]string, len(singles))
+	for i, s := range singles {
+		got[i] = postings(s)
+	}
+	if want := [][]string{{"hh:1"}, {"superjob:2"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("postings = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package dedup
+
+import (
+	"strings"
+	"unicode"
+)
+
+// организационно-правовые формы и прочие слова, не отличающие одного работодателя от другого
+var companyNoise = map[string]struct{}{
+	"ооо": {}, "оао": {}, "зао": {}, "пао": {}, "ао": {}, "ип": {}, "нко": {}, "гк": {},
+	"llc": {}, "ltd": {}, "inc": {}, "gmbh": {}, "corp": {}, "co": {},
+	"компания": {}, "группа": {}, "group": {},
+}
+
+// слова названия вакансии, которые источники пишут по-разному (приводим к одному виду)
+var titleSynonyms = map[string]string{
+	"golang":      "go",
+	"developer":   "разработчик",
+	"программист": "разработчик",
+	"engineer":    "инженер",
+	"senior":      "ведущий",
+	"старший":     "ведущий",
+	"junior":      "младший",
+	"middle":      "",
+	"вакансия":    "",
+}
+
+// валюты, которые источники называют по-разному
+var currencyAliases = map[string]string{
+	"rur": "rub",
+	"руб": "rub",
+}
+
+// функция разбиения строки на нормализованные слова: нижний регистр, ё -> е, без знаков препинания
+func words(s string) []string {
+	s = strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// функция нормализации названия работодателя
+func normalizeCompany(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range words(s) {
+		if _, noise := companyNoise[word]; noise {
+			continue
+		}
+		set[word] = struct{}{}
+	}
+	return set
+}
+
+// функция нормализации названия вакансии
+func normalizeTitle(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range words(s) {
+		if synonym, ok := titleSynonyms[word]; ok {
+			word = synonym
+		}
+		if word != "" {
+			set[word] = struct{}{}
+		}
+	}
+	return set
+}
+
+// функция нормализации города
+func normalizeCity(s string) string {
+	return strings.Join(words(s), " ")
+}
+
+// функция нормализации валюты
+func normalizeCurrency(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if alias, ok := currencyAliases[s]; ok {
+		return alias
+	}
+	return s
+}
+
+// функция коэффициента Дайса для множеств слов (0 - ничего общего, 1 - совпадают)
+func dice(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for word := range a {
+		if _, ok := b[word]; ok {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
@@ -0,0 +1,16 @@
+package models
+
+// VacancyRef - ссылка на вакансию в конкретном источнике
+type VacancyRef struct {
+	Source    string
+	VacancyID string
+}
+
+// BatchDetailsItemResult - результат получения деталей одной вакансии из пачки
+type BatchDetailsItemResult struct {
+	Source    string
+	VacancyID string
+	Result    SearchVacancyDetailesResult
+	FromCache bool  // детали взяты из кэша деталей вакансий, запрос к источнику не делался
+	Error     error // ошибка по этой вакансии (остальные вакансии пачки от неё не зависят)
+}
@@ -0,0 +1,17 @@
+package models
+
+// VacancyPosting - публикация вакансии в конкретном источнике
+type VacancyPosting struct {
+	Source string
+	ID     string
+	URL    string
+	Salary string
+}
+
+// MergedVacancy - вакансия, объединённая по всем источникам, где она опубликована
+type MergedVacancy struct {
+	Vacancy                     // основная публикация (первая в порядке источников)
+	Postings   []VacancyPosting // все публикации, включая основную
+	Similarity float64          // наименьшее сходство публикаций с основной (1 - публикация одна)
+	Score      float64          // оценка ранжирования в общей выдаче (0..1)
+}
@@ -4,10 +4,11 @@ import "time"
 
 // общая структура поиска
 type SearchParams struct {
-	Text    string
-	Area    string
-	PerPage int
-	Page    int
+	Text    string   `json:"text"`
+	Area    string   `json:"area,omitempty"`
+	PerPage int      `json:"per_page"`
+	Page    int      `json:"page,omitempty"`
+	Sources []string `json:"sources,omitempty"` // в каких источниках искать (пусто - источники выбирает балансировщик)
 }
 
 // Стуктура общей вакансии для всех ответов
@@ -16,6 +17,8 @@ type Vacancy struct {
 	Job         string
 	Company     string
 	Salary      *string
+	SalaryFrom  int // нижняя граница вилки зарплаты (0 - не указана)
+	SalaryTo    int // верхняя граница вилки зарплаты (0 - не указана)
 	Currency    string
 	Area        string
 	Experience  string
@@ -24,6 +27,7 @@ type Vacancy struct {
 	PublishedAt time.Time
 	Seeker      string // "hh", "superjob", ...
 	Description string
+	Skills      []string // теги навыков (тех-стек), нормализованные по словарю
 }
 
 // Структура для определния результатов поиска списка вакансий по всем доступным парсерам
@@ -64,4 +68,6 @@ type SearchVacancyDetailesResult struct {
 	Name        string
 	ID          string
 	Url         string
+	Skills      []string // навыки: key_skills от источника или извлечённые из описания
+	Archived    bool     // вакансия в архиве у источника (закрыта)
 }
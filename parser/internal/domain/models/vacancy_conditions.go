@@ -0,0 +1,19 @@
+package models
+
+// общие для всех источников значения опыта работы (Vacancy.Experience), источники называют их по-разному
+const (
+	ExperienceNone     = "Нет опыта"
+	ExperienceFrom1To3 = "1-3 года"
+	ExperienceFrom3To6 = "3-6 лет"
+	ExperienceMore6    = "Более 6 лет"
+)
+
+// общие для всех источников значения графика работы (Vacancy.Schedule)
+const (
+	ScheduleFullDay  = "Полный день"
+	SchedulePartTime = "Неполный день"
+	ScheduleShift    = "Сменный график"
+	ScheduleFlexible = "Гибкий график"
+	ScheduleRemote   = "Удалённая работа"
+	ScheduleRotation = "Вахта"
+)
@@ -0,0 +1,33 @@
+package facets
+
+import "fmt"
+
+// Config - конфигурация фасетов выдачи мульти-поиска
+type Config struct {
+	SalaryBands []int `yaml:"salary_bands"` // границы диапазонов зарплаты по возрастанию (100000, 150000 - "до 100 000", "100 000 - 150 000", "от 150 000")
+	MaxValues   int   `yaml:"max_values"`   // сколько самых частых значений фасета выводить (0 - все)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		SalaryBands: []int{100_000, 150_000, 200_000, 300_000},
+		MaxValues:   10,
+	}
+}
+
+// Validate проверяет конфиг
+func (c Config) Validate() error {
+	for i, band := range c.SalaryBands {
+		if band <= 0 {
+			return fmt.Errorf("facets: salary_bands must be positive, got %d", band)
+		}
+		if i > 0 && band <= c.SalaryBands[i-1] {
+			return fmt.Errorf("facets: salary_bands must be strictly increasing, got %v", c.SalaryBands)
+		}
+	}
+	if c.MaxValues < 0 {
+		return fmt.Errorf("facets: max_values must not be negative, got %d", c.MaxValues)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// фасеты выдачи мульти-поиска: количество вакансий по городу, работодателю, опыту, графику, диапазону зарплаты и источнику
+// выбранные значения фасетов сужают уже полученную выдачу без новых запросов к источникам
+package facets
+
+import (
+	"parser/internal/domain/models"
+	"parser/pkg"
+	"sort"
+	"strings"
+)
+
+// Dimension - признак, по которому считается фасет
+type Dimension string
+
+const (
+	DimensionCity       Dimension = "city"
+	DimensionEmployer   Dimension = "employer"
+	DimensionExperience Dimension = "experience"
+	DimensionSchedule   Dimension = "schedule"
+	DimensionSalary     Dimension = "salary"
+	DimensionSource     Dimension = "source"
+)
+
+// Dimensions - все признаки в порядке вывода
+var Dimensions = []Dimension{DimensionCity, DimensionEmployer, DimensionExperience, DimensionSchedule, DimensionSalary, DimensionSource}
+
+// Unknown - значение признака, который в вакансии не указан
+const Unknown = "не указано"
+
+// Title возвращает название признака для вывода пользователю
+func (d Dimension) Title() string {
+	switch d {
+	case DimensionCity:
+		return "Город"
+	case DimensionEmployer:
+		return "Работодатель"
+	case DimensionExperience:
+		return "Опыт"
+	case DimensionSchedule:
+		return "График"
+	case DimensionSalary:
+		return "Зарплата"
+	case DimensionSource:
+		return "Источник"
+	default:
+		return string(d)
+	}
+}
+
+// Value - значение фасета и количество вакансий с ним
+type Value struct {
+	Value string
+	Count int
+}
+
+// Facet - значения одного признака по убыванию количества вакансий (Unknown - в конце)
+type Facet struct {
+	Dimension Dimension
+	Values    []Value
+}
+
+// Selection - выбранные значения фасетов (по одному на признак), вакансия должна подходить под все
+type Selection map[Dimension]string
+
+// Compute считает фасеты по вакансиям
+// вакансия, опубликованная в нескольких источниках, учитывается в фасете источника у каждого из них
+func Compute(vacancies []models.MergedVacancy, config Config) []Facet {
+	facets := make([]Facet, 0, len(Dimensions))
+	for _, dimension := range Dimensions {
+		counts := make(map[string]int)
+		for _, vacancy := range vacancies {
+			for _, value := range values(vacancy, dimension, config) {
+				counts[value]++
+			}
+		}
+
+		facet := Facet{Dimension: dimension, Values: make([]Value, 0, len(counts))}
+		for value, count := range counts {
+			facet.Values = append(facet.Values, Value{Value: value, Count: count})
+		}
+		sort.Slice(facet.Values, func(i, j int) bool {
+			a, b := facet.Values[i], facet.Values[j]
+			if (a.Value == Unknown) != (b.Value == Unknown) {
+				return b.Value == Unknown
+			}
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Value < b.Value
+		})
+		facets = append(facets, facet)
+	}
+	return facets
+}
+
+// Filter оставляет вакансии, подходящие под все выбранные значения фасетов (порядок сохраняется)
+func Filter(vacancies []models.MergedVacancy, selection Selection, config Config) []models.MergedVacancy {
+	if len(selection) == 0 {
+		return vacancies
+	}
+
+	filtered := make([]models.MergedVacancy, 0, len(vacancies))
+	for _, vacancy := range vacancies {
+		if matches(vacancy, selection, config) {
+			filtered = append(filtered, vacancy)
+		}
+	}
+	return filtered
+}
+
+// функция проверки вакансии на соответствие выбранным значениям фасетов
+func matches(vacancy models.MergedVacancy, selection Selection, config Config) bool {
+	for dimension, selected := range selection {
+		found := false
+		for _, value := range values(vacancy, dimension, config) {
+			if value == selected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// функция получения значений признака вакансии (у всех признаков, кроме источника, значение одно)
+func values(vacancy models.MergedVacancy, dimension Dimension, config Config) []string {
+	switch dimension {
+	case DimensionCity:
+		return []string{orUnknown(vacancy.Area)}
+	case DimensionEmployer:
+		return []string{orUnknown(vacancy.Company)}
+	case DimensionExperience:
+		return []string{orUnknown(vacancy.Experience)}
+	case DimensionSchedule:
+		return []string{orUnknown(vacancy.Schedule)}
+	case DimensionSalary:
+		return []string{salaryBand(vacancy.Vacancy, config.SalaryBands)}
+	case DimensionSource:
+		if len(vacancy.Postings) == 0 {
+			return []string{orUnknown(vacancy.Seeker)}
+		}
+		sources := make([]string, 0, len(vacancy.Postings))
+		for _, posting := range vacancy.Postings {
+			sources = append(sources, posting.Source)
+		}
+		return sources
+	default:
+		return nil
+	}
+}
+
+// функция получения диапазона зарплаты: по середине вилки или по её известной границе
+func salaryBand(vacancy models.Vacancy, bands []int) string {
+	salary := 0
+	switch from, to := vacancy.SalaryFrom, vacancy.SalaryTo; {
+	case from > 0 && to > 0:
+		salary = (from + to) / 2
+	case from > 0:
+		salary = from
+	case to > 0:
+		salary = to
+	}
+	if salary == 0 || len(bands) == 0 {
+		return Unknown
+	}
+
+	if salary < bands[0] {
+		return bandTitle(0, bands[0])
+	}
+	for i := 1; i < len(bands); i++ {
+		if salary < bands[i] {
+			return bandTitle(bands[i-1], bands[i])
+		}
+	}
+	return bandTitle(bands[len(bands)-1], 0)
+}
+
+// функция названия диапазона зарплаты: "до 100 000", "100 000 - 150 000", "от 300 000"
+func bandTitle(from, to int) string {
+	return strings.TrimSpace(pkg.FormatSalary(from, to, ""))
+}
+
+// функция замены пустого значения признака на Unknown
+func orUnknown(value string) string {
+	if value = strings.TrimSpace(value); value == "" {
+		return Unknown
+	}
+	return value
+}
@@ -0,0 +1,32 @@
+// локальные фейковые API HH.ru и SuperJob для разработки и интеграционных тестов менеджера парсеров
+// отдают сгенерированные вакансии с пагинацией и умеют имитировать типичные сбои источников (429, 5xx, медленные ответы),
+// а также закрытые (архивные) и удалённые вакансии
+package fakesource
+
+import "time"
+
+// Config - конфигурация фейкового источника
+type Config struct {
+	Addr       string        // адрес, на котором слушает сервер (":0" - любой свободный порт)
+	Vacancies  int           // количество сгенерированных вакансий
+	Seed       int64         // зерно генератора: одно и то же зерно - одни и те же данные
+	Rate429    float64       // доля ответов 429 Too Many Requests (с Retry-After)
+	Rate5xx    float64       // доля ответов 500/502/503
+	SlowRate   float64       // доля медленных ответов
+	SlowDelay  time.Duration // задержка медленного ответа
+	RetryAfter time.Duration // значение Retry-After в ответах 429
+
+	ArchivedRate float64 // доля вакансий в архиве: не попадают в поиск, детали отдаются с archived / is_archive
+	RemovedRate  float64 // доля удалённых вакансий: не попадают в поиск, детали отвечают 404
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию (без сбоев, любой свободный порт)
+func DefaultConfig() Config {
+	return Config{
+		Addr:       "127.0.0.1:0",
+		Vacancies:  500,
+		Seed:       1,
+		SlowDelay:  3 * time.Second,
+		RetryAfter: time.Second,
+	}
+}
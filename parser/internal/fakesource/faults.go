@@ -0,0 +1,74 @@
+package fakesource
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// заголовок (или параметр запроса fake_error), которым можно принудительно вызвать сбой:
+// 429, 500, 502, 503 или slow - удобно для детерминированных интеграционных тестов
+const FaultHeader = "X-Fake-Error"
+
+// тип функции записи ошибки в формате конкретного источника
+type errorWriter func(w http.ResponseWriter, status int, message string)
+
+// метод выбора сбоя для запроса: сначала принудительный (заголовок / параметр), затем случайный по долям из конфига
+func (s *Server) pickFault(r *http.Request) string {
+	if forced := r.Header.Get(FaultHeader); forced != "" {
+		return strings.ToLower(forced)
+	}
+	if forced := r.URL.Query().Get("fake_error"); forced != "" {
+		return strings.ToLower(forced)
+	}
+
+	s.mu.Lock()
+	roll := s.rnd.Float64()
+	serverError := s.rnd.Intn(3)
+	s.mu.Unlock()
+
+	switch {
+	case roll < s.config.Rate429:
+		return "429"
+	case roll < s.config.Rate429+s.config.Rate5xx:
+		// распределяем серверные ошибки между типичными кодами
+		return [...]string{"500", "502", "503"}[serverError]
+	case roll < s.config.Rate429+s.config.Rate5xx+s.config.SlowRate:
+		return "slow"
+	}
+	return ""
+}
+
+// middleware имитации сбоев источника
+func (s *Server) withFaults(next http.Handler, writeError errorWriter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.countRequest()
+
+		switch fault := s.pickFault(r); fault {
+		case "":
+		case "slow":
+			// медленный ответ: ждём, но не дольше, чем клиент готов ждать
+			select {
+			case <-time.After(s.config.SlowDelay):
+			case <-r.Context().Done():
+				return
+			}
+		case "429":
+			s.countFault()
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, int(s.config.RetryAfter.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, "too many requests")
+			return
+		default:
+			status, err := strconv.Atoi(fault)
+			if err != nil || status < 400 || status > 599 {
+				status = http.StatusInternalServerError
+			}
+			s.countFault()
+			writeError(w, status, http.StatusText(status))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
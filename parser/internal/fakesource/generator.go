@@ -0,0 +1,172 @@
+package fakesource
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// сгенерированная вакансия (общая модель, из которой строятся ответы в формате HH.ru и SuperJob)
+type fakeVacancy struct {
+	ID          int
+	Name        string
+	Company     string
+	CompanyID   int
+	AreaID      string
+	AreaName    string
+	SalaryFrom  int
+	SalaryTo    int
+	Currency    string
+	Skills      []string
+	Description string
+	Age         time.Duration // сколько времени назад опубликована вакансия (относительно старта сервера)
+	Experience  experience    // требуемый опыт работы
+	Schedule    schedule      // график работы
+}
+
+// требуемый опыт работы (каждый источник отдаёт его в своём справочнике)
+type experience int
+
+const (
+	experienceNone experience = iota
+	experience1To3
+	experience3To6
+	experienceMore6
+)
+
+// график работы (каждый источник отдаёт его в своём справочнике)
+type schedule int
+
+const (
+	scheduleFullDay schedule = iota
+	scheduleRemote
+	scheduleFlexible
+	scheduleShift
+)
+
+// шаблон профессии: название и типичный стек
+type profession struct {
+	name   string
+	skills []string
+}
+
+// справочники для генерации
+var (
+	professions = []profession{
+		{"Go разработчик", []string{"Go", "PostgreSQL", "Docker", "Kubernetes", "gRPC", "Redis"}},
+		{"Backend разработчик (Golang)", []string{"Go", "Kafka", "PostgreSQL", "Linux"}},
+		{"Python разработчик", []string{"Python", "Django", "PostgreSQL", "Celery", "Docker"}},
+		{"Java разработчик", []string{"Java", "Spring", "Kafka", "PostgreSQL"}},
+		{"Frontend разработчик", []string{"JavaScript", "TypeScript", "React", "Redux"}},
+		{"Fullstack разработчик", []string{"TypeScript", "Node.js", "React", "MongoDB"}},
+		{"DevOps инженер", []string{"Kubernetes", "Docker", "Terraform", "Ansible", "Linux"}},
+		{"Data Engineer", []string{"Python", "Spark", "Airflow", "ClickHouse"}},
+		{"QA Automation инженер", []string{"Python", "Selenium", "Pytest"}},
+		{"1С программист", []string{"1C", "SQL"}},
+	}
+
+	grades    = []string{"Junior", "Middle", "Senior", "Lead"}
+	companies = []string{"Рога и Копыта", "ТехноСофт", "Облачные Решения", "ФинТех Лаб", "Ритейл Диджитал", "ГеоСервис", "МедИнфо", "Логистик Про"}
+
+	// опыт, который обычно требуют для грейда (индексы совпадают с grades)
+	gradeExperience = []experience{experienceNone, experience1To3, experience3To6, experienceMore6}
+	// распределение графиков работы: полный день встречается чаще остальных
+	schedules = []schedule{scheduleFullDay, scheduleFullDay, scheduleFullDay, scheduleRemote, scheduleRemote, scheduleFlexible, scheduleShift}
+
+	areas = []struct {
+		id   string
+		name string
+	}{
+		{"1", "Москва"},
+		{"2", "Санкт-Петербург"},
+		{"3", "Екатеринбург"},
+		{"4", "Новосибирск"},
+		{"88", "Казань"},
+	}
+)
+
+// функция генерации набора вакансий (детерминированно по зерну)
+// firstID - первый идентификатор, чтобы ID разных источников не пересекались
+func generateVacancies(count int, seed int64, firstID int) []fakeVacancy {
+	rnd := rand.New(rand.NewSource(seed))
+	// возраст публикаций и график берём из отдельного генератора, чтобы не менять остальные данные для прежних зёрен
+	extraRnd := rand.New(rand.NewSource(seed + 2))
+	vacancies := make([]fakeVacancy, 0, count)
+
+	for i := 0; i < count; i++ {
+		prof := professions[rnd.Intn(len(professions))]
+		gradeIndex := rnd.Intn(len(grades))
+		grade := grades[gradeIndex]
+		companyIndex := rnd.Intn(len(companies))
+		area := areas[rnd.Intn(len(areas))]
+
+		// у части вакансий зарплата не указана или указана только одна граница - как в реальных данных
+		salaryFrom, salaryTo := 0, 0
+		switch rnd.Intn(4) {
+		case 0:
+		case 1:
+			salaryFrom = 80_000 + rnd.Intn(200)*1000
+		default:
+			salaryFrom = 80_000 + rnd.Intn(200)*1000
+			salaryTo = salaryFrom + 20_000 + rnd.Intn(100)*1000
+		}
+
+		// стек вакансии - случайное подмножество типичного стека профессии (первый навык есть всегда)
+		vacancySkills := []string{prof.skills[0]}
+		for _, skill := range prof.skills[1:] {
+			if rnd.Intn(2) == 0 {
+				vacancySkills = append(vacancySkills, skill)
+			}
+		}
+
+		name := grade + " " + prof.name
+		vacancies = append(vacancies, fakeVacancy{
+			ID:         firstID + i,
+			Name:       name,
+			Company:    companies[companyIndex],
+			CompanyID:  1000 + companyIndex,
+			AreaID:     area.id,
+			AreaName:   area.name,
+			SalaryFrom: salaryFrom,
+			SalaryTo:   salaryTo,
+			Currency:   "RUR",
+			Skills:     vacancySkills,
+			Description: fmt.Sprintf(
+				"<p>Компания %s ищет специалиста на позицию %s.</p><p><strong>Стек:</strong> %s.</p><p>Офис в городе %s, возможна удалённая работа.</p>",
+				companies[companyIndex], name, strings.Join(vacancySkills, ", "), area.name,
+			),
+			Age:        time.Duration(extraRnd.Intn(30*24*60)) * time.Minute, // в пределах 30 дней
+			Experience: gradeExperience[gradeIndex],
+			Schedule:   schedules[extraRnd.Intn(len(schedules))],
+		})
+	}
+
+	return vacancies
+}
+
+// метод проверки соответствия вакансии поисковому запросу (все слова запроса должны встретиться в названии, навыках или описании)
+func (v fakeVacancy) matches(text string) bool {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return true
+	}
+
+	haystack := strings.ToLower(v.Name + " " + strings.Join(v.Skills, " ") + " " + v.Description)
+	for _, word := range words {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// функция выбора страницы из отфильтрованных вакансий
+func paginate(vacancies []fakeVacancy, page, perPage int) []fakeVacancy {
+	start := page * perPage
+	if start >= len(vacancies) {
+		return nil
+	}
+	end := min(start+perPage, len(vacancies))
+	return vacancies[start:end]
+}
@@ -0,0 +1,239 @@
+package fakesource
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ограничения API HH.ru
+const (
+	hhDefaultPerPage = 20
+	hhMaxPerPage     = 100
+	hhMaxDepth       = 2000 // HH.ru не отдаёт больше 2000 вакансий по одному запросу (page * per_page)
+)
+
+// структуры ответов в формате API HH.ru (только поля, которые реально используются парсерами и UI)
+type hhSalary struct {
+	From     *int   `json:"from"`
+	To       *int   `json:"to"`
+	Currency string `json:"currency"`
+	Gross    bool   `json:"gross"`
+}
+
+type hhRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type hhVacancy struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Salary       *hhSalary `json:"salary"`
+	Employer     hhRef     `json:"employer"`
+	Area         hhRef     `json:"area"`
+	URL          string    `json:"url"`
+	AlternateURL string    `json:"alternate_url"`
+	PublishedAt  string    `json:"published_at"`
+	Experience   hhRef     `json:"experience"`
+	Schedule     hhRef     `json:"schedule"`
+}
+
+// справочники опыта и графика работы HH.ru
+var (
+	hhExperience = map[experience]hhRef{
+		experienceNone:  {ID: "noExperience", Name: "Нет опыта"},
+		experience1To3:  {ID: "between1And3", Name: "От 1 года до 3 лет"},
+		experience3To6:  {ID: "between3And6", Name: "От 3 до 6 лет"},
+		experienceMore6: {ID: "moreThan6", Name: "Более 6 лет"},
+	}
+	hhSchedule = map[schedule]hhRef{
+		scheduleFullDay:  {ID: "fullDay", Name: "Полный день"},
+		scheduleRemote:   {ID: "remote", Name: "Удаленная работа"},
+		scheduleFlexible: {ID: "flexible", Name: "Гибкий график"},
+		scheduleShift:    {ID: "shift", Name: "Сменный график"},
+	}
+)
+
+type hhSearchResponse struct {
+	Items   []hhVacancy `json:"items"`
+	Found   int         `json:"found"`
+	Pages   int         `json:"pages"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+}
+
+type hhKeySkill struct {
+	Name string `json:"name"`
+}
+
+type hhDetails struct {
+	hhVacancy
+	Description string       `json:"description"`
+	KeySkills   []hhKeySkill `json:"key_skills"`
+	Archived    bool         `json:"archived"`
+}
+
+type hhError struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+type hhErrorResponse struct {
+	Errors      []hhError `json:"errors"`
+	Description string    `json:"description,omitempty"`
+}
+
+// NewHHServer создаёт фейковый API HH.ru: GET /vacancies и GET /vacancies/{id}
+func NewHHServer(config Config) *Server {
+	s := newServer("fake HH.ru", "/vacancies", config, 90_000_000)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveHH)
+	s.handler = s.withFaults(mux, writeHHError)
+
+	return s
+}
+
+// маршрутизация запросов HH.ru
+func (s *Server) serveHH(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeHHError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	segments := pathSegments(r.URL.Path)
+	switch {
+	case len(segments) == 1 && segments[0] == "vacancies":
+		s.hhSearch(w, r)
+	case len(segments) == 2 && segments[0] == "vacancies":
+		s.hhDetails(w, r, segments[1])
+	default:
+		writeHHError(w, http.StatusNotFound, "not_found")
+	}
+}
+
+// поиск вакансий: text, area, page (с нуля), per_page
+func (s *Server) hhSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	perPage := hhDefaultPerPage
+	if value := query.Get("per_page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > hhMaxPerPage {
+			writeJSON(w, r, http.StatusBadRequest, hhErrorResponse{Errors: []hhError{{Type: "bad_argument", Value: "per_page"}}}, false)
+			return
+		}
+		perPage = parsed
+	}
+
+	page := 0
+	if value := query.Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeJSON(w, r, http.StatusBadRequest, hhErrorResponse{Errors: []hhError{{Type: "bad_argument", Value: "page"}}}, false)
+			return
+		}
+		page = parsed
+	}
+
+	if (page+1)*perPage > hhMaxDepth {
+		writeJSON(w, r, http.StatusBadRequest, hhErrorResponse{
+			Errors:      []hhError{{Type: "bad_argument", Value: "page"}},
+			Description: "depth of search results is limited to 2000",
+		}, false)
+		return
+	}
+
+	text, area := query.Get("text"), query.Get("area")
+	var found []fakeVacancy
+	for _, vacancy := range s.vacancies {
+		if area != "" && vacancy.AreaID != area {
+			continue
+		}
+		if vacancy.matches(text) && s.searchable(vacancy) {
+			found = append(found, vacancy)
+		}
+	}
+
+	items := make([]hhVacancy, 0, perPage)
+	for _, vacancy := range paginate(found, page, perPage) {
+		items = append(items, s.toHHVacancy(r, vacancy))
+	}
+
+	writeJSON(w, r, http.StatusOK, hhSearchResponse{
+		Items:   items,
+		Found:   len(found),
+		Pages:   (min(len(found), hhMaxDepth) + perPage - 1) / perPage,
+		Page:    page,
+		PerPage: perPage,
+	}, true)
+}
+
+// детали вакансии по ID
+func (s *Server) hhDetails(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	vacancy, ok := s.byID[id]
+	archived, removed := s.vacancyState(id)
+	if err != nil || !ok || removed {
+		writeHHError(w, http.StatusNotFound, "not_found")
+		return
+	}
+
+	keySkills := make([]hhKeySkill, 0, len(vacancy.Skills))
+	for _, skill := range vacancy.Skills {
+		keySkills = append(keySkills, hhKeySkill{Name: skill})
+	}
+
+	writeJSON(w, r, http.StatusOK, hhDetails{
+		hhVacancy:   s.toHHVacancy(r, vacancy),
+		Description: vacancy.Description,
+		KeySkills:   keySkills,
+		Archived:    archived,
+	}, true)
+}
+
+// метод преобразования сгенерированной вакансии в формат HH.ru
+func (s *Server) toHHVacancy(r *http.Request, vacancy fakeVacancy) hhVacancy {
+	id := strconv.Itoa(vacancy.ID)
+
+	var salary *hhSalary
+	if vacancy.SalaryFrom > 0 || vacancy.SalaryTo > 0 {
+		salary = &hhSalary{Currency: vacancy.Currency, Gross: true}
+		if vacancy.SalaryFrom > 0 {
+			salary.From = &vacancy.SalaryFrom
+		}
+		if vacancy.SalaryTo > 0 {
+			salary.To = &vacancy.SalaryTo
+		}
+	}
+
+	return hhVacancy{
+		ID:           id,
+		Name:         vacancy.Name,
+		Salary:       salary,
+		Employer:     hhRef{ID: strconv.Itoa(vacancy.CompanyID), Name: vacancy.Company},
+		Area:         hhRef{ID: vacancy.AreaID, Name: vacancy.AreaName},
+		URL:          "http://" + r.Host + s.basePath + "/" + id,
+		AlternateURL: "http://" + r.Host + "/vacancy/" + id,
+		PublishedAt:  s.publishedAt(vacancy).Format("2006-01-02T15:04:05-0700"),
+		Experience:   hhExperience[vacancy.Experience],
+		Schedule:     hhSchedule[vacancy.Schedule],
+	}
+}
+
+// функция записи ошибки в формате HH.ru
+func writeHHError(w http.ResponseWriter, status int, message string) {
+	errorType := "server_error"
+	switch status {
+	case http.StatusNotFound:
+		errorType = "not_found"
+	case http.StatusTooManyRequests:
+		errorType = "too_many_requests"
+	case http.StatusMethodNotAllowed:
+		errorType = "method_not_allowed"
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(mustJSON(hhErrorResponse{Errors: []hhError{{Type: errorType}}, Description: message}))
+}
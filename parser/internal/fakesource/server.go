@@ -0,0 +1,231 @@
+package fakesource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Server - фейковый источник вакансий (HH.ru или SuperJob)
+type Server struct {
+	name      string
+	config    Config
+	basePath  string // путь, который нужно указать в base_url парсера
+	vacancies []fakeVacancy
+	byID      map[int]fakeVacancy
+	handler   http.Handler
+	startedAt time.Time // точка отсчёта дат публикации вакансий
+
+	mu       sync.Mutex
+	rnd      *rand.Rand   // генератор для случайных сбоев
+	archived map[int]bool // вакансии, закрытые вызовом Archive
+	removed  map[int]bool // вакансии, удалённые вызовом Remove
+
+	listener   net.Listener
+	httpServer *http.Server
+
+	requests atomic.Int64 // всего запросов
+	faults   atomic.Int64 // запросов, завершённых имитированной ошибкой
+}
+
+// Stats - счётчики запросов фейкового источника
+type Stats struct {
+	Requests int64
+	Faults   int64
+}
+
+// конструктор общей части фейкового источника
+func newServer(name, basePath string, config Config, firstID int) *Server {
+	if config.Vacancies <= 0 {
+		config.Vacancies = DefaultConfig().Vacancies
+	}
+	if config.SlowDelay <= 0 {
+		config.SlowDelay = DefaultConfig().SlowDelay
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = DefaultConfig().RetryAfter
+	}
+
+	vacancies := generateVacancies(config.Vacancies, config.Seed, firstID)
+	byID := make(map[int]fakeVacancy, len(vacancies))
+	for _, vacancy := range vacancies {
+		byID[vacancy.ID] = vacancy
+	}
+
+	return &Server{
+		name:      name,
+		config:    config,
+		basePath:  basePath,
+		vacancies: vacancies,
+		byID:      byID,
+		startedAt: time.Now().Truncate(time.Minute),
+		rnd:       rand.New(rand.NewSource(config.Seed + 1)),
+		archived:  make(map[int]bool),
+		removed:   make(map[int]bool),
+	}
+}
+
+// Start запускает сервер на адресе из конфига (в отдельной горутине)
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.config.Addr)
+	if err != nil {
+		return fmt.Errorf("[%s] listen %s: %w", s.name, s.config.Addr, err)
+	}
+
+	s.listener = listener
+	s.httpServer = &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("❌ [%s] fake server stopped: %v\n", s.name, err)
+		}
+	}()
+
+	return nil
+}
+
+// Close останавливает сервер, дожидаясь завершения активных запросов (в пределах ctx)
+func (s *Server) Close(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Handler возвращает обработчик запросов (можно повесить на свой сервер или httptest)
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Name возвращает имя источника
+func (s *Server) Name() string {
+	return s.name
+}
+
+// URL возвращает адрес запущенного сервера (без пути)
+func (s *Server) URL() string {
+	if s.listener == nil {
+		return ""
+	}
+	return "http://" + s.listener.Addr().String()
+}
+
+// BaseURL возвращает значение для base_url в конфиге парсера
+func (s *Server) BaseURL() string {
+	return s.URL() + s.basePath
+}
+
+// Stats возвращает счётчики запросов
+func (s *Server) Stats() Stats {
+	return Stats{
+		Requests: s.requests.Load(),
+		Faults:   s.faults.Load(),
+	}
+}
+
+// Archive переводит вакансию в архив (как если бы работодатель её закрыл)
+func (s *Server) Archive(id string) {
+	if parsed, err := strconv.Atoi(id); err == nil {
+		s.mu.Lock()
+		s.archived[parsed] = true
+		s.mu.Unlock()
+	}
+}
+
+// Remove удаляет вакансию: её детали начинают отвечать 404
+func (s *Server) Remove(id string) {
+	if parsed, err := strconv.Atoi(id); err == nil {
+		s.mu.Lock()
+		s.removed[parsed] = true
+		s.mu.Unlock()
+	}
+}
+
+// метод получения состояния вакансии: в архиве / удалена (вызовами Archive, Remove или по долям из конфига)
+// доли детерминированы зерном и ID: архивные берутся из начала диапазона, удалённые - из конца, поэтому не пересекаются
+func (s *Server) vacancyState(id int) (archived, removed bool) {
+	s.mu.Lock()
+	archived, removed = s.archived[id], s.removed[id]
+	s.mu.Unlock()
+
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%d:%d", s.config.Seed, id)
+	roll := float64(hash.Sum64()%1_000_000) / 1_000_000
+
+	archived = archived || roll < s.config.ArchivedRate
+	removed = removed || roll >= 1-s.config.RemovedRate
+	return archived, removed
+}
+
+// метод проверки, попадает ли вакансия в поиск (архивные и удалённые источник не отдаёт)
+func (s *Server) searchable(vacancy fakeVacancy) bool {
+	archived, removed := s.vacancyState(vacancy.ID)
+	return !archived && !removed
+}
+
+// метод получения даты публикации вакансии
+func (s *Server) publishedAt(vacancy fakeVacancy) time.Time {
+	return s.startedAt.Add(-vacancy.Age)
+}
+
+// методы учёта запросов
+func (s *Server) countRequest() { s.requests.Add(1) }
+func (s *Server) countFault()   { s.faults.Add(1) }
+
+// функция разбора пути запроса на сегменты (пустые сегменты от "//" отбрасываются - парсеры склеивают base_url и ID через "/")
+func pathSegments(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// функция записи JSON ответа
+// если задан withETag - ответ помечается ETag и на совпадающий If-None-Match отвечаем 304 (как HH.ru)
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, payload interface{}, withETag bool) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if withETag && status == http.StatusOK {
+		hash := fnv.New64a()
+		_, _ = hash.Write(body)
+		etag := fmt.Sprintf(`"%x"`, hash.Sum64())
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// функция сериализации заранее известных структур (ошибка невозможна)
+func mustJSON(payload interface{}) []byte {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
@@ -0,0 +1,203 @@
+package fakesource
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ограничения API SuperJob
+const (
+	sjDefaultCount = 20
+	sjMaxCount     = 100
+	sjMaxDepth     = 500 // SuperJob отдаёт не больше 500 вакансий по одному запросу
+)
+
+// структуры ответов в формате API SuperJob
+type sjTown struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type sjRef struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type sjVacancy struct {
+	ID              int    `json:"id"`
+	Profession      string `json:"profession"`
+	FirmName        string `json:"firm_name"`
+	PaymentFrom     int    `json:"payment_from"`
+	PaymentTo       int    `json:"payment_to"`
+	Currency        string `json:"currency"`
+	Town            sjTown `json:"town"`
+	Link            string `json:"link"`
+	VacancyRichText string `json:"vacancyRichText"`
+	IsArchive       bool   `json:"is_archive"`
+	DatePublished   int64  `json:"date_published"`
+	Experience      sjRef  `json:"experience"`
+	TypeOfWork      sjRef  `json:"type_of_work"`
+	PlaceOfWork     sjRef  `json:"place_of_work"`
+}
+
+// справочники опыта, типа занятости и места работы SuperJob
+// гибкого графика в справочнике SuperJob нет - такие вакансии публикуются с полным рабочим днём
+var (
+	sjExperience = map[experience]sjRef{
+		experienceNone:  {ID: 1, Title: "Без опыта"},
+		experience1To3:  {ID: 2, Title: "От 1 года"},
+		experience3To6:  {ID: 3, Title: "От 3 лет"},
+		experienceMore6: {ID: 4, Title: "От 6 лет"},
+	}
+	sjFullDay     = sjRef{ID: 6, Title: "Полный рабочий день"}
+	sjShift       = sjRef{ID: 12, Title: "Сменный график работы"}
+	sjOffice      = sjRef{ID: 1, Title: "Работа в офисе"}
+	sjRemotePlace = sjRef{ID: 2, Title: "Удалённая работа (на дому)"}
+)
+
+type sjSearchResponse struct {
+	Objects []sjVacancy `json:"objects"`
+	Total   int         `json:"total"`
+	More    bool        `json:"more"`
+}
+
+type sjError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type sjErrorResponse struct {
+	Error sjError `json:"error"`
+}
+
+// NewSJServer создаёт фейковый API SuperJob: GET /2.0/vacancies/ и GET /2.0/vacancies/{id}
+func NewSJServer(config Config) *Server {
+	s := newServer("fake SuperJob.ru", "/2.0/vacancies/", config, 40_000_000)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveSJ)
+	s.handler = s.withFaults(mux, writeSJError)
+
+	return s
+}
+
+// маршрутизация запросов SuperJob
+func (s *Server) serveSJ(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeSJError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	segments := pathSegments(r.URL.Path)
+	switch {
+	case len(segments) == 2 && segments[0] == "2.0" && segments[1] == "vacancies":
+		s.sjSearch(w, r)
+	case len(segments) == 3 && segments[0] == "2.0" && segments[1] == "vacancies":
+		s.sjDetails(w, r, segments[2])
+	default:
+		writeSJError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// поиск вакансий: keyword, town (название), page (с нуля), count
+func (s *Server) sjSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	count := sjDefaultCount
+	if value := query.Get("count"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeSJError(w, http.StatusBadRequest, "Invalid count")
+			return
+		}
+		count = min(parsed, sjMaxCount)
+	}
+
+	page := 0
+	if value := query.Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			writeSJError(w, http.StatusBadRequest, "Invalid page")
+			return
+		}
+		page = parsed
+	}
+
+	keyword, town := query.Get("keyword"), query.Get("town")
+	var found []fakeVacancy
+	for _, vacancy := range s.vacancies {
+		if town != "" && !strings.EqualFold(vacancy.AreaName, town) {
+			continue
+		}
+		if vacancy.matches(keyword) && s.searchable(vacancy) {
+			found = append(found, vacancy)
+		}
+	}
+
+	// глубже лимита SuperJob просто возвращает пустую страницу
+	visible := found[:min(len(found), sjMaxDepth)]
+	pageItems := paginate(visible, page, count)
+
+	objects := make([]sjVacancy, 0, len(pageItems))
+	for _, vacancy := range pageItems {
+		objects = append(objects, s.toSJVacancy(r, vacancy))
+	}
+
+	writeJSON(w, r, http.StatusOK, sjSearchResponse{
+		Objects: objects,
+		Total:   len(found),
+		More:    (page+1)*count < len(visible),
+	}, false)
+}
+
+// детали вакансии по ID
+func (s *Server) sjDetails(w http.ResponseWriter, r *http.Request, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	vacancy, ok := s.byID[id]
+	archived, removed := s.vacancyState(id)
+	if err != nil || !ok || removed {
+		writeSJError(w, http.StatusNotFound, "Vacancy not found")
+		return
+	}
+
+	details := s.toSJVacancy(r, vacancy)
+	details.IsArchive = archived
+	writeJSON(w, r, http.StatusOK, details, false)
+}
+
+// метод преобразования сгенерированной вакансии в формат SuperJob
+func (s *Server) toSJVacancy(r *http.Request, vacancy fakeVacancy) sjVacancy {
+	townID, _ := strconv.Atoi(vacancy.AreaID)
+
+	typeOfWork, placeOfWork := sjFullDay, sjOffice
+	switch vacancy.Schedule {
+	case scheduleRemote:
+		placeOfWork = sjRemotePlace
+	case scheduleShift:
+		typeOfWork = sjShift
+	}
+
+	return sjVacancy{
+		ID:              vacancy.ID,
+		Profession:      vacancy.Name,
+		FirmName:        vacancy.Company,
+		PaymentFrom:     vacancy.SalaryFrom,
+		PaymentTo:       vacancy.SalaryTo,
+		Currency:        "rub",
+		Town:            sjTown{ID: townID, Title: vacancy.AreaName},
+		Link:            "http://" + r.Host + "/vakansii/" + strconv.Itoa(vacancy.ID) + ".html",
+		VacancyRichText: vacancy.Description,
+		DatePublished:   s.publishedAt(vacancy).Unix(),
+		Experience:      sjExperience[vacancy.Experience],
+		TypeOfWork:      typeOfWork,
+		PlaceOfWork:     placeOfWork,
+	}
+}
+
+// функция записи ошибки в формате SuperJob
+func writeSJError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(mustJSON(sjErrorResponse{Error: sjError{Code: status, Message: message}}))
+}
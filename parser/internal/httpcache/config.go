@@ -0,0 +1,23 @@
+package httpcache
+
+import "time"
+
+// Config - конфигурация транспортного кэша HTTP ответов (условные запросы по ETag / Last-Modified)
+type Config struct {
+	Enabled      bool          `yaml:"enabled"`        // включён ли кэш для клиента парсера
+	MaxEntries   int           `yaml:"max_entries"`    // максимальное количество сохранённых ответов (вытесняются самые старые по использованию)
+	MaxBodyBytes int64         `yaml:"max_body_bytes"` // ответы больше этого размера не сохраняются
+	FreshTTL     time.Duration `yaml:"fresh_ttl"`      // сколько после последней проверки ответ отдаётся без похода в сеть (0 - всегда проверяем у сервера)
+	EntryTTL     time.Duration `yaml:"entry_ttl"`      // сколько хранится сохранённый ответ с валидаторами
+}
+
+// DefaultConfig возвращает конфигурацию кэша по умолчанию (кэш выключен)
+func DefaultConfig() Config {
+	return Config{
+		Enabled:      false,
+		MaxEntries:   1000,
+		MaxBodyBytes: 1 << 20,
+		FreshTTL:     0,
+		EntryTTL:     24 * time.Hour,
+	}
+}
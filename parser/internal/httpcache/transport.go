@@ -0,0 +1,316 @@
+// транспортный уровень кэширования HTTP ответов для клиентов парсеров
+// сохраняет ETag и Last-Modified, отправляет условные запросы (If-None-Match / If-Modified-Since)
+// и отдаёт сохранённое тело, если сервер ответил 304 Not Modified
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// заголовок, который транспорт добавляет к ответу, чтобы было видно, откуда взят ответ
+const HeaderCacheStatus = "X-Transport-Cache"
+
+// значения заголовка HeaderCacheStatus
+const (
+	StatusHit         = "HIT"         // ответ отдан из хранилища без похода в сеть (в пределах FreshTTL)
+	StatusRevalidated = "REVALIDATED" // сервер ответил 304, тело отдано из хранилища
+	StatusMiss        = "MISS"        // ответ получен от сервера
+)
+
+// сохранённый ответ
+type entry struct {
+	key          string
+	statusCode   int
+	header       http.Header
+	body         []byte
+	etag         string
+	lastModified string
+	validatedAt  time.Time // время последнего подтверждения актуальности у сервера
+	storedAt     time.Time
+}
+
+// Stats - статистика работы транспортного кэша
+type Stats struct {
+	Hits        uint64 // ответов из хранилища без запроса к серверу
+	Revalidated uint64 // ответов 304, тело взято из хранилища
+	Misses      uint64 // обычных ответов от сервера
+	Entries     int    // текущее количество сохранённых ответов
+}
+
+// Transport - http.RoundTripper с поддержкой условных запросов
+type Transport struct {
+	next   http.RoundTripper
+	config Config
+
+	mu      sync.Mutex
+	entries map[string]*list.Element // ключ -> элемент списка LRU
+	lru     *list.List               // в начале - последние использованные
+
+	hits        atomic.Uint64
+	revalidated atomic.Uint64
+	misses      atomic.Uint64
+}
+
+// конструктор транспортного кэша поверх транспорта next
+func NewTransport(next http.RoundTripper, config Config) *Transport {
+	defaults := DefaultConfig()
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = defaults.MaxEntries
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	if config.EntryTTL <= 0 {
+		config.EntryTTL = defaults.EntryTTL
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{
+		next:    next,
+		config:  config,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// RoundTrip выполняет запрос с учётом сохранённых ответов
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// кэшируем только простые GET запросы
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached := t.lookup(key)
+
+	// ответ недавно подтверждён сервером - отдаём без похода в сеть
+	if cached != nil && t.isFresh(cached) {
+		t.hits.Add(1)
+		return cached.toResponse(req, StatusHit), nil
+	}
+
+	// есть сохранённый ответ - делаем условный запрос
+	outReq := req
+	if cached != nil {
+		outReq = req.Clone(req.Context())
+		if cached.etag != "" && outReq.Header.Get("If-None-Match") == "" {
+			outReq.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" && outReq.Header.Get("If-Modified-Since") == "" {
+			outReq.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// сервер подтвердил, что ответ не изменился - отдаём сохранённое тело
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+
+		t.markValidated(key, resp.Header)
+		t.revalidated.Add(1)
+		return cached.toResponse(req, StatusRevalidated), nil
+	}
+
+	t.misses.Add(1)
+	resp.Header.Set(HeaderCacheStatus, StatusMiss)
+
+	// сохраняем только успешные ответы, у которых есть валидаторы
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	return t.storeResponse(key, resp, etag, lastModified)
+}
+
+// метод сохранения успешного ответа в хранилище
+// тело вычитывается (не больше MaxBodyBytes) и подменяется на буфер, чтобы вызывающий код мог его прочитать
+func (t *Transport) storeResponse(key string, resp *http.Response, etag, lastModified string) (*http.Response, error) {
+	if resp.ContentLength > t.config.MaxBodyBytes {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.config.MaxBodyBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	// тело больше лимита - не сохраняем, но отдаём вызывающему полностью (прочитанную часть + остаток)
+	if int64(len(body)) > t.config.MaxBodyBytes {
+		resp.Body = &multiReadCloser{
+			Reader: io.MultiReader(bytes.NewReader(body), resp.Body),
+			closer: resp.Body,
+		}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	now := time.Now()
+	t.put(&entry{
+		key:          key,
+		statusCode:   resp.StatusCode,
+		header:       resp.Header.Clone(),
+		body:         body,
+		etag:         etag,
+		lastModified: lastModified,
+		validatedAt:  now,
+		storedAt:     now,
+	})
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	return resp, nil
+}
+
+// IsFresh сообщает, будет ли GET запрос на url обслужен из хранилища без похода в сеть
+// используется парсером, чтобы не тратить токен rate limiter на такие запросы
+func (t *Transport) IsFresh(rawURL string) bool {
+	if t.config.FreshTTL <= 0 {
+		return false
+	}
+
+	// приводим URL к тому же виду, что и ключ хранилища (req.URL.String())
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	element, ok := t.entries[http.MethodGet+" "+parsed.String()]
+	if !ok {
+		return false
+	}
+	return t.isFresh(element.Value.(*entry))
+}
+
+// Stats возвращает статистику работы кэша
+func (t *Transport) Stats() Stats {
+	t.mu.Lock()
+	entries := len(t.entries)
+	t.mu.Unlock()
+
+	return Stats{
+		Hits:        t.hits.Load(),
+		Revalidated: t.revalidated.Load(),
+		Misses:      t.misses.Load(),
+		Entries:     entries,
+	}
+}
+
+// метод поиска сохранённого ответа (устаревшие по EntryTTL удаляются)
+func (t *Transport) lookup(key string) *entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	element, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+
+	cached := element.Value.(*entry)
+	if time.Since(cached.storedAt) > t.config.EntryTTL {
+		t.lru.Remove(element)
+		delete(t.entries, key)
+		return nil
+	}
+
+	t.lru.MoveToFront(element)
+	return cached
+}
+
+// метод проверки, что ответ можно отдать без похода в сеть
+func (t *Transport) isFresh(cached *entry) bool {
+	return t.config.FreshTTL > 0 && time.Since(cached.validatedAt) < t.config.FreshTTL
+}
+
+// метод сохранения ответа с вытеснением самых давно использованных
+func (t *Transport) put(newEntry *entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if element, ok := t.entries[newEntry.key]; ok {
+		element.Value = newEntry
+		t.lru.MoveToFront(element)
+		return
+	}
+
+	t.entries[newEntry.key] = t.lru.PushFront(newEntry)
+
+	for t.lru.Len() > t.config.MaxEntries {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// метод обновления времени подтверждения ответа после 304
+func (t *Transport) markValidated(key string, header http.Header) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	element, ok := t.entries[key]
+	if !ok {
+		return
+	}
+
+	// записи в хранилище не изменяем на месте (их могут читать другие горутины) - заменяем копией
+	updated := *element.Value.(*entry)
+	updated.validatedAt = time.Now()
+	updated.storedAt = updated.validatedAt // подтверждённый ответ хранится ещё EntryTTL
+	if etag := header.Get("ETag"); etag != "" {
+		updated.etag = etag
+	}
+	element.Value = &updated
+}
+
+// метод сборки ответа из сохранённой записи
+func (e *entry) toResponse(req *http.Request, cacheStatus string) *http.Response {
+	header := e.header.Clone()
+	header.Set(HeaderCacheStatus, cacheStatus)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.statusCode, http.StatusText(e.statusCode)),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// функция формирования ключа хранилища
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// тело ответа, часть которого уже вычитана в буфер
+type multiReadCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (m *multiReadCloser) Close() error {
+	return m.closer.Close()
+}
@@ -0,0 +1,56 @@
+package inmemory_cache
+
+import (
+	"math"
+	"sort"
+)
+
+// Len возвращает количество элементов в кэше (вместе с истёкшими, но ещё не удалёнными)
+func (c *InmemoryShardedCache) Len() int {
+	total := 0
+	for _, shard := range c.shards {
+		shard.mu.RLock()
+		total += len(shard.Items)
+		shard.mu.RUnlock()
+	}
+	return total
+}
+
+// EvictExpired удаляет истёкшие элементы, не дожидаясь интервала самоочистки, возвращает количество удалённых
+func (c *InmemoryShardedCache) EvictExpired() int {
+	before := c.Len()
+	c.cleanUpExpired()
+	return max(before-c.Len(), 0)
+}
+
+// EvictFraction удаляет долю fraction (0..1) элементов каждого шарда, начиная с тех, что истекают раньше
+// используется для освобождения памяти под нагрузкой, возвращает количество удалённых
+func (c *InmemoryShardedCache) EvictFraction(fraction float64) int {
+	if fraction <= 0 {
+		return 0
+	}
+	fraction = math.Min(fraction, 1)
+
+	evicted := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+
+		count := int(math.Ceil(fraction * float64(len(shard.Items))))
+		if count > 0 {
+			keys := make([]string, 0, len(shard.Items))
+			for key := range shard.Items {
+				keys = append(keys, key)
+			}
+			sort.Slice(keys, func(i, j int) bool {
+				return shard.Items[keys[i]].expTime.Before(shard.Items[keys[j]].expTime)
+			})
+			for _, key := range keys[:count] {
+				delete(shard.Items, key)
+			}
+			evicted += count
+		}
+
+		shard.mu.Unlock()
+	}
+	return evicted
+}
@@ -1,7 +1,10 @@
 package interfaces
 
+import "parser/internal/circuitbreaker"
+
 // интерфейс для circuit breaker
 type CBInterface interface {
 	Execute(fn func() error) error
 	GetStats() (total, success, failure uint)
+	GetState() circuitbreaker.State
 }
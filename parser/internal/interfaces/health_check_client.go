@@ -2,10 +2,11 @@ package interfaces
 
 import (
 	"context"
+	"net/http"
 	"time"
 )
 
 // HealthClient интерфейс для health checks
 type HealthClient interface {
-	CheckHealth(ctx context.Context, endpoint string) (time.Duration, bool, error)
+	CheckHealth(ctx context.Context, endpoint string, headers http.Header) (time.Duration, bool, error)
 }
@@ -2,7 +2,10 @@ package interfaces
 
 import (
 	"context"
+	"net/http"
+	"parser/internal/circuitbreaker"
 	"parser/internal/domain/models"
+	"parser/internal/schemadrift"
 )
 
 type Parser interface {
@@ -10,4 +13,7 @@ type Parser interface {
 	SearchVacanciesDetailes(ctx context.Context, vacancyID string) (models.SearchVacancyDetailesResult, error)
 	GetName() string
 	GetHealthEndPoint() string
+	GetRequestHeaders() http.Header        // заголовки, с которыми парсер ходит в источник (нужны и для health check)
+	GetSchemaDrift() schemadrift.Status    // находки детектора дрейфа схемы ответов источника
+	GetCircuitState() circuitbreaker.State // состояние circuit breaker источника
 }
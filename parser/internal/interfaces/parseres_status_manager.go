@@ -1,22 +1,24 @@
 package interfaces
 
 import (
+	"parser/internal/schemadrift"
 	"time"
 )
 
 // структура статуса отдельного парсера (DTO для этого интерфейса)
 type ParserStatus struct {
-	Name           string        // имя парсера
-	LastCheck      time.Time     // время последней проверки статуса
-	LastSuccess    time.Time     // время последней успешной проверки
-	ErrorCount     int           // количество состояний, что парсер в ошибке
-	SuccessCount   int           // количество состояний, что парсер - без ошибок
-	IsHealthy      bool          // состояние
-	LastError      error         // последняя ошибка
-	CircuitState   string        // "closed", "open", "half-open" (состояние внутреннего circuit breaker)
-	Initialized    bool          // false - просто создан парсер, true - была попытка запроса
-	HealthEndpoint string        // URL для health check
-	ResponseTime   time.Duration // время ответа от парсера
+	Name           string             // имя парсера
+	LastCheck      time.Time          // время последней проверки статуса
+	LastSuccess    time.Time          // время последней успешной проверки
+	ErrorCount     int                // количество состояний, что парсер в ошибке
+	SuccessCount   int                // количество состояний, что парсер - без ошибок
+	IsHealthy      bool               // состояние
+	LastError      error              // последняя ошибка
+	CircuitState   string             // "closed", "open", "half-open" (состояние внутреннего circuit breaker)
+	Initialized    bool               // false - просто создан парсер, true - была попытка запроса
+	HealthEndpoint string             // URL для health check
+	ResponseTime   time.Duration      // время ответа от парсера
+	SchemaDrift    schemadrift.Status // дрейф схемы ответов источника (новые / пропавшие поля, смена типов)
 }
 
 type ParsersStatusManager interface {
@@ -1,8 +1,12 @@
 package interfaces
 
-// Интерфейс с дженериком для FIFO очереди
+import "context"
+
+// Интерфейс с дженериком для очереди заданий
+// Enqueue и Dequeue блокируются до появления места/элемента, закрытия очереди или отмены контекста
 type FIFOQueueInterface[T any] interface {
-	Enqueue(item T) bool // потокобезопасен, так как очередь построена на базе каналов
-	Dequeue() (T, bool)
+	Enqueue(ctx context.Context, item T) error // ошибка - очередь закрыта (queue.ErrClosed) или контекст отменён
+	Dequeue(ctx context.Context) (T, error)    // после закрытия отдаёт оставшиеся элементы, затем queue.ErrClosed
 	Size() int
+	Close()
 }
@@ -1,7 +1,18 @@
 package interfaces
 
+import (
+	"context"
+	"parser/internal/queue"
+	"time"
+)
+
 // скорее всего названия методов  - поменяются !!!!!
 type Job interface {
 	GetID() string
+	GetCreatedAt() time.Time
+	GetPriority() queue.Priority // класс приоритета в очереди менеджера парсеров
 	Complete(data interface{}, err error)
+	Context() context.Context // контекст джобы: воркер выполняет запросы к источникам под ним
+	Cancel(cause error)       // отмена джобы (nil - jobs.ErrCanceled)
+	Err() error               // причина отмены (nil - джоба не отменена)
 }
@@ -2,11 +2,17 @@
 package jobs
 
 import (
+	"context"
+	"errors"
 	"log"
+	"parser/internal/queue"
 	"sync"
 	"time"
 )
 
+// ErrCanceled - джоба отменена (вызывающий отказался от результата или джобу отменили по ID)
+var ErrCanceled = errors.New("job canceled")
+
 // структура результата выполнения джобы
 type JobOutput struct {
 	Success bool
@@ -19,7 +25,44 @@ type BaseJob struct {
 	ID         string
 	ResultChan chan *JobOutput // обязательно при создании экземплярар джобы нужно делать буферизированный канал, 1
 	CreatedAt  time.Time
+	Priority   queue.Priority // класс приоритета в очереди, задаёт вызывающий
 	notified   sync.Once
+
+	ctx    context.Context         // контекст джобы, производный от контекста вызывающего (дедлайн наследуется)
+	cancel context.CancelCauseFunc // отмена джобы: прерывает ожидание в очереди и HTTP запросы к источникам
+}
+
+// Bind привязывает джобу к контексту вызывающего: отмена или дедлайн вызывающего отменяют и джобу
+// вызывается один раз при создании джобы, до постановки в очередь
+func (j *BaseJob) Bind(parent context.Context) {
+	j.ctx, j.cancel = context.WithCancelCause(parent)
+}
+
+// Context возвращает контекст джобы (если джоба не привязана - фоновый контекст)
+func (j *BaseJob) Context() context.Context {
+	if j.ctx == nil {
+		return context.Background()
+	}
+	return j.ctx
+}
+
+// Cancel отменяет джобу с указанной причиной (nil - ErrCanceled), повторные вызовы ничего не делают
+func (j *BaseJob) Cancel(cause error) {
+	if j.cancel == nil {
+		return
+	}
+	if cause == nil {
+		cause = ErrCanceled
+	}
+	j.cancel(cause)
+}
+
+// Err возвращает причину отмены джобы (nil - джоба не отменена)
+func (j *BaseJob) Err() error {
+	if j.ctx == nil || j.ctx.Err() == nil {
+		return nil
+	}
+	return context.Cause(j.ctx)
 }
 
 // метод у структуры джоб, который отправляет результат в результирующий канал
@@ -33,6 +76,7 @@ func (j *BaseJob) Complete(data interface{}, err error) {
 		}()
 		if err == nil {
 			j.ResultChan <- &JobOutput{Success: true, Data: data, Error: err}
+			return
 		}
 
 		j.ResultChan <- &JobOutput{Success: false, Data: data, Error: err}
@@ -43,3 +87,13 @@ func (j *BaseJob) Complete(data interface{}, err error) {
 func (j *BaseJob) GetID() string {
 	return j.ID
 }
+
+// возвращает время создания джобы
+func (j *BaseJob) GetCreatedAt() time.Time {
+	return j.CreatedAt
+}
+
+// возвращает класс приоритета джобы
+func (j *BaseJob) GetPriority() queue.Priority {
+	return j.Priority
+}
@@ -0,0 +1,9 @@
+package jobs
+
+import "parser/internal/domain/models"
+
+// BatchDetailsJob - джоба для получения деталей пачки вакансий из разных источников
+type BatchDetailsJob struct {
+	BaseJob
+	Items []models.VacancyRef
+}
@@ -5,4 +5,5 @@ type FetchDetailsJob struct {
 	BaseJob
 	Source    string
 	VacancyID string
+	Fresh     bool // в обход кэша деталей (перепроверка отслеживаемой вакансии), свежий результат кэш обновляет
 }
@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"fmt"
+	"parser/internal/retry"
+	"time"
+)
+
+// виды каналов уведомлений
+const (
+	SinkWebhook  = "webhook"
+	SinkSMTP     = "smtp"
+	SinkTelegram = "telegram"
+)
+
+// Config - конфигурация уведомлений
+type Config struct {
+	Enabled   bool          `yaml:"enabled"`    // отправка уведомлений (при false события только выводятся в консоль)
+	QueueSize int           `yaml:"queue_size"` // сколько неотправленных сообщений ждёт своей очереди в каждом канале
+	Cooldown  time.Duration `yaml:"cooldown"`   // одинаковое событие (вид + субъект) отправляется не чаще (0 - без ограничения)
+	Sinks     []SinkConfig  `yaml:"sinks"`      // каналы уведомлений
+	Routes    []Route       `yaml:"routes"`     // какие события в какие каналы отправляются
+}
+
+// SinkConfig - конфигурация канала уведомлений
+// секреты (secret, password, token) поддерживают ${ENV_VAR} и ${ENV_VAR:-default}
+type SinkConfig struct {
+	Name      string            `yaml:"name"`      // имя канала (на него ссылаются маршруты)
+	Type      string            `yaml:"type"`      // webhook, smtp или telegram
+	Timeout   time.Duration     `yaml:"timeout"`   // таймаут одной попытки отправки
+	Retry     retry.RetryConfig `yaml:"retry"`     // повторы отправки (незаданные значения - по умолчанию)
+	Templates map[Kind]string   `yaml:"templates"` // шаблоны текста по видам событий (text/template), незаданные - встроенные
+	Webhook   WebhookConfig     `yaml:"webhook"`
+	SMTP      SMTPConfig        `yaml:"smtp"`
+	Telegram  TelegramConfig    `yaml:"telegram"`
+}
+
+// WebhookConfig - HTTP webhook: POST JSON с событием и текстом, подписанный HMAC-SHA256
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Secret  string            `yaml:"secret"`  // ключ подписи тела запроса (заголовок X-Signature: sha256=<hex>), пусто - без подписи
+	Headers map[string]string `yaml:"headers"` // дополнительные заголовки
+}
+
+// SMTPConfig - отправка писем через SMTP сервер (STARTTLS, если сервер его поддерживает)
+type SMTPConfig struct {
+	Addr     string   `yaml:"addr"` // host:port
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Subject  string   `yaml:"subject"` // шаблон темы письма, пусто - заголовок события
+}
+
+// TelegramConfig - отправка сообщений через Telegram Bot API (sendMessage)
+type TelegramConfig struct {
+	BaseURL string `yaml:"base_url"` // адрес Bot API, пусто - https://api.telegram.org
+	Token   string `yaml:"token"`
+	ChatID  string `yaml:"chat_id"`
+}
+
+// Route - маршрут событий в каналы
+type Route struct {
+	Events   []Kind   `yaml:"events"`   // виды событий (пусто - все)
+	Subjects []string `yaml:"subjects"` // субъекты событий: имена поисков, источников (пусто - все)
+	Sinks    []string `yaml:"sinks"`    // имена каналов
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		QueueSize: 100,
+		Cooldown:  10 * time.Minute,
+	}
+}
+
+// Validate проверяет корректность конфигурации
+func (c Config) Validate() error {
+	if c.QueueSize < 0 {
+		return fmt.Errorf("notify: queue_size must not be negative, got %d", c.QueueSize)
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("notify: cooldown must not be negative, got %v", c.Cooldown)
+	}
+
+	sinks := make(map[string]struct{}, len(c.Sinks))
+	for _, sink := range c.Sinks {
+		if sink.Name == "" {
+			return fmt.Errorf("notify: sink name must not be empty")
+		}
+		if _, ok := sinks[sink.Name]; ok {
+			return fmt.Errorf("notify: duplicate sink %q", sink.Name)
+		}
+		sinks[sink.Name] = struct{}{}
+
+		if err := sink.validate(); err != nil {
+			return fmt.Errorf("notify: sink %q: %w", sink.Name, err)
+		}
+	}
+
+	for i, route := range c.Routes {
+		if len(route.Sinks) == 0 {
+			return fmt.Errorf("notify: route %d has no sinks", i+1)
+		}
+		for _, name := range route.Sinks {
+			if _, ok := sinks[name]; !ok {
+				return fmt.Errorf("notify: route %d refers to unknown sink %q", i+1, name)
+			}
+		}
+		for _, kind := range route.Events {
+			if !validKind(kind) {
+				return fmt.Errorf("notify: route %d has unknown event %q", i+1, kind)
+			}
+		}
+	}
+	return nil
+}
+
+// метод проверки конфигурации канала
+func (c SinkConfig) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %v", c.Timeout)
+	}
+	for kind := range c.Templates {
+		if !validKind(kind) {
+			return fmt.Errorf("template for unknown event %q", kind)
+		}
+	}
+
+	switch c.Type {
+	case SinkWebhook:
+		if c.Webhook.URL == "" {
+			return fmt.Errorf("webhook.url must not be empty")
+		}
+	case SinkSMTP:
+		if c.SMTP.Addr == "" || c.SMTP.From == "" || len(c.SMTP.To) == 0 {
+			return fmt.Errorf("smtp.addr, smtp.from and smtp.to are required")
+		}
+	case SinkTelegram:
+		if c.Telegram.Token == "" || c.Telegram.ChatID == "" {
+			return fmt.Errorf("telegram.token and telegram.chat_id are required")
+		}
+	default:
+		return fmt.Errorf("unknown type %q (want %s, %s or %s)", c.Type, SinkWebhook, SinkSMTP, SinkTelegram)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// уведомления о событиях системы во внешние каналы (webhook, email, Telegram):
+// производители публикуют события, маршруты из конфига решают, в какие каналы они уйдут
+package notify
+
+import "time"
+
+// Kind - вид события
+type Kind string
+
+const (
+	KindNewVacancies    Kind = "new_vacancies"    // сохранённый поиск нашёл новые вакансии
+	KindParserUnhealthy Kind = "parser_unhealthy" // источник стал нездоровым
+	KindParserRecovered Kind = "parser_recovered" // источник снова здоров
+	KindBreakerOpen     Kind = "breaker_open"     // circuit breaker источника или менеджера разомкнулся
+)
+
+// Kinds - все виды событий
+var Kinds = []Kind{KindNewVacancies, KindParserUnhealthy, KindParserRecovered, KindBreakerOpen}
+
+// Vacancy - краткие данные вакансии в событии
+type Vacancy struct {
+	Source  string `json:"source"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Company string `json:"company"`
+	Salary  string `json:"salary,omitempty"`
+	Area    string `json:"area,omitempty"`
+	URL     string `json:"url"`
+}
+
+// Event - событие для отправки в каналы уведомлений
+type Event struct {
+	Kind      Kind      `json:"kind"`
+	Subject   string    `json:"subject"` // к чему относится событие: имя сохранённого поиска, источника или breaker
+	Title     string    `json:"title"`   // краткое описание для человека
+	Error     string    `json:"error,omitempty"`
+	Vacancies []Vacancy `json:"vacancies,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// функция проверки вида события
+func validKind(kind Kind) bool {
+	for _, known := range Kinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// сколько Stop ждёт отправки сообщений, оставшихся в очередях каналов
+const drainTimeout = 5 * time.Second
+
+// Notifier - маршрутизация событий в каналы уведомлений
+// у каждого канала своя очередь и горутина: медленный или недоступный канал не задерживает остальные
+type Notifier struct {
+	config  Config
+	sinks   []*sinkRunner
+	byName  map[string]*sinkRunner
+	ctx     context.Context // отмена прерывает отправку и паузы между повторами
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	last    map[string]time.Time // время последней отправки события по ключу вид/субъект (для cooldown), под mu
+	started bool                 // под mu
+	stopped bool                 // под mu
+	wg      sync.WaitGroup
+}
+
+// New создаёт каналы уведомлений по конфигу (при выключенных уведомлениях каналы не создаются)
+func New(config Config) (*Notifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		config: config,
+		byName: make(map[string]*sinkRunner),
+		last:   make(map[string]time.Time),
+	}
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+
+	if !config.Enabled {
+		return n, nil
+	}
+
+	for _, sinkConfig := range config.Sinks {
+		runner, err := newSinkRunner(sinkConfig, config.QueueSize)
+		if err != nil {
+			return nil, fmt.Errorf("notify: sink %q: %w", sinkConfig.Name, err)
+		}
+		n.sinks = append(n.sinks, runner)
+		n.byName[runner.name] = runner
+	}
+	return n, nil
+}
+
+// Start запускает отправку сообщений каналами
+func (n *Notifier) Start() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.started || n.stopped {
+		return
+	}
+	n.started = true
+
+	for _, runner := range n.sinks {
+		n.wg.Add(1)
+		go func(r *sinkRunner) {
+			defer n.wg.Done()
+			for event := range r.queue {
+				r.deliver(n.ctx, event)
+			}
+		}(runner)
+	}
+}
+
+// Stop прекращает приём событий, ждёт отправки оставшихся сообщений (не дольше drainTimeout) и останавливает каналы
+func (n *Notifier) Stop() {
+	n.mu.Lock()
+	if n.stopped {
+		n.mu.Unlock()
+		return
+	}
+	n.stopped = true
+	for _, runner := range n.sinks {
+		close(runner.queue)
+	}
+	n.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(drainTimeout):
+		n.cancel()
+		<-done
+	}
+	n.cancel()
+}
+
+// Publish отправляет событие в каналы по маршрутам из конфига (не блокируется)
+// повторное событие о том же субъекте в пределах cooldown отбрасывается, кроме новых вакансий - они каждый раз разные
+func (n *Notifier) Publish(event Event) {
+	if !n.config.Enabled {
+		return
+	}
+	if event.At.IsZero() {
+		event.At = time.Now()
+	}
+
+	targets := n.route(event)
+	if len(targets) == 0 {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stopped {
+		return
+	}
+
+	if n.config.Cooldown > 0 && event.Kind != KindNewVacancies {
+		key := string(event.Kind) + "/" + event.Subject
+		if last, ok := n.last[key]; ok && event.At.Sub(last) < n.config.Cooldown {
+			return
+		}
+		n.last[key] = event.At
+	}
+
+	for _, runner := range targets {
+		select {
+		case runner.queue <- event:
+		default:
+			runner.mu.Lock()
+			runner.stats.Dropped++
+			runner.mu.Unlock()
+		}
+	}
+}
+
+// метод выбора каналов для события (каждый канал - не более одного раза)
+func (n *Notifier) route(event Event) []*sinkRunner {
+	var targets []*sinkRunner
+	for _, route := range n.config.Routes {
+		if len(route.Events) > 0 && !slices.Contains(route.Events, event.Kind) {
+			continue
+		}
+		if len(route.Subjects) > 0 && !slices.Contains(route.Subjects, event.Subject) {
+			continue
+		}
+		for _, name := range route.Sinks {
+			runner, ok := n.byName[name]
+			if ok && !slices.Contains(targets, runner) {
+				targets = append(targets, runner)
+			}
+		}
+	}
+	return targets
+}
+
+// Enabled сообщает, включены ли уведомления
+func (n *Notifier) Enabled() bool {
+	return n.config.Enabled
+}
+
+// Stats возвращает счётчики каналов в порядке конфига
+func (n *Notifier) Stats() []SinkStats {
+	stats := make([]SinkStats, 0, len(n.sinks))
+	for _, runner := range n.sinks {
+		stats = append(stats, runner.snapshot())
+	}
+	return stats
+}
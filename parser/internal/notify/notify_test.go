@@ -0,0 +1,379 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"parser/internal/retry"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// повторы без заметных пауз, чтобы тесты не ждали backoff
+var fastRetry = retry.RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+// событие для отправки в тестах
+func testEvent() Event {
+	return Event{Kind: KindParserUnhealthy, Subject: "HH.ru", Title: "HH.ru недоступен", Error: "timeout", At: time.Now()}
+}
+
+func TestWebhookSink(t *testing.T) {
+	const secret = "s3cr3t"
+
+	tests := []struct {
+		name        string
+		statuses    []int // ответы сервера по порядку, дальше - 200
+		wantCalls   int32
+		wantSent    int
+		wantFailed  int
+		wantRetries int
+	}{
+		{name: "delivered", wantCalls: 1, wantSent: 1},
+		{name: "5xx is retried", statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway}, wantCalls: 3, wantSent: 1, wantRetries: 2},
+		{name: "4xx is not retried", statuses: []int{http.StatusBadRequest}, wantCalls: 1, wantFailed: 1},
+		{name: "gives up after max attempts", statuses: []int{503, 503, 503, 503}, wantCalls: 3, wantFailed: 1, wantRetries: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			var mu sync.Mutex
+			var signatureErrs []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := calls.Add(1)
+				body, _ := io.ReadAll(r.Body)
+
+				// каждая попытка подписана ключом канала и помечена видом события
+				mu.Lock()
+				if got, want := r.Header.Get(HeaderSignature), Sign([]byte(secret), body); got != want {
+					signatureErrs = append(signatureErrs, "signature "+got+", want "+want)
+				}
+				if got := r.Header.Get(HeaderEvent); got != string(KindParserUnhealthy) {
+					signatureErrs = append(signatureErrs, "event header "+got)
+				}
+				var payload webhookPayload
+				if err := json.Unmarshal(body, &payload); err != nil || payload.Subject != "HH.ru" || payload.Text == "" {
+					signatureErrs = append(signatureErrs, "payload "+string(body))
+				}
+				mu.Unlock()
+
+				if int(call) <= len(tt.statuses) {
+					w.WriteHeader(tt.statuses[call-1])
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			runner, err := newSinkRunner(SinkConfig{
+				Name:    "hook",
+				Type:    SinkWebhook,
+				Retry:   fastRetry,
+				Webhook: WebhookConfig{URL: server.URL, Secret: secret},
+			}, 1)
+			if err != nil {
+				t.Fatalf("new sink: %v", err)
+			}
+
+			runner.deliver(context.Background(), testEvent())
+
+			for _, msg := range signatureErrs {
+				t.Error(msg)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			stats := runner.snapshot()
+			if stats.Sent != tt.wantSent || stats.Failed != tt.wantFailed || stats.Retries != tt.wantRetries {
+				t.Errorf("stats = %+v, want sent %d, failed %d, retries %d", stats, tt.wantSent, tt.wantFailed, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestTelegramSink(t *testing.T) {
+	const token = "123:secret-token"
+
+	tests := []struct {
+		name           string
+		status         int
+		response       string
+		wantErr        bool
+		wantCode       int
+		wantRetryAfter time.Duration
+	}{
+		{name: "sent", status: http.StatusOK, response: `{"ok":true}`},
+		{
+			name:           "flood limit",
+			status:         http.StatusTooManyRequests,
+			response:       `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":3}}`,
+			wantErr:        true,
+			wantCode:       http.StatusTooManyRequests,
+			wantRetryAfter: 3 * time.Second,
+		},
+		{name: "not json", status: http.StatusBadGateway, response: `bad gateway`, wantErr: true, wantCode: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/bot"+token+"/sendMessage" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				_ = json.NewDecoder(r.Body).Decode(&request)
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			sink, err := newTelegramSink(TelegramConfig{BaseURL: server.URL + "/", Token: token, ChatID: "42"})
+			if err != nil {
+				t.Fatalf("new sink: %v", err)
+			}
+
+			err = sink.Send(context.Background(), Message{Event: testEvent(), Text: "привет"})
+
+			if request["chat_id"] != "42" || request["text"] != "привет" {
+				t.Errorf("request = %v", request)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected err: %v", err)
+				}
+				return
+			}
+
+			var status *statusError
+			if !errors.As(err, &status) {
+				t.Fatalf("err = %v, want status error", err)
+			}
+			if status.code != tt.wantCode || status.retryAfter != tt.wantRetryAfter {
+				t.Errorf("status = %d, retry after %v; want %d, %v", status.code, status.retryAfter, tt.wantCode, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestTelegramSinkNetworkErrorHidesToken(t *testing.T) {
+	const token = "123:secret-token"
+
+	// адрес, на котором никто не слушает
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseURL := server.URL
+	server.Close()
+
+	sink, err := newTelegramSink(TelegramConfig{BaseURL: baseURL, Token: token, ChatID: "42"})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+
+	err = sink.Send(context.Background(), Message{Event: testEvent(), Text: "привет"})
+	if err == nil {
+		t.Fatal("want network error")
+	}
+	if strings.Contains(err.Error(), token) {
+		t.Errorf("error leaks the bot token: %v", err)
+	}
+}
+
+// smtpStub - SMTP сервер-заглушка: принимает одно письмо и записывает полученные команды
+type smtpStub struct {
+	listener net.Listener
+	rcptCode int // ответ на RCPT (0 - 250)
+
+	mu       sync.Mutex
+	commands []string
+	data     string
+	done     chan struct{}
+}
+
+func newSMTPStub(t *testing.T, rcptCode int) *smtpStub {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stub := &smtpStub{listener: listener, rcptCode: rcptCode, done: make(chan struct{})}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go stub.serve()
+	return stub
+}
+
+// метод обслуживания одного соединения
+func (s *smtpStub) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	text := textproto.NewConn(conn)
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			_ = text.PrintfLine("%s", line)
+		}
+	}
+
+	reply("220 localhost ESMTP stub")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		s.mu.Unlock()
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO":
+			// STARTTLS не объявляем - заглушка без TLS
+			reply("250-localhost", "250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			reply("250 2.1.0 Ok")
+		case "RCPT":
+			if s.rcptCode != 0 {
+				reply(strconv.Itoa(s.rcptCode) + " 5.1.1 recipient rejected")
+				continue
+			}
+			reply("250 2.1.5 Ok")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.data = string(data)
+			s.mu.Unlock()
+			reply("250 2.0.0 Ok: queued")
+		case "RSET", "NOOP":
+			reply("250 Ok")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+// метод ожидания конца сессии и получения записанных команд и письма
+func (s *smtpStub) result(t *testing.T) ([]string, string) {
+	t.Helper()
+
+	// клиент мог закрыть соединение без QUIT - после ошибки заглушка дочитывает до EOF
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp session did not finish")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...), s.data
+}
+
+func TestSMTPSink(t *testing.T) {
+	stub := newSMTPStub(t, 0)
+
+	sink, err := newSMTPSink("mail", SMTPConfig{
+		Addr:     stub.listener.Addr().String(),
+		Username: "bot",
+		Password: "pa55",
+		From:     "parser@example.com",
+		To:       []string{"a@example.com", "b@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sink.Send(ctx, Message{Event: testEvent(), Text: "hello from parser"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	commands, data := stub.result(t)
+
+	var verbs []string
+	for _, command := range commands {
+		verbs = append(verbs, strings.SplitN(command, " ", 2)[0])
+	}
+	if got, want := strings.Join(verbs, ","), "EHLO,AUTH,MAIL,RCPT,RCPT,DATA,QUIT"; got != want {
+		t.Fatalf("commands = %s, want %s (%q)", got, want, commands)
+	}
+
+	// AUTH PLAIN: base64("\x00user\x00password")
+	credentials, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(commands[1], "AUTH PLAIN "))
+	if err != nil || string(credentials) != "\x00bot\x00pa55" {
+		t.Errorf("auth = %q (%v), want PLAIN bot/pa55", credentials, err)
+	}
+	if commands[2] != "MAIL FROM:<parser@example.com>" {
+		t.Errorf("mail = %q", commands[2])
+	}
+	if commands[3] != "RCPT TO:<a@example.com>" || commands[4] != "RCPT TO:<b@example.com>" {
+		t.Errorf("rcpt = %q, %q", commands[3], commands[4])
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(data)))
+	header, err := reader.ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("message header: %v", err)
+	}
+	if header.Get("To") != "a@example.com, b@example.com" || header.Get("From") != "parser@example.com" {
+		t.Errorf("header = %v", header)
+	}
+	if !strings.Contains(data, "hello from parser") {
+		t.Errorf("message body does not contain the text: %q", data)
+	}
+}
+
+func TestSMTPSinkRejectedRecipientIsNotRetried(t *testing.T) {
+	stub := newSMTPStub(t, 550)
+
+	runner, err := newSinkRunner(SinkConfig{
+		Name:    "mail",
+		Type:    SinkSMTP,
+		Timeout: 2 * time.Second,
+		Retry:   fastRetry,
+		SMTP: SMTPConfig{
+			Addr: stub.listener.Addr().String(),
+			From: "parser@example.com",
+			To:   []string{"nobody@example.com"},
+		},
+	}, 1)
+	if err != nil {
+		t.Fatalf("new sink: %v", err)
+	}
+
+	runner.deliver(context.Background(), testEvent())
+
+	// постоянная ошибка SMTP (5xx) - одна попытка, письмо не отправлено
+	commands, data := stub.result(t)
+	if data != "" {
+		t.Errorf("message was sent to a rejected recipient: %q", data)
+	}
+	if stats := runner.snapshot(); stats.Failed != 1 || stats.Retries != 0 {
+		t.Errorf("stats = %+v, want failed 1 without retries (commands %q)", stats, commands)
+	}
+}
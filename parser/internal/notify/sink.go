@@ -0,0 +1,209 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"parser/internal/retry"
+	"parser/pkg"
+	"strings"
+	"sync"
+	"time"
+)
+
+// таймаут одной попытки отправки по умолчанию
+const defaultSendTimeout = 10 * time.Second
+
+// Message - уведомление, готовое к отправке
+type Message struct {
+	Event Event
+	Text  string // текст по шаблону канала
+}
+
+// Sink - канал уведомлений
+type Sink interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// SinkStats - счётчики канала уведомлений
+type SinkStats struct {
+	Name      string
+	Type      string
+	Sent      int       // доставлено сообщений
+	Failed    int       // не доставлено после всех попыток
+	Retries   int       // повторных попыток
+	Dropped   int       // отброшено из-за переполненной очереди
+	LastError string    // ошибка последней неудачной отправки
+	LastSent  time.Time // время последней доставки
+}
+
+// statusError - ответ HTTP сервера с неуспешным статусом
+type statusError struct {
+	code       int
+	body       string
+	retryAfter time.Duration // пауза, которую попросил сервер (0 - не просил)
+}
+
+func (e *statusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("unexpected status %d", e.code)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.code, e.body)
+}
+
+// функция проверки ответа HTTP сервера (тело ответа вычитывается и закрывается)
+func checkResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err := &statusError{code: resp.StatusCode, body: strings.TrimSpace(string(body))}
+	if delay, ok := retry.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		err.retryAfter = delay
+	}
+	return err
+}
+
+// sinkRunner - очередь канала и доставка сообщений с повторами
+type sinkRunner struct {
+	name      string
+	sink      Sink
+	templates templates
+	policy    *retry.Policy
+	timeout   time.Duration
+	queue     chan Event
+
+	mu    sync.Mutex
+	stats SinkStats
+}
+
+// функция создания канала по конфигу (секреты подставляются из переменных окружения)
+func newSinkRunner(config SinkConfig, queueSize int) (*sinkRunner, error) {
+	parsed, err := parseTemplates(config.Name, config.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	var sink Sink
+	switch config.Type {
+	case SinkWebhook:
+		sink, err = newWebhookSink(config.Webhook)
+	case SinkSMTP:
+		sink, err = newSMTPSink(config.Name, config.SMTP)
+	case SinkTelegram:
+		sink, err = newTelegramSink(config.Telegram)
+	default:
+		err = fmt.Errorf("unknown type %q", config.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultSendTimeout
+	}
+
+	return &sinkRunner{
+		name:      config.Name,
+		sink:      sink,
+		templates: parsed,
+		policy:    retry.NewPolicy(config.Retry),
+		timeout:   timeout,
+		queue:     make(chan Event, queueSize),
+		stats:     SinkStats{Name: config.Name, Type: config.Type},
+	}, nil
+}
+
+// метод доставки сообщения о событии с повторами по политике канала
+func (r *sinkRunner) deliver(ctx context.Context, event Event) {
+	text, err := r.templates.render(event)
+	if err != nil {
+		r.recordFailure(err)
+		return
+	}
+	message := Message{Event: event, Text: text}
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		err = r.sink.Send(attemptCtx, message)
+		cancel()
+
+		if err == nil {
+			r.mu.Lock()
+			r.stats.Sent++
+			r.stats.LastSent = time.Now()
+			r.mu.Unlock()
+			return
+		}
+
+		retryable, delay := r.classify(err)
+		if !retryable || attempt >= r.policy.MaxAttempts() || ctx.Err() != nil {
+			break
+		}
+		if delay == 0 {
+			delay = r.policy.Backoff(attempt)
+		}
+
+		r.mu.Lock()
+		r.stats.Retries++
+		r.mu.Unlock()
+
+		if retry.Sleep(ctx, delay) != nil {
+			break
+		}
+	}
+
+	r.recordFailure(err)
+}
+
+// метод определения, стоит ли повторять отправку, и паузы, которую попросил сервер
+func (r *sinkRunner) classify(err error) (bool, time.Duration) {
+	var status *statusError
+	if errors.As(err, &status) {
+		if !r.policy.IsRetryableStatus(status.code) || status.retryAfter > r.policy.MaxRetryAfter() {
+			return false, 0
+		}
+		return true, status.retryAfter
+	}
+
+	// SMTP: коды 4xx - временные ошибки сервера, 5xx - постоянные (неверный адрес, отказ в авторизации)
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) {
+		return smtpErr.Code >= 400 && smtpErr.Code < 500, 0
+	}
+
+	return r.policy.IsRetryableError(err), 0
+}
+
+// метод учёта недоставленного сообщения
+func (r *sinkRunner) recordFailure(err error) {
+	fmt.Printf("⚠️  уведомление через %s не доставлено: %v\n", r.name, err)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats.Failed++
+	r.stats.LastError = err.Error()
+}
+
+// метод получения копии счётчиков канала
+func (r *sinkRunner) snapshot() SinkStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// функция подстановки переменных окружения в секрет из конфига
+func expandSecret(field, value string) (string, error) {
+	expanded, missing := pkg.ExpandTemplate(value, nil)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s: environment variables are not set: %s", field, strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
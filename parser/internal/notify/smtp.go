@@ -0,0 +1,139 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// smtpSink - отправка писем через SMTP сервер
+type smtpSink struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+	subject  *template.Template
+}
+
+// конструктор SMTP канала
+func newSMTPSink(name string, config SMTPConfig) (*smtpSink, error) {
+	host, _, err := net.SplitHostPort(config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp.addr: %w", err)
+	}
+	password, err := expandSecret("smtp.password", config.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := config.Subject
+	if subject == "" {
+		subject = defaultSubjectTemplate
+	}
+	tmpl, err := template.New(name + "/subject").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("smtp.subject: %w", err)
+	}
+
+	return &smtpSink{
+		addr:     config.Addr,
+		host:     host,
+		username: config.Username,
+		password: password,
+		from:     config.From,
+		to:       config.To,
+		subject:  tmpl,
+	}, nil
+}
+
+// Send отправляет текст уведомления письмом всем получателям
+// соединение устанавливается вручную (а не через smtp.SendMail), чтобы отправка укладывалась в таймаут контекста
+func (s *smtpSink) Send(ctx context.Context, message Message) error {
+	subject, err := execute(s.subject, message.Event)
+	if err != nil {
+		return err
+	}
+	body, err := s.buildMessage(subject, message.Text)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	for _, to := range s.to {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// метод сборки письма: заголовки и текст в quoted-printable (в тексте кириллица и эмодзи)
+func (s *smtpSink) buildMessage(subject, text string) ([]byte, error) {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	writer := quotedprintable.NewWriter(&msg)
+	if _, err := writer.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
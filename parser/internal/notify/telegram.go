@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// адрес Telegram Bot API по умолчанию
+const defaultTelegramBaseURL = "https://api.telegram.org"
+
+// максимальная длина сообщения Telegram (длинные списки вакансий обрезаются)
+const telegramMaxLength = 4096
+
+// telegramSink - отправка сообщений ботом в чат
+type telegramSink struct {
+	endpoint string // полный адрес метода sendMessage (содержит токен бота)
+	chatID   string
+	client   *http.Client
+}
+
+// ответ Bot API
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// конструктор Telegram канала
+func newTelegramSink(config TelegramConfig) (*telegramSink, error) {
+	token, err := expandSecret("telegram.token", config.Token)
+	if err != nil {
+		return nil, err
+	}
+	chatID, err := expandSecret("telegram.chat_id", config.ChatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" || chatID == "" {
+		return nil, fmt.Errorf("telegram.token and telegram.chat_id must not be empty")
+	}
+
+	baseURL := strings.TrimRight(config.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultTelegramBaseURL
+	}
+
+	return &telegramSink{
+		endpoint: baseURL + "/bot" + token + "/sendMessage",
+		chatID:   chatID,
+		client:   &http.Client{},
+	}, nil
+}
+
+// Send отправляет текст уведомления в чат
+func (s *telegramSink) Send(ctx context.Context, message Message) error {
+	text := message.Text
+	if runes := []rune(text); len(runes) > telegramMaxLength {
+		text = string(runes[:telegramMaxLength-1]) + "…"
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"chat_id":                  s.chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// ошибка клиента содержит адрес запроса, а в нём токен бота
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("telegram sendMessage: %w", urlErr.Err)
+		}
+		return err
+	}
+
+	// Bot API описывает ошибку в теле ответа, в том числе паузу при превышении лимита сообщений
+	var result telegramResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && decodeErr == nil && result.OK {
+		resp.Body.Close()
+		return nil
+	}
+	if decodeErr != nil {
+		return checkResponse(resp)
+	}
+	resp.Body.Close()
+
+	code := result.ErrorCode
+	if code == 0 {
+		code = resp.StatusCode
+	}
+	return &statusError{
+		code:       code,
+		body:       result.Description,
+		retryAfter: time.Duration(result.Parameters.RetryAfter) * time.Second,
+	}
+}
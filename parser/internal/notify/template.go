@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// встроенные шаблоны текста уведомлений (данные шаблона - Event)
+var defaultTemplates = map[Kind]string{
+	KindNewVacancies: `🆕 {{.Title}}
+{{range .Vacancies}}
+• {{.Name}} - {{.Company}}{{if .Salary}}, {{.Salary}}{{end}}{{if .Area}} ({{.Area}}){{end}}
+  {{.URL}}
+{{end}}`,
+	KindParserUnhealthy: `❌ {{.Title}}{{if .Error}}
+Ошибка: {{.Error}}{{end}}
+{{.At.Format "02.01.2006 15:04:05"}}`,
+	KindParserRecovered: `✅ {{.Title}}
+{{.At.Format "02.01.2006 15:04:05"}}`,
+	KindBreakerOpen: `⛔ {{.Title}}{{if .Error}}
+Ошибка: {{.Error}}{{end}}
+{{.At.Format "02.01.2006 15:04:05"}}`,
+}
+
+// шаблон темы письма по умолчанию
+const defaultSubjectTemplate = `{{.Title}}`
+
+// templates - разобранные шаблоны канала по видам событий
+type templates map[Kind]*template.Template
+
+// функция разбора шаблонов канала: заданные в конфиге перекрывают встроенные
+func parseTemplates(name string, overrides map[Kind]string) (templates, error) {
+	parsed := make(templates, len(Kinds))
+	for _, kind := range Kinds {
+		text, ok := overrides[kind]
+		if !ok {
+			text = defaultTemplates[kind]
+		}
+
+		tmpl, err := template.New(name + "/" + string(kind)).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", kind, err)
+		}
+		parsed[kind] = tmpl
+	}
+	return parsed, nil
+}
+
+// метод получения текста уведомления о событии
+func (t templates) render(event Event) (string, error) {
+	tmpl, ok := t[event.Kind]
+	if !ok {
+		return "", fmt.Errorf("no template for event %q", event.Kind)
+	}
+	return execute(tmpl, event)
+}
+
+// функция выполнения шаблона
+func execute(tmpl *template.Template, event Event) (string, error) {
+	var text strings.Builder
+	if err := tmpl.Execute(&text, event); err != nil {
+		return "", fmt.Errorf("template %s: %w", tmpl.Name(), err)
+	}
+	return strings.TrimSpace(text.String()), nil
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// заголовки запроса webhook
+const (
+	HeaderSignature = "X-Signature" // sha256=<hex HMAC-SHA256 тела запроса>
+	HeaderEvent     = "X-Event"     // вид события
+)
+
+// webhookPayload - тело запроса webhook
+type webhookPayload struct {
+	Event
+	Text string `json:"text"`
+}
+
+// webhookSink - отправка событий POST-запросом с JSON телом
+type webhookSink struct {
+	url     string
+	secret  []byte
+	headers map[string]string
+	client  *http.Client
+}
+
+// конструктор webhook канала
+func newWebhookSink(config WebhookConfig) (*webhookSink, error) {
+	secret, err := expandSecret("webhook.secret", config.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string, len(config.Headers))
+	for key, value := range config.Headers {
+		expanded, err := expandSecret("webhook.headers."+key, value)
+		if err != nil {
+			return nil, err
+		}
+		headers[key] = expanded
+	}
+
+	return &webhookSink{
+		url:     config.URL,
+		secret:  []byte(secret),
+		headers: headers,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Send отправляет событие и текст уведомления на адрес webhook
+func (s *webhookSink) Send(ctx context.Context, message Message) error {
+	body, err := json.Marshal(webhookPayload{Event: message.Event, Text: message.Text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(message.Event.Kind))
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	if len(s.secret) > 0 {
+		req.Header.Set(HeaderSignature, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp)
+}
+
+// Sign возвращает подпись тела запроса в формате заголовка X-Signature (для проверки на стороне получателя)
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
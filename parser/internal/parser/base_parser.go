@@ -6,10 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"parser/internal/cassette"
 	"parser/internal/circuitbreaker"
 	"parser/internal/domain/models"
+	"parser/internal/httpcache"
 	"parser/internal/interfaces"
+	"parser/internal/pipeline"
+	"parser/internal/proxy"
 	ratelimiter "parser/internal/rate_limiter"
+	"parser/internal/retry"
+	"parser/internal/schemadrift"
 	"parser/pkg"
 	"time"
 )
@@ -29,6 +35,17 @@ type BaseConfig struct {
 	TLSHandshakeTimeout   time.Duration                       // максимальное время ожидания завершения TLS handshake
 	ResponseHeaderTimeout time.Duration                       // интервал, сколько ждать ответа сервера после отправки запроса
 	ExpectContinueTimeout time.Duration                       // интервал, оптимизация для сценариев загрузки больших данных
+	RetryCfg              retry.RetryConfig                   // политика повторных запросов (попытки, пауза, повторяемые ошибки)
+	MaxResponseBytes      int64                               // максимальный размер тела успешного ответа (защита памяти от "неадекватного" источника)
+	MaxErrorBodyBytes     int                                 // сколько байт тела ответа с ошибкой попадает в логи и в текст ошибки
+	ResponseCacheCfg      httpcache.Config                    // транспортный кэш ответов (условные запросы по ETag / Last-Modified)
+	ProxyCfg              proxy.Config                        // прокси (одиночный или пул с ротацией) для запросов к источнику
+	Headers               map[string]string                   // шаблоны заголовков запросов (User-Agent, Accept-Language, заголовки вендора), с подстановкой переменных окружения
+	CassetteCfg           *cassette.Config                    // запись/воспроизведение HTTP взаимодействий (nil - выключено)
+	SchemaDriftCfg        schemadrift.Config                  // обнаружение дрейфа схемы ответов источника
+	Middleware            []string                            // порядок middleware запросов к источнику (пусто - порядок по умолчанию)
+	FaultInjectionCfg     pipeline.FaultInjectionConfig       // параметры middleware имитации сбоев (fault_injection)
+	HedgeCfg              pipeline.HedgeConfig                // параметры middleware дублирующих запросов (hedge)
 }
 
 // BaseParser базовая реализация парсера
@@ -42,44 +59,110 @@ type BaseParser struct {
 	circuitBreaker interfaces.CBInterface // экземпляр для circuit breaker (отказоустойчивость)
 	semaphore      chan struct{}          // семафор (ограничение конкурентности)
 	maxConcurrent  int                    // размер буфера для семафора
+	retryPolicy    *retry.Policy          // политика повторных запросов к источнику
+
+	maxResponseBytes  int64                // максимальный размер тела успешного ответа
+	maxErrorBodyBytes int                  // максимальный размер тела ответа с ошибкой в тексте ошибки
+	responseCache     *httpcache.Transport // транспортный кэш ответов (nil - если выключен в конфиге)
+	proxyPool         *proxy.Pool          // пул прокси (nil - если прокси не используется)
+	proxyConfig       proxy.Config         // конфиг прокси источника (по нему строится и клиент health check)
+	headers           http.Header          // заголовки, которые отправляются с каждым запросом к источнику
+	schemaDrift       *schemadrift.Monitor // детекторы дрейфа схемы ответов (по эндпоинтам)
+	faultInjection    pipeline.FaultInjectionConfig
+	hedge             pipeline.HedgeConfig
+	hedgeStats        *pipeline.HedgeStats  // недавние задержки источника и счётчики дублирующих запросов
+	metrics           *pipeline.Metrics     // метрики запросов к источнику (заполняются middleware metrics)
+	middlewares       []pipeline.Middleware // цепочка middleware запросов к источнику
 }
 
 // Конструктор, который создает базовый парсер
 func NewBaseParser(config BaseConfig) *BaseParser {
-	return &BaseParser{
+	if config.MaxResponseBytes <= 0 {
+		config.MaxResponseBytes = defaultMaxResponseBytes
+	}
+	if config.MaxErrorBodyBytes <= 0 {
+		config.MaxErrorBodyBytes = defaultMaxErrorBodyBytes
+	}
+
+	httpClient, proxyPool := createHTTPClient(config)
+
+	// если включён транспортный кэш - оборачиваем им транспорт клиента
+	var responseCache *httpcache.Transport
+	if config.ResponseCacheCfg.Enabled {
+		responseCache = httpcache.NewTransport(httpClient.Transport, config.ResponseCacheCfg)
+		httpClient.Transport = responseCache
+	}
+
+	p := &BaseParser{
 		name:           config.Name,
 		baseURL:        config.BaseURL,
 		healthEndPoint: config.HealthEndPoint,
 		apiKey:         config.APIKey,
-		httpClient:     createHTTPClient(config),
+		httpClient:     httpClient,
 		rateLimiter:    ratelimiter.NewChannelRateLimiter(config.RateLimit),
 		circuitBreaker: circuitbreaker.NewCircutBreaker(config.CircuitBreakerCfg),
 		semaphore:      make(chan struct{}, config.MaxConcurrent),
 		maxConcurrent:  config.MaxConcurrent,
+		retryPolicy:    retry.NewPolicy(config.RetryCfg),
+
+		maxResponseBytes:  config.MaxResponseBytes,
+		maxErrorBodyBytes: config.MaxErrorBodyBytes,
+		responseCache:     responseCache,
+		proxyPool:         proxyPool,
+		proxyConfig:       config.ProxyCfg,
+		headers:           buildRequestHeaders(config.Name, config.APIKey, config.Headers),
+		schemaDrift:       schemadrift.NewMonitor(config.Name, config.SchemaDriftCfg),
+		faultInjection:    config.FaultInjectionCfg,
+		hedge:             config.HedgeCfg,
+		hedgeStats:        pipeline.NewHedgeStats(config.HedgeCfg),
+		metrics:           pipeline.NewMetrics(),
 	}
+
+	// собираем цепочку middleware из конфига парсера
+	p.middlewares = p.buildMiddlewares(config.Middleware)
+
+	return p
 }
 
 // функция, которая создаёт новый клиент с параметрами
-func createHTTPClient(config BaseConfig) *http.Client {
-	return &http.Client{
-		Timeout: config.Timeout,
-		Transport: &http.Transport{
-			MaxConnsPerHost:       config.MaxConcurrent,
-			MaxIdleConnsPerHost:   config.MaxIdleConns,
-			IdleConnTimeout:       config.IdleConnTimeout,
-			TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
-			ResponseHeaderTimeout: config.ResponseHeaderTimeout,
-			ExpectContinueTimeout: config.ExpectContinueTimeout,
-		},
+// если в конфиге задан прокси - транспорт клиента ходит через него (возвращается пул прокси, иначе nil)
+// если включены кассеты - ответы записываются в кассету или воспроизводятся из неё
+func createHTTPClient(config BaseConfig) (*http.Client, *proxy.Pool) {
+	transport := &http.Transport{
+		MaxConnsPerHost:       config.MaxConcurrent,
+		MaxIdleConnsPerHost:   config.MaxIdleConns,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		ExpectContinueTimeout: config.ExpectContinueTimeout,
 	}
+
+	roundTripper, proxyPool, err := proxy.Configure(transport, config.ProxyCfg)
+	if err != nil {
+		// некорректный конфиг прокси не должен ронять приложение - работаем напрямую, но громко предупреждаем
+		fmt.Printf("⚠️  [%s] некорректный конфиг прокси, запросы пойдут напрямую: %v\n", config.Name, err)
+		roundTripper = transport
+	}
+
+	// кассета стоит перед прокси: в режиме воспроизведения запросы не доходят ни до прокси, ни до сети
+	// записываемое тело ответа ограничено тем же лимитом, что и разбор ответа парсером
+	roundTripper = cassette.Wrap(roundTripper, config.Name, config.CassetteCfg, config.MaxResponseBytes, config.APIKey)
+
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: roundTripper,
+	}, proxyPool
 }
 
 // ParserFuncs определяет типы специфичных функций парсера
+// для разбора ответа достаточно одной из функций: Decode (потоковый разбор, приоритетнее) или Parse
 type ParserFuncs struct {
 	BuildURL       func(models.SearchParams) (string, error)
 	Parse          func([]byte) (interface{}, error)
+	Decode         func(io.Reader) (interface{}, error)
 	Convert        func(interface{}) ([]models.Vacancy, error)
 	ConvertDetails func(interface{}) (models.SearchVacancyDetailesResult, error)
+	Schema         *schemadrift.Schema // ожидаемая схема ответа эндпоинта (nil - дрейф не проверяется)
 }
 
 // SearchVacancies общий метод для поиска вакансий
@@ -90,65 +173,35 @@ func (p *BaseParser) SearchVacancies(ctx context.Context, params models.SearchPa
 		return nil, fmt.Errorf("build URL failed: %w", err)
 	}
 
-	// заводим переменную, в которую будем складывать результат
-	var vacancies []models.Vacancy
-
-	// Используем Circuit Breaker для выполнения запроса к внешнему сервису
-	//---------------------------------------------------------------------------------------------------
-	err = p.circuitBreaker.Execute(func() error {
-		// Обработка семафора
-		if err := p.acquireSemaphore(ctx); err != nil {
-			return err
-		}
-		defer p.releaseSemaphore() // после завершения вызова функции, освобождаем семафор
-
-		// Перед осуществлением запроса проверяем rate limiter
-		p.rateLimiter.Wait()
-
-		// Выполнение HTTP запроса
-		resp, err := p.executeRequest(ctx, apiURL)
+	// запрос проходит через цепочку middleware парсера (circuit breaker, семафор, повторы, rate limiter, ...)
+	// последним звеном выполняется HTTP запрос, разбор ответа и приведение к единому формату
+	handler := pipeline.Chain(func(ctx context.Context, req *pipeline.Request) (*pipeline.Result, error) {
+		parsedData, err := p.fetch(ctx, req.URL, funcs)
 		if err != nil {
-			return err
-		}
-
-		// освобождаем ресурсы
-		defer p.drainAndClose(resp)
-
-		// Проверка статуса
-		if err := p.checkResponseStatus(resp); err != nil {
-			return err
-		}
-
-		// Чтение и парсинг
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("read response failed: %w", err)
-		}
-
-		// парсим даные
-		parsedData, err := funcs.Parse(body)
-		if err != nil {
-			return fmt.Errorf("parse response failed: %w", err)
+			return nil, err
 		}
 
 		// пробуем сконвертировать результаты поиска к единому формату. Обязательно type assertion, на входе interface{}
 		converted, err := funcs.Convert(parsedData)
 		if err != nil {
-			return fmt.Errorf("convert to universal failed: %w", err)
+			return nil, fmt.Errorf("convert to universal failed: %w", err)
 		}
-
-		vacancies = converted
-
-		return nil
+		return &pipeline.Result{Vacancies: converted}, nil
+	}, p.middlewares...)
+
+	result, err := handler(ctx, &pipeline.Request{
+		Kind:   pipeline.KindSearch,
+		Source: p.name,
+		URL:    apiURL,
+		Params: params,
 	})
-	//---------------------------------------------------------------------------------------------------
 
 	// если ошибки есть, определяем какого они рода
 	if err != nil {
 		return p.handleCircuitBreakerErrorVacanciesSearch(err)
 	}
 
-	return vacancies, nil
+	return result.Vacancies, nil
 }
 
 // SearchVacancyDetailes общий метод для поиска деталей по конкретной вакансии
@@ -156,56 +209,26 @@ func (p *BaseParser) SearchVacancyDetailes(ctx context.Context, vacancyID string
 	// формируем url поиска для базового парсера
 	searchUrl := pkg.UrlBuilder(p.baseURL, vacancyID)
 
-	// заводим переменную, в которую будем складывать результат
-	var vacancyDetails models.SearchVacancyDetailesResult
-
-	// Используем Circuit Breaker для выполнения запроса к внешнему сервису
-	//---------------------------------------------------------------------------------------------------
-	err := p.circuitBreaker.Execute(func() error {
-		// Обработка семафора
-		if err := p.acquireSemaphore(ctx); err != nil {
-			return err
-		}
-		defer p.releaseSemaphore() // после завершения вызова функции, освобождаем семафор
-
-		// Перед осуществлением запроса проверяем rate limiter
-		p.rateLimiter.Wait()
-
-		// Выполнение HTTP запроса
-		resp, err := p.executeRequest(ctx, searchUrl)
-		if err != nil {
-			return err
-		}
-
-		// освобождаем ресурсы
-		defer p.drainAndClose(resp)
-
-		// Проверка статуса
-		if err := p.checkResponseStatus(resp); err != nil {
-			return err
-		}
-
-		// Чтение и парсинг
-		body, err := io.ReadAll(resp.Body)
+	// запрос проходит через ту же цепочку middleware, что и поиск
+	handler := pipeline.Chain(func(ctx context.Context, req *pipeline.Request) (*pipeline.Result, error) {
+		parsedData, err := p.fetch(ctx, req.URL, funcs)
 		if err != nil {
-			return fmt.Errorf("read response failed: %w", err)
-		}
-
-		// парсим даные
-		parsedData, err := funcs.Parse(body)
-		if err != nil {
-			return fmt.Errorf("parse response failed: %w", err)
+			return nil, err
 		}
 
 		// пробуем сконвертировать результаты поиска к единому формату. Обязательно type assertion, на входе interface{}
 		converted, err := funcs.ConvertDetails(parsedData)
 		if err != nil {
-			return fmt.Errorf("convert of details - failed: %w", err)
+			return nil, fmt.Errorf("convert of details - failed: %w", err)
 		}
-
-		vacancyDetails = converted
-
-		return nil
+		return &pipeline.Result{Details: converted}, nil
+	}, p.middlewares...)
+
+	result, err := handler(ctx, &pipeline.Request{
+		Kind:      pipeline.KindDetails,
+		Source:    p.name,
+		URL:       searchUrl,
+		VacancyID: vacancyID,
 	})
 
 	// если ошибки есть, определяем какого они рода
@@ -213,25 +236,29 @@ func (p *BaseParser) SearchVacancyDetailes(ctx context.Context, vacancyID string
 		return p.handleCircuitBreakerErrorVacancyDetails(err)
 	}
 
-	return vacancyDetails, nil
-
+	return result.Details, nil
 }
 
-// метод проверки доступности семафора
-func (p *BaseParser) acquireSemaphore(ctx context.Context) error {
-	select {
-	case p.semaphore <- struct{}{}:
-		return nil
-	case <-ctx.Done():
-		return fmt.Errorf("context canceled while waiting for semaphore: %w", ctx.Err())
-	case <-time.After(2 * time.Second):
-		return fmt.Errorf("semaphore timeout: %s API is busy", p.name)
+// метод выполнения запроса к источнику и разбора ответа (последнее звено цепочки middleware)
+func (p *BaseParser) fetch(ctx context.Context, url string, funcs ParserFuncs) (interface{}, error) {
+	resp, err := p.executeRequest(ctx, url)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// метод освобождения семафора
-func (p *BaseParser) releaseSemaphore() {
-	<-p.semaphore
+	// освобождаем ресурсы
+	defer p.drainAndClose(resp)
+
+	if err := p.checkResponseStatus(resp); err != nil {
+		return nil, err
+	}
+
+	// Чтение и парсинг (с ограничением размера тела ответа)
+	parsedData, err := p.decodeBody(resp, funcs)
+	if err != nil {
+		return nil, fmt.Errorf("parse response failed: %w", err)
+	}
+	return parsedData, nil
 }
 
 // метод для выполнения HTTP запроса через клиент
@@ -242,6 +269,9 @@ func (p *BaseParser) executeRequest(ctx context.Context, url string) (*http.Resp
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
+	// добавляем заголовки источника (User-Agent, Accept-Language, заголовки вендора)
+	p.applyHeaders(req)
+
 	// делаем запрос, получаем ответ
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
@@ -265,12 +295,17 @@ func (p *BaseParser) drainAndClose(resp *http.Response) {
 // метод проверки статуса ответа на запрос к API
 func (p *BaseParser) checkResponseStatus(resp *http.Response) error {
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
+		// тело ошибки читаем с лимитом и очищаем - оно попадёт в логи и в статус парсера
+		body := p.readErrorBody(resp)
+
+		// запоминаем паузу, которую запросил сервер (обычно приходит с 429 и 503)
+		retryAfter, _ := retry.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 
-		if resp.StatusCode >= 500 && resp.StatusCode < 600 {
-			return fmt.Errorf("API server error %d: %s", resp.StatusCode, string(body))
+		return &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       body,
+			RetryAfter: retryAfter,
 		}
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 	return nil
 }
@@ -289,6 +324,46 @@ func (p *BaseParser) GetHealthEndPoint() string {
 	return p.healthEndPoint
 }
 
+// GetProxyStats возвращает статистику пула прокси (false - если прокси не используется)
+func (p *BaseParser) GetProxyStats() ([]proxy.ProxyStats, bool) {
+	if p.proxyPool == nil {
+		return nil, false
+	}
+	return p.proxyPool.Stats(), true
+}
+
+// GetProxyConfig возвращает конфиг прокси источника
+func (p *BaseParser) GetProxyConfig() proxy.Config {
+	return p.proxyConfig
+}
+
+// GetResponseCacheStats возвращает статистику транспортного кэша (false - если кэш выключен)
+func (p *BaseParser) GetResponseCacheStats() (httpcache.Stats, bool) {
+	if p.responseCache == nil {
+		return httpcache.Stats{}, false
+	}
+	return p.responseCache.Stats(), true
+}
+
+// GetSchemaDrift возвращает состояние схем ответов источника (находки детектора дрейфа)
+func (p *BaseParser) GetSchemaDrift() schemadrift.Status {
+	return p.schemaDrift.Status()
+}
+
+// GetCircuitState возвращает состояние circuit breaker источника
+func (p *BaseParser) GetCircuitState() circuitbreaker.State {
+	return p.circuitBreaker.GetState()
+}
+
+// SubscribeCircuit добавляет обработчик смены состояния circuit breaker источника
+func (p *BaseParser) SubscribeCircuit(handler circuitbreaker.StateHandler) {
+	if breaker, ok := p.circuitBreaker.(interface {
+		Subscribe(circuitbreaker.StateHandler)
+	}); ok {
+		breaker.Subscribe(handler)
+	}
+}
+
 // Отдельная функция с дженериками для определния : обычная ошибка или ошибка circuitBreaker
 func handleCircuitBreakerErrorUniversal[T any](name string, cb interfaces.CBInterface, err error) (T, error) {
 	var zero T
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"parser/pkg"
+	"strings"
+)
+
+// User-Agent по умолчанию, если в конфиге источника заголовок не задан
+const defaultUserAgent = "JobParser/1.0"
+
+// функция построения заголовков запросов к источнику из шаблонов конфига
+// в шаблонах доступны переменные окружения, а также ${API_KEY} и ${PARSER_NAME} текущего источника
+func buildRequestHeaders(name, apiKey string, templates map[string]string) http.Header {
+	local := map[string]string{
+		"API_KEY":     apiKey,
+		"PARSER_NAME": name,
+	}
+
+	headers := make(http.Header, len(templates)+1)
+	for key, template := range templates {
+		value, missing := pkg.ExpandTemplate(template, local)
+		if len(missing) > 0 {
+			fmt.Printf("⚠️  [%s] заголовок %s: не заданы переменные окружения %s\n", name, key, strings.Join(missing, ", "))
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			// пустой заголовок хуже отсутствующего - некоторые API отвечают на него 400
+			continue
+		}
+		headers.Set(key, value)
+	}
+
+	if headers.Get("User-Agent") == "" {
+		headers.Set("User-Agent", defaultUserAgent)
+	}
+
+	return headers
+}
+
+// метод установки заголовков источника в запрос (заголовки, уже заданные в запросе, не перезаписываются)
+func (p *BaseParser) applyHeaders(req *http.Request) {
+	for key, values := range p.headers {
+		if req.Header.Get(key) != "" {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
+
+// GetRequestHeaders возвращает копию заголовков, которые парсер отправляет источнику
+// используется health check клиентом, чтобы проверки шли с теми же заголовками, что и рабочие запросы
+func (p *BaseParser) GetRequestHeaders() http.Header {
+	return p.headers.Clone()
+}
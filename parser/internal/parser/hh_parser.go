@@ -11,6 +11,8 @@ import (
 	"parser/internal/domain/models"
 	"parser/internal/interfaces"
 	"parser/internal/parser/model"
+	"parser/internal/schemadrift"
+	"parser/internal/skills"
 	"reflect"
 	"strconv"
 )
@@ -30,6 +32,7 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) interfaces.Parser {
 		Name:                  "HH.ru",
 		BaseURL:               cfg.BaseURL,
 		HealthEndPoint:        cfg.HealthEndPoint,
+		APIKey:                cfg.APIKey,
 		Timeout:               cfg.Timeout,
 		RateLimit:             cfg.RateLimit,
 		MaxConcurrent:         cfg.MaxConcurrent,
@@ -39,6 +42,17 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) interfaces.Parser {
 		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
 		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		RetryCfg:              cfg.Retry,
+		MaxResponseBytes:      cfg.MaxResponseBytes,
+		MaxErrorBodyBytes:     cfg.MaxErrorBodyBytes,
+		ResponseCacheCfg:      cfg.ResponseCache,
+		ProxyCfg:              cfg.Proxy,
+		Headers:               cfg.Headers,
+		CassetteCfg:           cfg.Cassette,
+		SchemaDriftCfg:        cfg.SchemaDrift,
+		Middleware:            cfg.Middleware,
+		FaultInjectionCfg:     cfg.FaultInjection,
+		HedgeCfg:              cfg.Hedge,
 	}
 
 	return &HHParser{
@@ -46,6 +60,12 @@ func NewHHParser(cfg *configs.ParserInstanceConfig) interfaces.Parser {
 	}
 }
 
+// ожидаемые схемы ответов API HH.ru (для обнаружения дрейфа схемы)
+var (
+	hhSearchSchema  = schemadrift.FromModel("search", model.SearchResponse{})
+	hhDetailsSchema = schemadrift.FromModel("details", model.SearchDetails{})
+)
+
 // метод парсера для поиска списка вакансий
 func (p *HHParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
 	return p.BaseParser.SearchVacancies(
@@ -53,8 +73,9 @@ func (p *HHParser) SearchVacancies(ctx context.Context, params models.SearchPara
 		params,
 		ParserFuncs{
 			BuildURL: p.buildURL,
-			Parse:    p.parseResponseSearchVacancies,
+			Decode:   p.decodeResponseSearchVacancies,
 			Convert:  p.convertToUniversal,
+			Schema:   hhSearchSchema,
 		},
 	)
 }
@@ -66,8 +87,9 @@ func (p *HHParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string
 		ctx,
 		vacancyID,
 		ParserFuncs{
-			Parse:          p.parseResponseSearchDetails,
+			Decode:         p.decodeResponseSearchDetails,
 			ConvertDetails: p.convertDetails,
+			Schema:         hhDetailsSchema,
 		},
 	)
 }
@@ -109,19 +131,34 @@ func (p *HHParser) buildURL(params models.SearchParams) (string, error) {
 	return u.String(), nil
 }
 
-// метод парсера обработки тела запроса при поиске списка вакансий
-func (p *HHParser) parseResponseSearchVacancies(body []byte) (interface{}, error) {
+// метод парсера потокового разбора тела ответа при поиске списка вакансий
+// вакансии декодируются по одной, поэтому большие страницы не требуют буфера на весь ответ
+func (p *HHParser) decodeResponseSearchVacancies(body io.Reader) (interface{}, error) {
 	var searchResponse model.SearchResponse
-	if err := json.Unmarshal(body, &searchResponse); err != nil {
+
+	err := decodeObjectStream(body, map[string]func(dec *json.Decoder) error{
+		"items": func(dec *json.Decoder) error {
+			return decodeArrayStream(dec, func(vacancy model.HHVacancy) {
+				searchResponse.Items = append(searchResponse.Items, vacancy)
+			})
+		},
+		"found": func(dec *json.Decoder) error {
+			return dec.Decode(&searchResponse.Found)
+		},
+		"pages": func(dec *json.Decoder) error {
+			return dec.Decode(&searchResponse.Pages)
+		},
+	})
+	if err != nil {
 		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
 	}
 	return &searchResponse, nil
 }
 
-// метод парсера обработки тела запроса при поиске списка вакансий
-func (p *HHParser) parseResponseSearchDetails(body []byte) (interface{}, error) {
+// метод парсера обработки тела запроса при поиске деталей вакансии
+func (p *HHParser) decodeResponseSearchDetails(body io.Reader) (interface{}, error) {
 	var searchResponse model.SearchDetails //--------------------------------------------------------------------???????
-	if err := json.Unmarshal(body, &searchResponse); err != nil {
+	if err := json.NewDecoder(body).Decode(&searchResponse); err != nil {
 		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
 	}
 	return &searchResponse, nil
@@ -161,10 +198,16 @@ func (p *HHParser) convertToUniversal(searchResponse interface{}) ([]models.Vaca
 			Company:     hhvacancy.Employer.Name,
 			Currency:    hhvacancy.Salary.Currency,
 			Salary:      &salary,
+			SalaryFrom:  hhvacancy.Salary.From,
+			SalaryTo:    hhvacancy.Salary.To,
 			Area:        hhvacancy.Area.Name,
 			URL:         hhvacancy.URL,
+			PublishedAt: hhvacancy.GetPublishedAt(),
+			Experience:  hhvacancy.GetExperience(),
+			Schedule:    hhvacancy.GetSchedule(),
 			Seeker:      p.GetName(),
 			Description: hhvacancy.Description,
+			Skills:      skills.Extract(hhvacancy.Name + " " + hhvacancy.Description), // в выдаче поиска HH нет key_skills
 		}
 	}
 
@@ -195,6 +238,13 @@ func (p *HHParser) convertDetails(detailsResponse interface{}) (models.SearchVac
 		Name:        searchResp.Name,
 		ID:          searchResp.ID,
 		Url:         searchResp.Url,
+		Archived:    searchResp.Archived,
+	}
+
+	// навыки берём из key_skills, а если работодатель их не указал - извлекаем из описания по словарю
+	vacDetails.Skills = skills.NormalizeAll(searchResp.GetKeySkillNames())
+	if len(vacDetails.Skills) == 0 {
+		vacDetails.Skills = skills.Extract(searchResp.Name + " " + searchResp.Description)
 	}
 
 	return vacDetails, nil
@@ -207,7 +257,13 @@ func (p *HHParser) GetVacancyByID(vacancyID string) (*model.HHVacancy, error) {
 	}
 
 	apiURL := p.baseURL + "/" + vacancyID
-	resp, err := p.httpClient.Get(apiURL)
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	p.applyHeaders(req)
+
+	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -218,7 +274,12 @@ func (p *HHParser) GetVacancyByID(vacancyID string) (*model.HHVacancy, error) {
 		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// читаем тело с ограничением размера
+	limited, err := p.limitBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(limited)
 	if err != nil {
 		return nil, fmt.Errorf("read response failed: %w", err)
 	}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"fmt"
+	"hash/fnv"
+	"parser/internal/pipeline"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// сколько запрос ждёт свободного слота семафора парсера
+const semaphoreWait = 2 * time.Second
+
+// MiddlewareFactory - функция создания middleware для конкретного парсера
+type MiddlewareFactory func(p *BaseParser) pipeline.Middleware
+
+// реестр middleware: имя в конфиге -> фабрика
+var (
+	middlewareMu       sync.RWMutex
+	middlewareRegistry = map[string]MiddlewareFactory{
+		pipeline.NameCircuitBreaker: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.CircuitBreaker(p.circuitBreaker)
+		},
+		pipeline.NameSemaphore: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.Semaphore(p.semaphore, semaphoreWait, p.name)
+		},
+		pipeline.NameRetry: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.Retry(p.retryPolicy, p.name)
+		},
+		pipeline.NameRateLimiter: func(p *BaseParser) pipeline.Middleware {
+			// ответ, который транспортный кэш отдаст без похода в сеть, не расходует квоту источника;
+			// условные запросы (ответ 304) идут к источнику и ждут общую квоту
+			return pipeline.RateLimiter(p.rateLimiter, func(url string) bool {
+				return p.responseCache != nil && p.responseCache.IsFresh(url)
+			})
+		},
+		pipeline.NameLogging: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.Logging(p.name)
+		},
+		pipeline.NameMetrics: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.Collect(p.metrics)
+		},
+		pipeline.NameHedge: func(p *BaseParser) pipeline.Middleware {
+			return pipeline.Hedge(p.hedge, p.hedgeStats, p.name)
+		},
+		pipeline.NameFaultInjection: func(p *BaseParser) pipeline.Middleware {
+			// зерно от имени парсера: у каждого источника своя, но воспроизводимая последовательность сбоев
+			hash := fnv.New64a()
+			_, _ = hash.Write([]byte(p.name))
+			return pipeline.FaultInjection(p.faultInjection, int64(hash.Sum64()))
+		},
+	}
+)
+
+// RegisterMiddleware регистрирует middleware под именем, которое можно указать в секции middleware конфига парсера
+// позволяет добавлять этапы обработки запросов (трассировка, авторизация и т.п.), не меняя код парсеров
+func RegisterMiddleware(name string, factory MiddlewareFactory) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middlewareRegistry[name] = factory
+}
+
+// метод сборки цепочки middleware по списку имён из конфига (пустой список - порядок по умолчанию)
+// неизвестные и повторяющиеся имена пропускаются с предупреждением
+func (p *BaseParser) buildMiddlewares(names []string) []pipeline.Middleware {
+	if len(names) == 0 {
+		names = pipeline.DefaultMiddleware()
+	}
+
+	middlewareMu.RLock()
+	defer middlewareMu.RUnlock()
+
+	middlewares := make([]pipeline.Middleware, 0, len(names))
+	used := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		factory, ok := middlewareRegistry[name]
+		if !ok {
+			fmt.Printf("⚠️  [%s] неизвестная middleware %q пропущена (доступны: %s)\n", p.name, name, strings.Join(registeredMiddlewareNames(), ", "))
+			continue
+		}
+		if _, dup := used[name]; dup {
+			fmt.Printf("⚠️  [%s] middleware %q указана повторно и пропущена\n", p.name, name)
+			continue
+		}
+		used[name] = struct{}{}
+
+		middlewares = append(middlewares, factory(p))
+	}
+
+	return middlewares
+}
+
+// функция получения отсортированного списка зарегистрированных middleware (вызывается под мьютексом реестра)
+func registeredMiddlewareNames() []string {
+	names := make([]string, 0, len(middlewareRegistry))
+	for name := range middlewareRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetHedgeStats возвращает счётчики дублирующих запросов к источнику (заполняются middleware hedge)
+func (p *BaseParser) GetHedgeStats() pipeline.HedgeSnapshot {
+	return p.hedgeStats.Snapshot()
+}
+
+// GetRequestMetrics возвращает метрики запросов к источнику по типам запросов (заполняются middleware metrics)
+func (p *BaseParser) GetRequestMetrics() map[pipeline.RequestKind]pipeline.MetricsSnapshot {
+	return p.metrics.Snapshot()
+}
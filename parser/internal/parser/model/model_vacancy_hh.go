@@ -1,16 +1,74 @@
 package model
 
-import "parser/pkg"
+import (
+	"parser/internal/domain/models"
+	"parser/pkg"
+	"time"
+)
 
 // HHVacancy представляет структуру вакансии с HH.ru
 type HHVacancy struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Salary      Salary   `json:"salary"`
-	Employer    Employer `json:"employer"`
-	Area        Area     `json:"area"`
-	URL         string   `json:"url"`
+	ID          string   `json:"id" drift:"critical"`
+	Name        string   `json:"name" drift:"critical"`
+	Salary      Salary   `json:"salary" drift:"required"`
+	Employer    Employer `json:"employer" drift:"required"`
+	Area        Area     `json:"area" drift:"required"`
+	URL         string   `json:"url" drift:"required"`
 	Description string   `json:"description"`
+	PublishedAt string   `json:"published_at"` // дата публикации в формате 2006-01-02T15:04:05-0700
+	Experience  Ref      `json:"experience"`   // требуемый опыт работы (id: noExperience, between1And3, ...)
+	Schedule    Ref      `json:"schedule"`     // график работы (id: fullDay, remote, ...)
+}
+
+// справочники опыта и графика работы HH.ru, приведённые к общим для всех источников значениям
+var (
+	hhExperience = map[string]string{
+		"noExperience": models.ExperienceNone,
+		"between1And3": models.ExperienceFrom1To3,
+		"between3And6": models.ExperienceFrom3To6,
+		"moreThan6":    models.ExperienceMore6,
+	}
+	hhSchedule = map[string]string{
+		"fullDay":     models.ScheduleFullDay,
+		"shift":       models.ScheduleShift,
+		"flexible":    models.ScheduleFlexible,
+		"remote":      models.ScheduleRemote,
+		"flyInFlyOut": models.ScheduleRotation,
+	}
+)
+
+// GetExperience возвращает требуемый опыт работы в общем для всех источников виде ("" - не указан)
+func (v HHVacancy) GetExperience() string {
+	if experience, ok := hhExperience[v.Experience.ID]; ok {
+		return experience
+	}
+	return v.Experience.Name
+}
+
+// GetSchedule возвращает график работы в общем для всех источников виде ("" - не указан)
+func (v HHVacancy) GetSchedule() string {
+	if schedule, ok := hhSchedule[v.Schedule.ID]; ok {
+		return schedule
+	}
+	return v.Schedule.Name
+}
+
+// Ref представляет элемент справочника HH.ru
+type Ref struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// формат дат в ответах API HH.ru
+const hhTimeLayout = "2006-01-02T15:04:05-0700"
+
+// GetPublishedAt возвращает дату публикации вакансии (нулевое время, если дата не указана или не разобрана)
+func (v HHVacancy) GetPublishedAt() time.Time {
+	publishedAt, err := time.Parse(hhTimeLayout, v.PublishedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return publishedAt
 }
 
 // Salary представляет информацию о зарплате
@@ -35,20 +93,36 @@ type Area struct {
 
 // SearchResponse представляет ответ от API HH.ru
 type SearchResponse struct {
-	Items []HHVacancy `json:"items"`
-	Found int         `json:"found"`
-	Pages int         `json:"pages"`
+	Items []HHVacancy `json:"items" drift:"critical"`
+	Found int         `json:"found" drift:"required"`
+	Pages int         `json:"pages" drift:"required"`
 }
 
 // предоставляет ответ API HH.ru по запросу с ID
 type SearchDetails struct {
-	Employer    Employer `json:"employer"`
-	Area        Area     `json:"area"`
-	Salary      Salary   `json:"salary"`
-	Description string   `json:"description"`
-	Name        string   `json:"name"`
-	ID          string   `json:"id"`
-	Url         string   `json:"alternate_url"`
+	Employer    Employer   `json:"employer" drift:"required"`
+	Area        Area       `json:"area" drift:"required"`
+	Salary      Salary     `json:"salary" drift:"required"`
+	Description string     `json:"description" drift:"critical"`
+	Name        string     `json:"name" drift:"critical"`
+	ID          string     `json:"id" drift:"critical"`
+	Url         string     `json:"alternate_url" drift:"required"`
+	KeySkills   []KeySkill `json:"key_skills" drift:"required"`
+	Archived    bool       `json:"archived"` // вакансия в архиве (закрыта работодателем)
+}
+
+// KeySkill представляет ключевой навык из ответа API HH.ru
+type KeySkill struct {
+	Name string `json:"name"`
+}
+
+// GetKeySkillNames возвращает названия ключевых навыков вакансии
+func (d SearchDetails) GetKeySkillNames() []string {
+	names := make([]string, 0, len(d.KeySkills))
+	for _, skill := range d.KeySkills {
+		names = append(names, skill.Name)
+	}
+	return names
 }
 
 // GetSalaryString возвращает форматированную строку зарплаты
@@ -1,23 +1,86 @@
 package model
 
-import "parser/pkg"
+import (
+	"parser/internal/domain/models"
+	"parser/pkg"
+	"time"
+)
 
 // Структуры для SuperJob API
 type SuperJobResponse struct {
-	Items []SJVacancy `json:"objects"`
-	Total int         `json:"total"`
+	Items []SJVacancy `json:"objects" drift:"critical"`
+	Total int         `json:"total" drift:"required"`
 }
 
 type SJVacancy struct {
-	ID              int    `json:"id"`
-	Profession      string `json:"profession"`
-	FirmName        string `json:"firm_name"`
-	PaymentFrom     int    `json:"payment_from"`
-	PaymentTo       int    `json:"payment_to"`
-	Currency        string `json:"currency"`
-	Town            Town   `json:"town"`
-	Link            string `json:"link"`
-	VacancyRichText string `json:"vacancyRichText"`
+	ID              int    `json:"id" drift:"critical"`
+	Profession      string `json:"profession" drift:"critical"`
+	FirmName        string `json:"firm_name" drift:"required"`
+	PaymentFrom     int    `json:"payment_from" drift:"required"`
+	PaymentTo       int    `json:"payment_to" drift:"required"`
+	Currency        string `json:"currency" drift:"required"`
+	Town            Town   `json:"town" drift:"required"`
+	Link            string `json:"link" drift:"required"`
+	VacancyRichText string `json:"vacancyRichText" drift:"required"`
+	IsArchive       bool   `json:"is_archive"`     // вакансия в архиве (закрыта работодателем)
+	DatePublished   int64  `json:"date_published"` // дата публикации (unix time)
+	Experience      SJRef  `json:"experience"`     // требуемый опыт работы (id: 1 - без опыта, 2 - от 1 года, ...)
+	TypeOfWork      SJRef  `json:"type_of_work"`   // тип занятости (id: 6 - полный рабочий день, ...)
+	PlaceOfWork     SJRef  `json:"place_of_work"`  // место работы (id: 2 - удалённая работа)
+}
+
+// справочники опыта и типа занятости SuperJob, приведённые к общим для всех источников значениям
+var (
+	sjExperience = map[int]string{
+		1: models.ExperienceNone,
+		2: models.ExperienceFrom1To3,
+		3: models.ExperienceFrom3To6,
+		4: models.ExperienceMore6,
+	}
+	sjTypeOfWork = map[int]string{
+		6:  models.ScheduleFullDay,
+		10: models.SchedulePartTime,
+		12: models.ScheduleShift,
+		13: models.SchedulePartTime,
+		9:  models.ScheduleRotation,
+	}
+)
+
+// место работы SuperJob "удалённая работа"
+const sjRemotePlaceOfWork = 2
+
+// GetExperience возвращает требуемый опыт работы в общем для всех источников виде ("" - не указан)
+func (v SJVacancy) GetExperience() string {
+	if experience, ok := sjExperience[v.Experience.ID]; ok {
+		return experience
+	}
+	return v.Experience.Title
+}
+
+// GetSchedule возвращает график работы в общем для всех источников виде ("" - не указан)
+// у SuperJob удалённая работа - место работы, а не тип занятости, она важнее для выбора
+func (v SJVacancy) GetSchedule() string {
+	if v.PlaceOfWork.ID == sjRemotePlaceOfWork {
+		return models.ScheduleRemote
+	}
+	if schedule, ok := sjTypeOfWork[v.TypeOfWork.ID]; ok {
+		return schedule
+	}
+	return v.TypeOfWork.Title
+}
+
+// SJRef представляет элемент справочника SuperJob
+type SJRef struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// GetPublishedAt возвращает дату публикации вакансии (нулевое время, если дата не указана)
+func (v SJVacancy) GetPublishedAt() time.Time {
+	if v.DatePublished <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(v.DatePublished, 0)
 }
 
 type Town struct {
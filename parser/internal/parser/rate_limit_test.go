@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"parser/configs"
+	"parser/internal/cassette"
+	"parser/internal/domain/models"
+	"parser/internal/fakesource"
+	"parser/internal/httpcache"
+	"sync"
+	"testing"
+	"time"
+)
+
+// обработчик, запоминающий время прихода каждого запроса к источнику
+type arrivals struct {
+	mu    sync.Mutex
+	times []time.Time
+	next  http.Handler
+}
+
+func (a *arrivals) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.times = append(a.times, time.Now())
+	a.mu.Unlock()
+	a.next.ServeHTTP(w, r)
+}
+
+func (a *arrivals) snapshot() []time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]time.Time(nil), a.times...)
+}
+
+func TestRevalidationsShareSourceRateLimit(t *testing.T) {
+	const rate = 40 * time.Millisecond
+
+	source := fakesource.NewHHServer(fakesource.DefaultConfig())
+	recorder := &arrivals{next: source.Handler()}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	config := configs.DefaultParsersConfig().HH
+	config.BaseURL = server.URL + source.BaseURL()
+	config.RateLimit = rate
+	config.Retry.MaxAttempts = 1
+	config.Cassette = cassette.DefaultConfig()
+	config.ResponseCache.Enabled = true
+	config.ResponseCache.FreshTTL = 0 // каждый повторный запрос - условный (ответ 304)
+
+	hh := NewHHParser(config)
+	ctx := context.Background()
+
+	vacancies, err := hh.SearchVacancies(ctx, models.SearchParams{Text: "go", PerPage: 3})
+	if err != nil || len(vacancies) < 3 {
+		t.Fatalf("search: %d vacancies, err %v", len(vacancies), err)
+	}
+
+	// детали запрашиваются параллельно: первые запросы - полные, повторные - условные
+	var wg sync.WaitGroup
+	for worker := 0; worker < 4; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				if _, err := hh.SearchVacanciesDetailes(ctx, vacancies[(worker+i)%3].ID); err != nil {
+					t.Errorf("details: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats, ok := hh.(interface {
+		GetResponseCacheStats() (httpcache.Stats, bool)
+	}).GetResponseCacheStats()
+	if !ok || stats.Revalidated == 0 {
+		t.Fatalf("cache stats = %+v, want revalidations (304) among the requests", stats)
+	}
+
+	// все запросы к источнику, включая условные, идут по одной квоте: не чаще одного за интервал rate_limit
+	// (допуск - на планирование горутин; отдельная квота для 304 давала бы промежутки около нуля)
+	times := recorder.snapshot()
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < rate*3/4 {
+			t.Errorf("requests %d and %d arrived %v apart, rate limit is %v", i, i+1, gap, rate)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"parser/pkg"
+	"strings"
+)
+
+// значения по умолчанию для ограничений на размер ответа
+const (
+	defaultMaxResponseBytes  = 10 << 20 // 10MB - максимальный размер тела успешного ответа
+	defaultMaxErrorBodyBytes = 512      // сколько байт тела ответа с ошибкой попадает в текст ошибки
+)
+
+// ErrResponseTooLarge - тело ответа источника превысило допустимый размер
+var ErrResponseTooLarge = errors.New("response body exceeds size limit")
+
+// ридер, который возвращает ErrResponseTooLarge при превышении лимита (в отличие от io.LimitReader, который молча обрезает)
+type limitedBody struct {
+	reader    io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedBody) Read(b []byte) (int, error) {
+	if l.remaining <= 0 {
+		// проверяем, есть ли данные за пределами лимита
+		var probe [1]byte
+		n, err := l.reader.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, l.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(b)) > l.remaining {
+		b = b[:l.remaining]
+	}
+	n, err := l.reader.Read(b)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// метод получения тела ответа с ограничением размера
+// если сервер заранее сообщил Content-Length больше лимита - не читаем тело вовсе
+func (p *BaseParser) limitBody(resp *http.Response) (io.Reader, error) {
+	if resp.ContentLength > p.maxResponseBytes {
+		return nil, fmt.Errorf("%w: Content-Length %d > %d", ErrResponseTooLarge, resp.ContentLength, p.maxResponseBytes)
+	}
+	return &limitedBody{reader: resp.Body, remaining: p.maxResponseBytes, limit: p.maxResponseBytes}, nil
+}
+
+// метод декодирования тела успешного ответа
+// если парсер предоставил потоковый декодер (Decode) - используем его, иначе читаем тело целиком и вызываем Parse
+// если ответ попал в выборку проверки дрейфа схемы - тело читается целиком, сверяется со схемой и затем декодируется из памяти
+func (p *BaseParser) decodeBody(resp *http.Response, funcs ParserFuncs) (interface{}, error) {
+	body, err := p.limitBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.schemaDrift.ShouldSample(funcs.Schema) {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("read response failed: %w", err)
+		}
+		p.schemaDrift.Check(funcs.Schema, data)
+		body = bytes.NewReader(data)
+	}
+
+	if funcs.Decode != nil {
+		return funcs.Decode(body)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("read response failed: %w", err)
+	}
+	return funcs.Parse(data)
+}
+
+// метод чтения тела ответа с ошибкой: читаем не больше лимита, убираем управляющие символы и API ключ
+// результат безопасно класть в логи и в ParserStatus.LastError
+func (p *BaseParser) readErrorBody(resp *http.Response) string {
+	// читаем на 1 байт больше лимита, чтобы SanitizeText понял, что текст обрезан
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, int64(p.maxErrorBodyBytes)+1))
+	return p.sanitize(string(data))
+}
+
+// метод очистки текста от внешнего источника перед выводом в логи
+func (p *BaseParser) sanitize(text string) string {
+	if p.apiKey != "" {
+		text = strings.ReplaceAll(text, p.apiKey, "***")
+	}
+	return pkg.SanitizeText(text, p.maxErrorBodyBytes)
+}
+
+// функция потокового разбора JSON объекта верхнего уровня
+// для полей из fields вызывается соответствующий обработчик, остальные поля пропускаются
+func decodeObjectStream(r io.Reader, fields map[string]func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected JSON token %v, object key expected", token)
+		}
+
+		handler, ok := fields[key]
+		if !ok {
+			// поле нам не нужно - пропускаем его значение целиком
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := handler(dec); err != nil {
+			return fmt.Errorf("field %q: %w", key, err)
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// функция потокового разбора JSON массива: элементы декодируются по одному,
+// поэтому в памяти декодера одновременно находится только один элемент страницы
+func decodeArrayStream[T any](dec *json.Decoder, onItem func(item T)) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	// null вместо массива - просто нет данных
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("unexpected JSON token %v, array expected", token)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		onItem(item)
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// функция проверки, что следующий токен - ожидаемый разделитель
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("unexpected JSON token %v, %q expected", token, expected)
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"parser/internal/domain/models"
 	"parser/internal/interfaces"
 	"parser/internal/parser/model"
+	"parser/internal/schemadrift"
+	"parser/internal/skills"
 	"reflect"
 	"strconv"
 )
@@ -40,6 +42,17 @@ func NewSJParser(cfg *configs.ParserInstanceConfig) interfaces.Parser {
 		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
 		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
 		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		RetryCfg:              cfg.Retry,
+		MaxResponseBytes:      cfg.MaxResponseBytes,
+		MaxErrorBodyBytes:     cfg.MaxErrorBodyBytes,
+		ResponseCacheCfg:      cfg.ResponseCache,
+		ProxyCfg:              cfg.Proxy,
+		Headers:               cfg.Headers,
+		CassetteCfg:           cfg.Cassette,
+		SchemaDriftCfg:        cfg.SchemaDrift,
+		Middleware:            cfg.Middleware,
+		FaultInjectionCfg:     cfg.FaultInjection,
+		HedgeCfg:              cfg.Hedge,
 	}
 
 	return &SJParser{
@@ -47,6 +60,12 @@ func NewSJParser(cfg *configs.ParserInstanceConfig) interfaces.Parser {
 	}
 }
 
+// ожидаемые схемы ответов API SuperJob (для обнаружения дрейфа схемы)
+var (
+	sjSearchSchema  = schemadrift.FromModel("search", model.SuperJobResponse{})
+	sjDetailsSchema = schemadrift.FromModel("details", model.SJVacancy{}) // детали вакансии SuperJob отдаёт в том же формате, что и элемент поиска
+)
+
 // метод парсера для поиска списка вакансий
 func (p *SJParser) SearchVacancies(ctx context.Context, params models.SearchParams) ([]models.Vacancy, error) {
 	return p.BaseParser.SearchVacancies(
@@ -54,8 +73,9 @@ func (p *SJParser) SearchVacancies(ctx context.Context, params models.SearchPara
 		params,
 		ParserFuncs{
 			BuildURL: p.buildURL,
-			Parse:    p.parseResponseSearchVacancies,
+			Decode:   p.decodeResponseSearchVacancies,
 			Convert:  p.convertToUniversal,
+			Schema:   sjSearchSchema,
 		},
 	)
 }
@@ -66,8 +86,9 @@ func (p *SJParser) SearchVacanciesDetailes(ctx context.Context, vacancyID string
 		ctx,
 		vacancyID,
 		ParserFuncs{
-			Parse:          p.parseResponseSearchDetails,
+			Decode:         p.decodeResponseSearchDetails,
 			ConvertDetails: p.convertDetails,
+			Schema:         sjDetailsSchema,
 		},
 	)
 }
@@ -103,19 +124,31 @@ func (p *SJParser) buildURL(params models.SearchParams) (string, error) {
 	return u.String(), nil
 }
 
-// метод парсера обработки тела запроса
-func (p *SJParser) parseResponseSearchVacancies(body []byte) (interface{}, error) {
+// метод парсера потокового разбора тела ответа при поиске списка вакансий
+// вакансии декодируются по одной, поэтому большие страницы не требуют буфера на весь ответ
+func (p *SJParser) decodeResponseSearchVacancies(body io.Reader) (interface{}, error) {
 	var searchResponse model.SuperJobResponse
-	if err := json.Unmarshal(body, &searchResponse); err != nil {
+
+	err := decodeObjectStream(body, map[string]func(dec *json.Decoder) error{
+		"objects": func(dec *json.Decoder) error {
+			return decodeArrayStream(dec, func(vacancy model.SJVacancy) {
+				searchResponse.Items = append(searchResponse.Items, vacancy)
+			})
+		},
+		"total": func(dec *json.Decoder) error {
+			return dec.Decode(&searchResponse.Total)
+		},
+	})
+	if err != nil {
 		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
 	}
 	return &searchResponse, nil
 }
 
-// метод парсера обработки тела запроса при поиске списка вакансий
-func (p *SJParser) parseResponseSearchDetails(body []byte) (interface{}, error) {
-	var searchResponse model.SearchDetails //--------------------------------------------------------------------???????
-	if err := json.Unmarshal(body, &searchResponse); err != nil {
+// метод парсера обработки тела запроса при поиске деталей вакансии
+func (p *SJParser) decodeResponseSearchDetails(body io.Reader) (interface{}, error) {
+	var searchResponse model.SJVacancy // детали вакансии SuperJob отдаёт в том же формате, что и элемент поиска
+	if err := json.NewDecoder(body).Decode(&searchResponse); err != nil {
 		return nil, fmt.Errorf("[Parser name: %s] parse reaponse body - failed: %w", p.name, err)
 	}
 	return &searchResponse, nil
@@ -129,16 +162,27 @@ func (p *SJParser) convertDetails(detailsResponse interface{}) (models.SearchVac
 	}
 
 	// Проводим type assertion
-	searchResp, ok := detailsResponse.(*model.SearchDetails)
+	searchResp, ok := detailsResponse.(*model.SJVacancy)
 	if !ok {
 
 		// Для более детальной информации можно использовать reflect
-		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(searchResp))
+		fmt.Printf("----------------->>>[Parser name: %s] DEBUG: Type details: %v\n", p.name, reflect.TypeOf(detailsResponse))
 		return models.SearchVacancyDetailesResult{}, fmt.Errorf("[Parser name: %s], wrong data type in the response body\n", p.name)
 	}
 
-	var vacDetails models.SearchVacancyDetailesResult
-	vacDetails.Description = searchResp.Description
+	vacDetails := models.SearchVacancyDetailesResult{
+		Employer:    models.Employer{Name: searchResp.FirmName},
+		Area:        models.Area{Name: searchResp.Town.Title},
+		Salary:      models.Salary{From: searchResp.PaymentFrom, To: searchResp.PaymentTo, Currency: searchResp.Currency},
+		Description: searchResp.VacancyRichText,
+		Name:        searchResp.Profession,
+		ID:          strconv.Itoa(searchResp.ID),
+		Url:         searchResp.Link,
+		Archived:    searchResp.IsArchive,
+	}
+
+	// у SuperJob нет структурированных навыков - извлекаем их из описания по словарю
+	vacDetails.Skills = skills.Extract(searchResp.Profession + " " + searchResp.VacancyRichText)
 
 	return vacDetails, nil
 }
@@ -165,10 +209,16 @@ func (p *SJParser) convertToUniversal(searchResponse interface{}) ([]models.Vaca
 			Company:     sjv.FirmName,
 			Currency:    sjv.Currency,
 			Salary:      &salary,
+			SalaryFrom:  sjv.PaymentFrom,
+			SalaryTo:    sjv.PaymentTo,
 			Area:        sjv.Town.Title,
 			URL:         sjv.Link,
+			PublishedAt: sjv.GetPublishedAt(),
+			Experience:  sjv.GetExperience(),
+			Schedule:    sjv.GetSchedule(),
 			Seeker:      p.GetName(),
 			Description: sjv.VacancyRichText,
+			Skills:      skills.Extract(sjv.Profession + " " + sjv.VacancyRichText),
 		}
 	}
 	return universalVacancies, nil
@@ -195,7 +245,13 @@ func (p *SJParser) GetVacancyByID(vacancyID string) (*model.SJVacancy, error) {
 	}
 
 	apiURL := p.baseURL + "/" + vacancyID
-	resp, err := p.httpClient.Get(apiURL)
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	p.applyHeaders(req)
+
+	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -206,7 +262,12 @@ func (p *SJParser) GetVacancyByID(vacancyID string) (*model.SJVacancy, error) {
 		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	// читаем тело с ограничением размера
+	limited, err := p.limitBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(limited)
 	if err != nil {
 		return nil, fmt.Errorf("read response failed: %w", err)
 	}
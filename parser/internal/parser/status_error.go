@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"fmt"
+	"time"
+)
+
+// StatusError - ошибка ответа API с кодом, отличным от 200
+// хранит код статуса и паузу из Retry-After, чтобы политика повторов могла принять решение
+type StatusError struct {
+	StatusCode int           // HTTP статус ответа
+	Body       string        // тело ответа (для диагностики)
+	RetryAfter time.Duration // пауза, которую запросил сервер (0 - если заголовка не было)
+}
+
+func (e *StatusError) Error() string {
+	if e.StatusCode >= 500 && e.StatusCode < 600 {
+		return fmt.Sprintf("API server error %d: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatus возвращает HTTP статус ответа (нужен middleware повторов)
+func (e *StatusError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// RetryAfterDelay возвращает паузу, которую запросил сервер
+func (e *StatusError) RetryAfterDelay() time.Duration {
+	return e.RetryAfter
+}
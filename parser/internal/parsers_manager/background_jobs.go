@@ -0,0 +1,169 @@
+// фоновые джобы (запуски сохранённых поисков и перепроверки отслеживаемых вакансий): повторы при ошибке с экспоненциальной паузой,
+// джобы, исчерпавшие повторы, попадают в dead-letter, откуда их можно повторить вручную или удалить
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"parser/internal/deadletter"
+	"parser/internal/domain/models"
+	"parser/internal/pipeline"
+	"parser/internal/queue"
+	"parser/internal/retry"
+	"strings"
+	"time"
+)
+
+// метод выполнения фонового поиска с повторами по политике background_jobs.retry
+// поиск, исчерпавший повторы (или время запуска), сохраняется в dead-letter; отменённый вызывающим - нет
+func (pm *ParsersManager) runBackgroundSearch(ctx context.Context, origin string, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
+	var attempts []deadletter.Attempt
+	for attempt := 1; ; attempt++ {
+		results, err := pm.attemptSearch(ctx, params, queue.PriorityLow)
+		if err == nil {
+			return results, nil
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return results, err
+		}
+		attempts = append(attempts, deadletter.Attempt{At: time.Now(), Error: err.Error()})
+
+		if attempt < pm.jobRetry.MaxAttempts() && retry.Sleep(ctx, pm.jobRetry.Backoff(attempt)) == nil {
+			continue
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return results, err
+		}
+
+		entry := pm.deadLetters.Add(deadletter.Entry{
+			Kind:     deadletter.KindSearch,
+			Origin:   origin,
+			Params:   params,
+			Attempts: attempts,
+		})
+		fmt.Printf("☠️  %s: джоба поиска «%s» не выполнена после %d попыток и перенесена в dead-letter (меню 9), ID %s\n",
+			origin, params.Text, len(attempts), entry.ID)
+		return results, err
+	}
+}
+
+// метод фоновой перепроверки отслеживаемой вакансии с повторами по политике background_jobs.retry
+// 404 - ответ источника (вакансия удалена), а не сбой: не повторяется и в dead-letter не попадает;
+// повторные неудачи той же вакансии дописываются в историю уже сохранённой записи, а не плодят новые
+func (pm *ParsersManager) runBackgroundDetails(ctx context.Context, origin, source, vacancyID string) (models.SearchVacancyDetailesResult, error) {
+	var attempts []deadletter.Attempt
+	for attempt := 1; ; attempt++ {
+		details, err := pm.attemptWatchedVacancy(ctx, source, vacancyID, queue.PriorityLow)
+		if err == nil || pipeline.IsNotFound(err) || errors.Is(ctx.Err(), context.Canceled) {
+			return details, err
+		}
+		attempts = append(attempts, deadletter.Attempt{At: time.Now(), Error: err.Error()})
+
+		if attempt < pm.jobRetry.MaxAttempts() && retry.Sleep(ctx, pm.jobRetry.Backoff(attempt)) == nil {
+			continue
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return details, err
+		}
+
+		if existing, ok := pm.findDetailsDeadLetter(source, vacancyID); ok {
+			for _, failed := range attempts {
+				if recordErr := pm.deadLetters.RecordAttempt(existing.ID, failed); recordErr != nil {
+					break
+				}
+			}
+			fmt.Printf("☠️  %s: перепроверка %s:%s снова не выполнена, попытки добавлены в dead-letter (меню 9), ID %s\n",
+				origin, source, vacancyID, existing.ID)
+			return details, err
+		}
+
+		entry := pm.deadLetters.Add(deadletter.Entry{
+			Kind:      deadletter.KindDetails,
+			Origin:    origin,
+			Source:    source,
+			VacancyID: vacancyID,
+			Attempts:  attempts,
+		})
+		fmt.Printf("☠️  %s: перепроверка %s:%s не выполнена после %d попыток и перенесена в dead-letter (меню 9), ID %s\n",
+			origin, source, vacancyID, len(attempts), entry.ID)
+		return details, err
+	}
+}
+
+// метод поиска записи dead-letter о деталях вакансии
+func (pm *ParsersManager) findDetailsDeadLetter(source, vacancyID string) (deadletter.Entry, bool) {
+	for _, entry := range pm.deadLetters.List() {
+		if entry.Kind == deadletter.KindDetails && entry.Source == source && entry.VacancyID == vacancyID {
+			return entry, true
+		}
+	}
+	return deadletter.Entry{}, false
+}
+
+// метод одной попытки поиска: ошибка - если джоба не выполнена или ни один источник не ответил
+func (pm *ParsersManager) attemptSearch(ctx context.Context, params models.SearchParams, priority queue.Priority) ([]models.SearchVacanciesResult, error) {
+	results, err := pm.searchVacancies(ctx, params, priority)
+	if err != nil {
+		return results, err
+	}
+
+	var failures []string
+	for _, result := range results {
+		if result.Error == nil {
+			return results, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", result.ParserName, result.Error))
+	}
+	if len(failures) == 0 {
+		return results, fmt.Errorf("ни один источник не участвовал в поиске")
+	}
+	return results, errors.New(strings.Join(failures, "; "))
+}
+
+// ReplayDeadLetter вручную повторяет джобу из dead-letter (одна попытка, без автоматических повторов)
+// при успехе запись удаляется, при ошибке попытка добавляется в её историю; возвращает описание результата
+// неизвестный ID - deadletter.ErrNotFound
+func (pm *ParsersManager) ReplayDeadLetter(ctx context.Context, id string) (string, error) {
+	entry, err := pm.deadLetters.Get(id)
+	if err != nil {
+		return "", err
+	}
+
+	var summary string
+	switch entry.Kind {
+	case deadletter.KindSearch:
+		var results []models.SearchVacanciesResult
+		results, err = pm.attemptSearch(ctx, entry.Params, queue.PriorityNormal)
+		found := 0
+		for _, result := range results {
+			found += len(result.Vacancies)
+		}
+		summary = fmt.Sprintf("поиск выполнен, найдено вакансий: %d", found)
+	case deadletter.KindDetails:
+		var details models.SearchVacancyDetailesResult
+		details, err = pm.attemptWatchedVacancy(ctx, entry.Source, entry.VacancyID, queue.PriorityNormal)
+		switch {
+		case pipeline.IsNotFound(err):
+			// источник ответил: вакансия удалена - отслеживание отметит её закрытой при следующей проверке
+			err = nil
+			summary = fmt.Sprintf("вакансия %s:%s удалена у источника", entry.Source, entry.VacancyID)
+		case err == nil:
+			summary = fmt.Sprintf("детали вакансии «%s» получены, изменения отслеживание покажет при следующей проверке", details.Name)
+		}
+	default:
+		return "", fmt.Errorf("неизвестный вид джобы %q", entry.Kind)
+	}
+
+	if err != nil {
+		if recordErr := pm.deadLetters.RecordAttempt(id, deadletter.Attempt{At: time.Now(), Error: err.Error(), Replay: true}); recordErr != nil {
+			return "", recordErr
+		}
+		return "", err
+	}
+
+	if err := pm.deadLetters.Remove(id); err != nil {
+		return "", err
+	}
+	return summary, nil
+}
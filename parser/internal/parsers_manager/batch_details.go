@@ -0,0 +1,257 @@
+package parsers_manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/internal/queue"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// сколько вакансий из последнего поиска берётся в пачку, если пользователь не указал количество
+const defaultBatchFromLastSearch = 50
+
+// метод получения полной информации сразу по нескольким вакансиям (из разных источников)
+func (pm *ParsersManager) GetBatchVacancyDetails(scanner *bufio.Scanner) error {
+	fmt.Println("\n📚 Полные описания нескольких вакансий")
+
+	refs, err := pm.getVacancyRefsFromInput(scanner)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("⏳ Загружаем детали %d вакансий...\n", len(refs))
+	start := time.Now()
+
+	// пачка тяжелее одиночного запроса: уступает интерактивным поискам, но обгоняет фоновые задачи
+	results, err := pm.executeBatchDetails(context.Background(), refs, queue.PriorityNormal)
+	if err != nil {
+		return err
+	}
+
+	var fromCache, failed int
+	for _, item := range results {
+		if item.Error != nil {
+			failed++
+			fmt.Printf("\n❌ %s:%s - %v\n", item.Source, item.VacancyID, item.Error)
+			continue
+		}
+		if item.FromCache {
+			fromCache++
+		}
+		printVacancyDetails(detailsToVacancy(item.Result))
+	}
+
+	fmt.Printf("\n🎯 Получено %d из %d (из кэша: %d), ошибок: %d, время: %v\n",
+		len(results)-failed, len(results), fromCache, failed, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// метод менджера парсеров, который формирует джобу получения деталей пачки вакансий, добавляет её в очередь и дожидается результата
+// возвращает результаты в порядке входного списка, ошибки по отдельным вакансиям - внутри результатов
+func (pm *ParsersManager) executeBatchDetails(ctx context.Context, refs []models.VacancyRef, priority queue.Priority) ([]models.BatchDetailsItemResult, error) {
+	batchCfg := pm.config.Manager.BatchDetails
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("❌ Список вакансий пуст")
+	}
+	if batchCfg.MaxItems > 0 && len(refs) > batchCfg.MaxItems {
+		return nil, fmt.Errorf("❌ Слишком много вакансий в пачке: %d (максимум %d)", len(refs), batchCfg.MaxItems)
+	}
+
+	job := pm.NewBatchDetailsJob(ctx, refs, priority)
+
+	// Пытаемся добавить в очередь с таймаутом и повторными попытками
+	if !pm.tryEnqueueJob(ctx, job, 5*time.Second) {
+		return nil, fmt.Errorf("❌ Джоба не была добавлена в очередь")
+	}
+	// после возврата (результат, таймаут ожидания или отмена) джоба не должна расходовать квоту источников
+	defer pm.releaseJob(job)
+
+	// ждём чуть дольше таймаута самой пачки: по его истечении воркер вернёт то, что успел получить
+	return waitJobResult[[]models.BatchDetailsItemResult](ctx, job.ResultChan, batchCfg.Timeout+5*time.Second)
+}
+
+// Основная логика получения деталей пачки вакансий
+// повторяющиеся вакансии запрашиваются один раз, найденные в кэше деталей - не запрашиваются вовсе,
+// остальные запрашиваются параллельно по источникам: не более PerSourceConcurrency одновременно в каждый источник,
+// дальше запросы ограничивают семафор и rate limiter самого парсера
+func (pm *ParsersManager) fetchBatchDetails(ctx context.Context, refs []models.VacancyRef) []models.BatchDetailsItemResult {
+	results := make([]models.BatchDetailsItemResult, len(refs))
+
+	firstIndex := make(map[string]int, len(refs)) // ключ кэша деталей -> индекс первого вхождения
+	bySource := make(map[string][]int)            // источник -> индексы вакансий, которые нужно запросить
+
+	for i, ref := range refs {
+		results[i].Source = ref.Source
+		results[i].VacancyID = ref.VacancyID
+
+		key := detailsCacheKey(ref.Source, ref.VacancyID)
+		if _, dup := firstIndex[key]; dup {
+			continue
+		}
+		firstIndex[key] = i
+
+		cached, found, err := pm.tryGetDetailsFromCache(ref.Source, ref.VacancyID)
+		if found && err == nil {
+			results[i].Result = cached
+			results[i].FromCache = true
+			continue
+		}
+		bySource[ref.Source] = append(bySource[ref.Source], i)
+	}
+
+	concurrency := max(pm.config.Manager.BatchDetails.PerSourceConcurrency, 1)
+
+	var wg sync.WaitGroup
+	for _, indexes := range bySource {
+		queue := make(chan int, len(indexes))
+		for _, i := range indexes {
+			queue <- i
+		}
+		close(queue)
+
+		for w := 0; w < min(concurrency, len(indexes)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range queue {
+					// каждая горутина пишет только в свои элементы results, мьютекс не нужен
+					results[i].Result, results[i].Error = pm.fetchBatchItem(ctx, refs[i])
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	// повторяющиеся вакансии получают результат первого вхождения
+	for i, ref := range refs {
+		if first := firstIndex[detailsCacheKey(ref.Source, ref.VacancyID)]; first != i {
+			results[i] = results[first]
+		}
+	}
+
+	return results
+}
+
+// метод получения деталей одной вакансии из пачки через глобальный circuit breaker
+func (pm *ParsersManager) fetchBatchItem(ctx context.Context, ref models.VacancyRef) (models.SearchVacancyDetailesResult, error) {
+	// пачка могла не уложиться в таймаут - оставшиеся вакансии не запрашиваем
+	if err := ctx.Err(); err != nil {
+		return models.SearchVacancyDetailesResult{}, fmt.Errorf("не успели запросить: %w", err)
+	}
+
+	// неизвестный источник - ошибка ввода, а не сбой: проверяем до circuit breaker, чтобы не копить ему отказы
+	if _, err := pm.parserByName(ref.Source); err != nil {
+		return models.SearchVacancyDetailesResult{}, err
+	}
+
+	var result models.SearchVacancyDetailesResult
+	err := pm.executeWithCB(ctx, func() error {
+		var err error
+		result, err = pm.searchVacancyDetailes(ctx, ref.VacancyID, ref.Source, false)
+		return err
+	})
+	return result, err
+}
+
+// метод получения списка вакансий для пачки из ввода: пары источник:ID или первые N из последнего поиска
+func (pm *ParsersManager) getVacancyRefsFromInput(scanner *bufio.Scanner) ([]models.VacancyRef, error) {
+	fmt.Print("Введите вакансии в формате источник:ID через пробел или запятую (Enter - из последнего поиска): ")
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input != "" {
+		return parseVacancyRefs(input)
+	}
+
+	count := defaultBatchFromLastSearch
+	fmt.Printf("Сколько вакансий взять из последнего поиска (по умолчанию %d): ", count)
+	if scanner.Scan() {
+		if countStr := strings.TrimSpace(scanner.Text()); countStr != "" {
+			n, err := strconv.Atoi(countStr)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("❌ Неверное количество вакансий: %q\n", countStr)
+			}
+			count = n
+		}
+	}
+
+	refs := pm.lastSearchTop(count)
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("❌ Нет результатов последнего поиска, сначала выполните поиск (пункт меню 1)\n")
+	}
+	return refs, nil
+}
+
+// функция разбора списка пар источник:ID
+func parseVacancyRefs(input string) ([]models.VacancyRef, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t'
+	})
+
+	refs := make([]models.VacancyRef, 0, len(fields))
+	for _, field := range fields {
+		source, vacancyID, ok := strings.Cut(field, ":")
+		source, vacancyID = strings.TrimSpace(source), strings.TrimSpace(vacancyID)
+		if !ok || source == "" || vacancyID == "" {
+			return nil, fmt.Errorf("❌ Неверный формат %q, ожидается источник:ID (например, HH.ru:123456)\n", field)
+		}
+		refs = append(refs, models.VacancyRef{Source: source, VacancyID: vacancyID})
+	}
+	return refs, nil
+}
+
+// метод запоминания вакансий последнего поиска (для получения деталей пачкой)
+// вакансии источников чередуются, чтобы первые N содержали лучшие результаты каждого источника
+func (pm *ParsersManager) rememberLastSearch(results []models.SearchVacanciesResult) {
+	var refs []models.VacancyRef
+	for i := 0; ; i++ {
+		added := false
+		for _, result := range results {
+			if result.Error != nil || i >= len(result.Vacancies) {
+				continue
+			}
+			refs = append(refs, models.VacancyRef{Source: result.ParserName, VacancyID: result.Vacancies[i].ID})
+			added = true
+		}
+		if !added {
+			break
+		}
+	}
+
+	pm.mu.Lock()
+	pm.lastSearchRefs = refs
+	pm.mu.Unlock()
+}
+
+// метод получения первых n вакансий последнего поиска
+func (pm *ParsersManager) lastSearchTop(n int) []models.VacancyRef {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	n = min(n, len(pm.lastSearchRefs))
+	return append([]models.VacancyRef(nil), pm.lastSearchRefs[:n]...)
+}
+
+// функция преобразования деталей вакансии в модель для вывода в консоль
+func detailsToVacancy(result models.SearchVacancyDetailesResult) models.Vacancy {
+	salary := strconv.Itoa(result.Salary.From) // переводим зарплату из int в string
+
+	return models.Vacancy{
+		Company:     result.Employer.Name,
+		Job:         result.Name,
+		Description: result.Description,
+		Salary:      &salary,
+		Area:        result.Area.Name,
+		ID:          result.ID,
+		URL:         result.Url,
+		Skills:      result.Skills,
+	}
+}
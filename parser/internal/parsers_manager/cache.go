@@ -54,11 +54,33 @@ func (pm *ParsersManager) cacheSearchResults(params models.SearchParams, results
 }
 
 // метод для кэширования результатов поиска деталей конкретной вакансии по заднанному ID и парсеру (источнику)
-func (pm *ParsersManager) cacheDetailsResult(vacancyID string, results models.SearchVacancyDetailesResult) {
-	//записываем данные в поисковый кэш №3 (для деталей вакансии)
-	pm.searchCache.AddItemWithTTL(vacancyID, results, pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL)
+func (pm *ParsersManager) cacheDetailsResult(source, vacancyID string, results models.SearchVacancyDetailesResult) {
+	key := detailsCacheKey(source, vacancyID)
 
-	fmt.Printf("✅ Результаты поиска закэшированы в поисковом кэше (ключ: %s)\n", vacancyID)
+	//записываем данные в кэш №3 (для деталей вакансии)
+	pm.vacancyDetails.AddItemWithTTL(key, results, pm.config.Cache.VacancyCacheConfig.VacancyCacheTTL)
+
+	fmt.Printf("✅ Детали вакансии закэшированы в кэше деталей (ключ: %s)\n", key)
+}
+
+// метод получения деталей вакансии из кэша деталей
+func (pm *ParsersManager) tryGetDetailsFromCache(source, vacancyID string) (models.SearchVacancyDetailesResult, bool, error) {
+	cached, found := pm.vacancyDetails.GetItem(detailsCacheKey(source, vacancyID))
+	if !found {
+		return models.SearchVacancyDetailesResult{}, false, nil
+	}
+
+	// необходим type assertion
+	checkedCached, ok := cached.(models.SearchVacancyDetailesResult)
+	if !ok {
+		return models.SearchVacancyDetailesResult{}, false, fmt.Errorf("⚠️  Type assertion для кэшированных данных деталей вакансии -  не удался\n")
+	}
+	return checkedCached, true, nil
+}
+
+// функция формирования ключа кэша деталей: ID вакансий уникальны только в пределах источника
+func detailsCacheKey(source, vacancyID string) string {
+	return fmt.Sprintf("%s_%s", source, vacancyID)
 }
 
 // метод обёртка для генерации поискового хэша
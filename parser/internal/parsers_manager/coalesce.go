@@ -0,0 +1,200 @@
+// объединение одинаковых запросов: пока джоба поиска (или деталей вакансии) с тем же ключом ожидает в очереди или выполняется,
+// повторные вызовы не создают новых джоб, а дожидаются результата уже выполняющейся
+package parsers_manager
+
+import (
+	"context"
+	"fmt"
+	"parser/internal/interfaces"
+	"parser/internal/jobs"
+	"parser/internal/queue"
+	"sync"
+	"time"
+)
+
+// тип объединяемых запросов
+type flightKind string
+
+const (
+	flightSearch  flightKind = "поиск"
+	flightDetails flightKind = "детали"
+	flightWatch   flightKind = "перепроверка" // детали отслеживаемой вакансии в обход кэша (к обычным деталям не присоединяется)
+)
+
+// CoalesceStats - счётчики объединения запросов одного типа
+type CoalesceStats struct {
+	Executed  int64 // вызовов, для которых создана своя джоба
+	Coalesced int64 // вызовов, получивших результат чужой джобы (сэкономленные обращения к источникам)
+}
+
+// общая джоба, результат которой ждут все вызовы с одинаковым ключом
+type flight struct {
+	key      string
+	priority queue.Priority
+	job      interfaces.Job  // nil, пока ведущий вызов ставит джобу в очередь
+	waiters  int             // сколько вызовов ждут результат, под coalescer.mu
+	done     chan struct{}   // закрывается, когда результат готов
+	output   *jobs.JobOutput // результат джобы (читать после done)
+}
+
+// структура объединения запросов
+type coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+	stats   map[flightKind]*CoalesceStats
+}
+
+// конструктор объединения запросов
+func newCoalescer() *coalescer {
+	return &coalescer{
+		flights: make(map[string]*flight),
+		stats:   make(map[flightKind]*CoalesceStats),
+	}
+}
+
+// метод присоединения вызова к общей джобе; true - вызов ведущий и должен сам создать джобу
+// к джобе с более низким приоритетом не присоединяемся: ждать её в очереди дольше, чем свою
+func (c *coalescer) join(kind flightKind, key string, priority queue.Priority) (*flight, bool) {
+	key = string(kind) + ":" + key
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.stats[kind]
+	if !ok {
+		stats = &CoalesceStats{}
+		c.stats[kind] = stats
+	}
+
+	if f, ok := c.flights[key]; ok && f.priority <= priority {
+		f.waiters++
+		stats.Coalesced++
+		return f, false
+	}
+
+	f := &flight{key: key, priority: priority, waiters: 1, done: make(chan struct{})}
+	c.flights[key] = f // более приоритетный вызов вытесняет прежнюю джобу: её ждут только уже присоединившиеся
+	stats.Executed++
+	return f, true
+}
+
+// метод публикации результата общей джобы
+func (c *coalescer) finish(f *flight, output *jobs.JobOutput) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.flights[f.key] == f {
+		delete(c.flights, f.key)
+	}
+	f.output = output
+	close(f.done)
+}
+
+// метод ухода вызова, не дождавшегося результата; возвращает джобу, если её больше никто не ждёт (её нужно отменить)
+func (c *coalescer) leave(f *flight) interfaces.Job {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 || f.job == nil {
+		return nil
+	}
+	// новые вызовы не должны присоединяться к отменяемой джобе
+	if c.flights[f.key] == f {
+		delete(c.flights, f.key)
+	}
+	return f.job
+}
+
+// метод запоминания джобы ведущего вызова
+func (c *coalescer) setJob(f *flight, job interfaces.Job) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f.job = job
+}
+
+// метод получения копии счётчиков
+func (c *coalescer) snapshot() map[flightKind]CoalesceStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[flightKind]CoalesceStats, len(c.stats))
+	for kind, stats := range c.stats {
+		snapshot[kind] = *stats
+	}
+	return snapshot
+}
+
+// метод выполнения джобы с объединением одинаковых вызовов
+// newJob создаёт джобу под переданным контекстом и возвращает её канал результата (вызывается только ведущим вызовом);
+// общая джоба не отменяется вместе с контекстом одного вызывающего (её отменяют, когда результат перестают ждать все),
+// но получает его значения и дедлайн (см. flightContext)
+func (pm *ParsersManager) runCoalesced(ctx context.Context, kind flightKind, key string, priority queue.Priority,
+	newJob func(ctx context.Context) (interfaces.Job, <-chan *jobs.JobOutput), timeout time.Duration) (*jobs.JobOutput, error) {
+
+	f, leader := pm.coalescer.join(kind, key, priority)
+	if leader {
+		flightCtx, cancelFlight := flightContext(ctx)
+		job, resChan := newJob(flightCtx)
+
+		// Пытаемся добавить в очередь с таймаутом
+		if !pm.tryEnqueueJob(ctx, job, 5*time.Second) {
+			cancelFlight()
+			// присоединившиеся за время ожидания очереди получают ту же ошибку
+			pm.coalescer.finish(f, &jobs.JobOutput{Error: fmt.Errorf("❌ Джоба не была добавлена в очередь")})
+			return nil, f.output.Error
+		}
+		pm.coalescer.setJob(f, job)
+
+		go func() {
+			defer cancelFlight()
+			pm.awaitFlight(f, job, resChan)
+		}()
+	} else {
+		fmt.Printf("🔗 %s: присоединились к выполняющемуся запросу\n", kind)
+	}
+
+	select {
+	case <-f.done:
+		return f.output, nil
+
+	case <-time.After(timeout):
+		pm.leaveFlight(f)
+		return nil, fmt.Errorf("таймаут выполнения поиска\n")
+
+	case <-ctx.Done():
+		pm.leaveFlight(f)
+		return nil, ctx.Err()
+	}
+}
+
+// функция получения контекста общей джобы из контекста ведущего вызова:
+// значения и дедлайн вызывающего сохраняются, а отмена вызывающего - нет, иначе его уход прервал бы джобу для всех присоединившихся
+func flightContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return context.WithCancel(detached)
+}
+
+// метод ожидания результата общей джобы и раздачи его всем вызовам
+func (pm *ParsersManager) awaitFlight(f *flight, job interfaces.Job, resChan <-chan *jobs.JobOutput) {
+	var output *jobs.JobOutput
+	select {
+	case output = <-resChan:
+	case <-job.Context().Done():
+		// отменённая джоба может так и не дойти до воркера
+		output = &jobs.JobOutput{Error: context.Cause(job.Context())}
+	}
+
+	pm.coalescer.finish(f, output)
+	pm.releaseJob(job)
+}
+
+// метод ухода вызова от общей джобы: последний ушедший отменяет её, чтобы она не расходовала квоту источников
+func (pm *ParsersManager) leaveFlight(f *flight) {
+	if job := pm.coalescer.leave(f); job != nil {
+		pm.releaseJob(job)
+	}
+}
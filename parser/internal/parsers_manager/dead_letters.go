@@ -0,0 +1,143 @@
+package parsers_manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"parser/internal/deadletter"
+	"strconv"
+	"strings"
+)
+
+// ListDeadLetters возвращает фоновые джобы, исчерпавшие повторы, от старых к новым
+func (pm *ParsersManager) ListDeadLetters() []deadletter.Entry {
+	return pm.deadLetters.List()
+}
+
+// GetDeadLetter возвращает джобу из dead-letter с историей попыток (неизвестный ID - deadletter.ErrNotFound)
+func (pm *ParsersManager) GetDeadLetter(id string) (deadletter.Entry, error) {
+	return pm.deadLetters.Get(id)
+}
+
+// RemoveDeadLetter удаляет джобу из dead-letter без повтора (неизвестный ID - deadletter.ErrNotFound)
+func (pm *ParsersManager) RemoveDeadLetter(id string) error {
+	return pm.deadLetters.Remove(id)
+}
+
+// PurgeDeadLetters удаляет все джобы из dead-letter, возвращает их количество
+func (pm *ParsersManager) PurgeDeadLetters() int {
+	return pm.deadLetters.Purge()
+}
+
+// метод меню фоновых джоб, исчерпавших повторы (dead-letter)
+func (pm *ParsersManager) ManageDeadLetters(scanner *bufio.Scanner) error {
+	fmt.Println("\n☠️  Неудавшиеся фоновые джобы (dead-letter)")
+	fmt.Println("1. Список")
+	fmt.Println("2. История попыток джобы")
+	fmt.Println("3. Повторить джобу")
+	fmt.Println("4. Удалить джобу")
+	fmt.Println("5. Очистить всё")
+	fmt.Print("Выберите действие: ")
+
+	if !scanner.Scan() {
+		return fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+		printDeadLetters(pm.ListDeadLetters())
+	case "2":
+		entry, err := pm.readDeadLetter(scanner)
+		if err != nil {
+			return err
+		}
+		printDeadLetter(entry)
+	case "3":
+		entry, err := pm.readDeadLetter(scanner)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("⏳ Повторяем: %s...\n", describeDeadLetter(entry))
+		summary, err := pm.ReplayDeadLetter(context.Background(), entry.ID)
+		if err != nil {
+			return fmt.Errorf("❌ Повтор не удался (попытка добавлена в историю): %v\n", err)
+		}
+		fmt.Printf("✅ %s; джоба удалена из dead-letter\n", summary)
+	case "4":
+		entry, err := pm.readDeadLetter(scanner)
+		if err != nil {
+			return err
+		}
+		if err := pm.RemoveDeadLetter(entry.ID); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Джоба %s удалена\n", entry.ID)
+	case "5":
+		fmt.Printf("✅ Удалено джоб: %d\n", pm.PurgeDeadLetters())
+	default:
+		return fmt.Errorf("❌ Неверный выбор\n")
+	}
+
+	return nil
+}
+
+// метод чтения записи dead-letter по номеру в списке или ID
+func (pm *ParsersManager) readDeadLetter(scanner *bufio.Scanner) (deadletter.Entry, error) {
+	entries := pm.ListDeadLetters()
+	if len(entries) == 0 {
+		return deadletter.Entry{}, fmt.Errorf("❌ Неудавшихся фоновых джоб нет\n")
+	}
+	printDeadLetters(entries)
+
+	input, err := readLine(scanner, "Номер или ID джобы: ")
+	if err != nil {
+		return deadletter.Entry{}, err
+	}
+	if number, err := strconv.Atoi(input); err == nil {
+		if number < 1 || number > len(entries) {
+			return deadletter.Entry{}, fmt.Errorf("❌ Неверный номер: %d\n", number)
+		}
+		return entries[number-1], nil
+	}
+	return pm.GetDeadLetter(input)
+}
+
+// функция вывода списка записей dead-letter
+func printDeadLetters(entries []deadletter.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("Неудавшихся фоновых джоб нет")
+		return
+	}
+
+	for i, entry := range entries {
+		fmt.Printf("   %d. [%s] %s: %s, попыток %d, последняя %s\n", i+1, entry.ID, entry.Origin,
+			describeDeadLetter(entry), len(entry.Attempts), entry.FailedAt.Format("02.01.2006 15:04"))
+		fmt.Printf("      последняя ошибка: %s\n", entry.LastError())
+	}
+}
+
+// функция вывода записи dead-letter с историей попыток
+func printDeadLetter(entry deadletter.Entry) {
+	fmt.Printf("Джоба %s (%s), %s\n", entry.ID, entry.Kind, entry.Origin)
+	switch entry.Kind {
+	case deadletter.KindDetails:
+		fmt.Printf("   вакансия %s:%s\n", entry.Source, entry.VacancyID)
+	default:
+		fmt.Printf("   запрос «%s», источники: %s, вакансий на странице: %d\n", entry.Params.Text, strings.Join(entry.Params.Sources, ", "), entry.Params.PerPage)
+	}
+	for i, attempt := range entry.Attempts {
+		kind := "автоматическая"
+		if attempt.Replay {
+			kind = "ручной повтор"
+		}
+		fmt.Printf("   %d. %s (%s): %s\n", i+1, attempt.At.Format("02.01.2006 15:04:05"), kind, attempt.Error)
+	}
+}
+
+// функция краткого описания джобы dead-letter
+func describeDeadLetter(entry deadletter.Entry) string {
+	if entry.Kind == deadletter.KindDetails {
+		return fmt.Sprintf("детали вакансии %s:%s", entry.Source, entry.VacancyID)
+	}
+	return fmt.Sprintf("поиск «%s» (%s)", entry.Params.Text, strings.Join(entry.Params.Sources, ", "))
+}
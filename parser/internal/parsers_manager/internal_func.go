@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"parser/internal/domain/models"
+	"parser/internal/ranking"
+	"strings"
 )
 
 // Метод для вывода в консоль результатов поиска списка вакансий (с нужными атрибутами)
@@ -30,6 +32,9 @@ func (pm *ParsersManager) printMultiSearchResults(results []models.SearchVacanci
 				break
 			}
 			fmt.Printf("      %d. %s - %s, company:%s, URL:[ %s ], ID:%s\n", i+1, vacancy.Job, *vacancy.Salary, vacancy.Company, vacancy.URL, vacancy.ID)
+			if len(vacancy.Skills) > 0 {
+				fmt.Printf("         🛠  %s\n", strings.Join(vacancy.Skills, ", "))
+			}
 		}
 
 		if len(result.Vacancies) > resultsPerPage {
@@ -40,6 +45,50 @@ func (pm *ParsersManager) printMultiSearchResults(results []models.SearchVacanci
 	fmt.Printf("\n🎯 Всего найдено: %d вакансий\n", totalVacancies)
 }
 
+// метод вывода в консоль общей выдачи: сводка по источникам и ранжированный список вакансий всех источников
+func (pm *ParsersManager) printRankedSearchResults(results []models.SearchVacanciesResult, ranked []models.MergedVacancy, mode ranking.SortMode) {
+	totalVacancies := 0
+
+	for _, result := range results {
+		if result.Error != nil {
+			fmt.Printf("\n📊 %s: ⏱️  %v, ❌ Ошибка: %v\n", result.ParserName, result.Duration, result.Error)
+			continue
+		}
+		fmt.Printf("\n📊 %s: ⏱️  %v, ✅ Найдено: %d вакансий\n", result.ParserName, result.Duration, len(result.Vacancies))
+		totalVacancies += len(result.Vacancies)
+	}
+
+	if pm.config.Manager.Dedup.Enabled {
+		fmt.Printf("\n🔗 Вакансии всех источников, %s (одинаковые из разных источников объединены):\n", mode.Title())
+	} else {
+		fmt.Printf("\n🔗 Вакансии всех источников, %s:\n", mode.Title())
+	}
+	printRankedVacancies(ranked)
+
+	fmt.Printf("\n🎯 Всего найдено: %d публикаций, уникальных вакансий: %d\n", totalVacancies, len(ranked))
+}
+
+// функция вывода в консоль ранжированного списка вакансий со ссылками на все публикации
+func printRankedVacancies(ranked []models.MergedVacancy) {
+	for i, vacancy := range ranked {
+		fmt.Printf("      %d. %s - %s, company:%s, %s\n", i+1, vacancy.Job, *vacancy.Salary, vacancy.Company, vacancy.Area)
+		if vacancy.PublishedAt.IsZero() {
+			fmt.Printf("         оценка: %.0f%%, дата публикации не указана\n", vacancy.Score*100)
+		} else {
+			fmt.Printf("         оценка: %.0f%%, опубликовано: %s\n", vacancy.Score*100, formatDate(vacancy.PublishedAt))
+		}
+		if len(vacancy.Skills) > 0 {
+			fmt.Printf("         🛠  %s\n", strings.Join(vacancy.Skills, ", "))
+		}
+		for _, posting := range vacancy.Postings {
+			fmt.Printf("         %s: URL:[ %s ], ID:%s\n", posting.Source, posting.URL, posting.ID)
+		}
+		if len(vacancy.Postings) > 1 {
+			fmt.Printf("         сходство публикаций: %.0f%%\n", vacancy.Similarity*100)
+		}
+	}
+}
+
 // метод для построения обратного индекса и хранения его в кэше №2 для индексов и ID вакансий
 func (pm *ParsersManager) buildReverseIndex(searchHash string, results []models.SearchVacanciesResult) {
 	for _, parserResult := range results {
@@ -62,16 +111,18 @@ func (pm *ParsersManager) buildReverseIndex(searchHash string, results []models.
 func genHashFromSearchParam(params models.SearchParams) (string, error) {
 	// Учитываем ВСЕ параметры, которые влияют на результат
 	keyData := struct {
-		Text    string `json:"text"`
-		Area    string `json:"area"`
-		PerPage int    `json:"per_page"`
-		Page    int    `json:"page"`
+		Text    string   `json:"text"`
+		Area    string   `json:"area"`
+		PerPage int      `json:"per_page"`
+		Page    int      `json:"page"`
+		Sources []string `json:"sources,omitempty"` // без источников хэш прежний
 		// Добавьте другие поля из SearchParams
 	}{
 		Text:    params.Text,
 		Area:    params.Area,
 		PerPage: params.PerPage,
 		Page:    params.Page,
+		Sources: params.Sources,
 	}
 
 	data, err := json.Marshal(keyData)
@@ -0,0 +1,132 @@
+// отмена джоб: джоба выполняется под контекстом, производным от контекста вызывающего,
+// отмена по ID убирает джобу из очереди или прерывает её запросы к источникам
+package parsers_manager
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"parser/internal/interfaces"
+	"parser/internal/jobs"
+	"parser/internal/pipeline"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrJobNotFound - джоба с таким ID не ожидает в очереди и не выполняется
+var ErrJobNotFound = errors.New("job not found")
+
+// причина отмены джобы, результат которой вызывающий уже не ждёт
+var errCallerGone = fmt.Errorf("%w: вызывающий больше не ждёт результата", jobs.ErrCanceled)
+
+// метод регистрации джобы среди активных (до постановки в очередь, чтобы её можно было отменить по ID)
+func (pm *ParsersManager) registerJob(job interfaces.Job) {
+	pm.jobsMu.Lock()
+	defer pm.jobsMu.Unlock()
+	pm.activeJobs[job.GetID()] = job
+}
+
+// метод освобождения джобы вызывающим: джоба больше не активна, а если она ещё выполняется - её запросы прерываются
+func (pm *ParsersManager) releaseJob(job interfaces.Job) {
+	pm.jobsMu.Lock()
+	delete(pm.activeJobs, job.GetID())
+	pm.jobsMu.Unlock()
+
+	job.Cancel(errCallerGone)
+}
+
+// Cancel отменяет джобу по ID: ожидающая джоба убирается из очереди, у выполняющейся прерываются запросы к источникам
+// вызывающий сразу получает jobs.ErrCanceled
+func (pm *ParsersManager) Cancel(jobID string) error {
+	pm.jobsMu.Lock()
+	job, ok := pm.activeJobs[jobID]
+	delete(pm.activeJobs, jobID)
+	pm.jobsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+	}
+
+	job.Cancel(jobs.ErrCanceled)
+
+	// очередь с приоритетами умеет удалять элементы; из другой очереди джоба уйдёт сама - воркер пропустит отменённую
+	if remover, ok := pm.jobSearchQueue.(interface {
+		Remove(match func(interfaces.Job) bool) int
+	}); ok {
+		if remover.Remove(func(queued interfaces.Job) bool { return queued.GetID() == jobID }) > 0 {
+			fmt.Printf("🛑 джоба %s удалена из очереди\n", jobID)
+		}
+	}
+
+	job.Complete(nil, jobs.ErrCanceled)
+	return nil
+}
+
+// метод отмены джобы по ID из ввода (для меню): выводит активные джобы и отменяет выбранную
+func (pm *ParsersManager) CancelJob(scanner *bufio.Scanner) error {
+	fmt.Println("\n🛑 Отмена джобы")
+	pm.printActiveJobs()
+
+	fmt.Print("Введите ID джобы (Enter - назад): ")
+	if !scanner.Scan() {
+		return fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+	jobID := strings.TrimSpace(scanner.Text())
+	if jobID == "" {
+		return nil
+	}
+
+	if err := pm.Cancel(jobID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Джоба %s отменена\n", jobID)
+	return nil
+}
+
+// метод выполнения через глобальный circuit breaker: отмена джобы и 404 (вакансия удалена) не считаются сбоем источников
+func (pm *ParsersManager) executeWithCB(ctx context.Context, fn func() error) error {
+	var fnErr error
+	err := pm.circuitBreaker.Execute(func() error {
+		fnErr = fn()
+		if jobCanceled(ctx) || pipeline.IsNotFound(fnErr) {
+			return nil
+		}
+		return fnErr
+	})
+	if err != nil {
+		return err
+	}
+	return fnErr
+}
+
+// функция проверки, что контекст отменён вызывающим (а не истёк таймаут запроса к источнику)
+func jobCanceled(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+// функция получения ошибки для учёта в балансировщике и статусах: прерванный вызывающим запрос - не сбой источника
+func sourceError(ctx context.Context, err error) error {
+	if jobCanceled(ctx) {
+		return nil
+	}
+	return err
+}
+
+// метод вывода активных джоб (ожидающих в очереди и выполняющихся) для состояния системы
+func (pm *ParsersManager) printActiveJobs() {
+	pm.jobsMu.Lock()
+	lines := make([]string, 0, len(pm.activeJobs))
+	for id, job := range pm.activeJobs {
+		lines = append(lines, fmt.Sprintf("   %s: %s, приоритет %s, создана %v назад", id,
+			strings.TrimPrefix(fmt.Sprintf("%T", job), "*jobs."), job.GetPriority(), time.Since(job.GetCreatedAt()).Round(time.Millisecond)))
+	}
+	pm.jobsMu.Unlock()
+
+	sort.Strings(lines)
+	fmt.Printf("Активные джобы: %d\n", len(lines))
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
@@ -5,9 +5,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"parser/internal/dedup"
 	"parser/internal/domain/models"
+	"parser/internal/facets"
+	"parser/internal/queue"
+	"parser/internal/ranking"
+	"parser/internal/skills"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Главный метод (точка входа) логики поиска списка вакансий в зарегестрированных и "живых" парсерах
@@ -37,10 +43,31 @@ func (pm *ParsersManager) MultiSearch(scanner *bufio.Scanner) error {
 		params.PerPage = 20
 	}
 
+	// читаем фильтр по стеку (необязательно). Фильтр применяется к результатам, на запрос к источникам не влияет
+	var stack []string
+	fmt.Print("Фильтр по стеку через запятую (например: golang, postgres; Enter - без фильтра): ")
+	if scanner.Scan() {
+		stack = skills.ParseStack(scanner.Text())
+	}
+
+	// читаем вид выдачи: по умолчанию - общий список всех источников с ранжированием, по желанию - списки по источникам
+	perSource := false
+	fmt.Print("Вид выдачи: 1 - общий список, 2 - по источникам (Enter - общий список): ")
+	if scanner.Scan() {
+		perSource = strings.TrimSpace(scanner.Text()) == "2"
+	}
+
+	// для общего списка читаем режим сортировки
+	sortMode := pm.config.Manager.Ranking.DefaultSort
+	if !perSource {
+		sortMode = readSortMode(scanner, sortMode)
+	}
+
 	ctx := context.Background()
 
 	// запускаем комплексный метод поиска
-	results, err := pm.searchVacancies(ctx, params)
+	// поиск из меню - интерактивный, пользователь ждёт ответа: обгоняет фоновые задачи и пачки
+	results, err := pm.searchVacancies(ctx, params, queue.PriorityHigh)
 	if err != nil {
 		return err
 	}
@@ -55,9 +82,62 @@ func (pm *ParsersManager) MultiSearch(scanner *bufio.Scanner) error {
 		// Возможно, стоит возвращать специальную ошибку
 		return fmt.Errorf("поиск не дал результатов")
 	default:
+		// фильтруем результаты по стеку, если он был задан
+		if len(stack) > 0 {
+			fmt.Printf("🛠  Фильтр по стеку: %s\n", strings.Join(stack, ", "))
+			results = skills.FilterResults(results, stack)
+		}
+
 		// вызываем функцию вывода в консоль информации о результатах поиска
-		pm.printMultiSearchResults(results, params.PerPage)
+		if perSource {
+			pm.printMultiSearchResults(results, params.PerPage)
+		} else {
+			merged := pm.mergeResults(results, params.Text, sortMode)
+			pm.printRankedSearchResults(results, merged, sortMode)
+			printFacets(facets.Compute(merged, pm.config.Manager.Facets), nil, len(merged), pm.config.Manager.Facets.MaxValues)
+			fmt.Println("💡 Уточнить выдачу по фасетам без новых запросов к источникам - пункт меню 7")
+		}
+
+		// запоминаем найденные вакансии, чтобы получить их полные описания пачкой (пункт меню 4)
+		pm.rememberLastSearch(results)
+		// запоминаем запрос, чтобы уточнять его выдачу по фасетам (пункт меню 7)
+		pm.rememberLastSearchQuery(params, stack, sortMode)
 	}
 
 	return nil
 }
+
+// метод сведения результатов всех источников в общий ранжированный список
+// одна и та же вакансия из разных источников становится одной записью со ссылками на все публикации (если объединение дублей включено)
+func (pm *ParsersManager) mergeResults(results []models.SearchVacanciesResult, query string, mode ranking.SortMode) []models.MergedVacancy {
+	var merged []models.MergedVacancy
+	if pm.config.Manager.Dedup.Enabled {
+		merged = dedup.Merge(results, pm.config.Manager.Dedup)
+	} else {
+		merged = dedup.Singles(results)
+	}
+	return ranking.Rank(merged, query, mode, pm.config.Manager.Ranking, time.Now())
+}
+
+// функция чтения режима сортировки общего списка (при пустом или неверном вводе - режим по умолчанию)
+func readSortMode(scanner *bufio.Scanner, defaultMode ranking.SortMode) ranking.SortMode {
+	options := make([]string, len(ranking.SortModes))
+	for i, mode := range ranking.SortModes {
+		options[i] = fmt.Sprintf("%d - %s", i+1, mode.Title())
+	}
+	fmt.Printf("Сортировка: %s (Enter - %s): ", strings.Join(options, ", "), defaultMode.Title())
+	if !scanner.Scan() {
+		return defaultMode
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "" {
+		return defaultMode
+	}
+	mode, err := ranking.ParseSortMode(input)
+	if err != nil {
+		fmt.Printf("⚠️  Неизвестный режим сортировки %q, сортируем %s\n", input, defaultMode.Title())
+		return defaultMode
+	}
+	return mode
+}
@@ -0,0 +1,98 @@
+package parsers_manager
+
+import (
+	"fmt"
+	"parser/internal/circuitbreaker"
+	"parser/internal/notify"
+	"parser/internal/savedsearch"
+	"strings"
+)
+
+// субъект событий глобального circuit breaker менеджера
+const managerBreakerSubject = "manager"
+
+// метод подписки уведомлений на события менеджера
+// статусы источников и circuit breaker - необязательные возможности: подписываемся, если реализация их поддерживает
+func (pm *ParsersManager) subscribeNotifications() {
+	if !pm.notifier.Enabled() {
+		return
+	}
+
+	pm.savedSearches.Subscribe(pm.notifySavedSearchResult)
+
+	if health, ok := pm.parsersStatusManager.(interface {
+		SubscribeHealth(func(name string, healthy bool, reason error))
+	}); ok {
+		health.SubscribeHealth(pm.notifyParserHealth)
+	}
+
+	for _, parser := range pm.parsers {
+		if breaker, ok := parser.(interface {
+			SubscribeCircuit(circuitbreaker.StateHandler)
+		}); ok {
+			breaker.SubscribeCircuit(pm.breakerHandler(parser.GetName()))
+		}
+	}
+	if breaker, ok := pm.circuitBreaker.(interface {
+		Subscribe(circuitbreaker.StateHandler)
+	}); ok {
+		breaker.Subscribe(pm.breakerHandler(managerBreakerSubject))
+	}
+}
+
+// метод публикации новых вакансий сохранённого поиска
+func (pm *ParsersManager) notifySavedSearchResult(result savedsearch.Result) {
+	if len(result.New) == 0 {
+		return
+	}
+
+	vacancies := make([]notify.Vacancy, len(result.New))
+	for i, posting := range result.New {
+		vacancies[i] = notify.Vacancy(posting)
+	}
+
+	pm.notifier.Publish(notify.Event{
+		Kind:      notify.KindNewVacancies,
+		Subject:   result.Name,
+		Title:     fmt.Sprintf("Сохранённый поиск «%s»: новых вакансий: %d", result.Name, len(result.New)),
+		Error:     strings.Join(result.Errors, "; "),
+		Vacancies: vacancies,
+		At:        result.At,
+	})
+}
+
+// метод публикации смены здоровья источника
+func (pm *ParsersManager) notifyParserHealth(name string, healthy bool, reason error) {
+	event := notify.Event{
+		Kind:    notify.KindParserRecovered,
+		Subject: name,
+		Title:   fmt.Sprintf("Источник %s снова здоров", name),
+	}
+	if !healthy {
+		event.Kind = notify.KindParserUnhealthy
+		event.Title = fmt.Sprintf("Источник %s стал нездоровым", name)
+		if reason != nil {
+			event.Error = reason.Error()
+		}
+	}
+	pm.notifier.Publish(event)
+}
+
+// метод создания обработчика смены состояния circuit breaker (публикуется только размыкание)
+func (pm *ParsersManager) breakerHandler(subject string) circuitbreaker.StateHandler {
+	return func(from, to circuitbreaker.State) {
+		if to != circuitbreaker.StateOpen {
+			return
+		}
+
+		title := fmt.Sprintf("Circuit breaker источника %s разомкнут (было: %s)", subject, from)
+		if subject == managerBreakerSubject {
+			title = fmt.Sprintf("Глобальный circuit breaker менеджера разомкнут (было: %s)", from)
+		}
+		pm.notifier.Publish(notify.Event{
+			Kind:    notify.KindBreakerOpen,
+			Subject: subject,
+			Title:   title,
+		})
+	}
+}
@@ -37,14 +37,16 @@ func (pm *ParsersManager) concurrentSearchWithTimeout(ctx context.Context, param
 
 			go func() {
 				start := time.Now()
+				done := pm.balancer.Track(p.GetName())
 				vacancies, err := p.SearchVacancies(ctx, params)
+				done(sourceError(ctx, err))
 				duration := time.Since(start)
 
-				// обновляем статус парсера, в зависимости от результата поиска
-				if err != nil {
-					pm.parsersStatusManager.UpdateStatus(p.GetName(), false, err)
-				} else {
+				// обновляем статус парсера, в зависимости от результата поиска (прерванный вызывающим поиск статус не меняет)
+				if err == nil {
 					pm.parsersStatusManager.UpdateStatus(p.GetName(), true, nil)
+				} else if !jobCanceled(ctx) {
+					pm.parsersStatusManager.UpdateStatus(p.GetName(), false, err)
 				}
 
 				resultChan <- models.SearchVacanciesResult{
@@ -7,78 +7,78 @@ import (
 	"parser/internal/domain/models"
 	"parser/internal/interfaces"
 	"parser/internal/jobs"
+	"parser/internal/queue"
 	"parser/pkg"
 	"time"
 )
 
 // NewSearchJob - создает джобу для поиска вакансий
-func (pm *ParsersManager) NewSearchJob(params models.SearchParams) *jobs.SearchJob {
-	return &jobs.SearchJob{
+func (pm *ParsersManager) NewSearchJob(ctx context.Context, params models.SearchParams, priority queue.Priority) *jobs.SearchJob {
+	job := &jobs.SearchJob{
 		BaseJob: jobs.BaseJob{
 			ID:         pkg.QuickUUID(),
 			ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
 			CreatedAt:  time.Now(),
+			Priority:   priority,
 		},
 		Params: params,
 	}
+	job.Bind(ctx) // отмена и дедлайн вызывающего распространяются на джобу
+	return job
 }
 
 // NewFetchVacancyJob - создает джобу для получения деталей вакансии
-func (pm *ParsersManager) NewFetchVacancyJob(source, vacancyID string) *jobs.FetchDetailsJob {
-	return &jobs.FetchDetailsJob{
+func (pm *ParsersManager) NewFetchVacancyJob(ctx context.Context, source, vacancyID string, priority queue.Priority) *jobs.FetchDetailsJob {
+	job := &jobs.FetchDetailsJob{
 		BaseJob: jobs.BaseJob{
 			ID:         pkg.QuickUUID(),
 			ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
 			CreatedAt:  time.Now(),
+			Priority:   priority,
 		},
 		Source:    source,
 		VacancyID: vacancyID,
 	}
+	job.Bind(ctx) // отмена и дедлайн вызывающего распространяются на джобу
+	return job
 }
 
-// метод для добавления джобы в очередь, с возможностью повторных попыток в течение таймаута
-func (pm *ParsersManager) tryEnqueueJob(ctx context.Context, job interfaces.Job, timeout time.Duration) bool {
-
-	start := time.Now()
-
-	for {
-		// Пробуем добавить в очередь
-		if pm.jobSearchQueue.Enqueue(job) {
-			return true
-		}
-
-		// Проверяем таймаут
-		if time.Since(start) > timeout {
-			return false
-		}
-
-		// Проверяем отмену контекста
-		select {
-		case <-ctx.Done():
-			return false
-		default:
-			// Небольшая пауза перед следующей попыткой
-			time.Sleep(50 * time.Millisecond)
-		}
+// NewBatchDetailsJob - создает джобу для получения деталей пачки вакансий
+func (pm *ParsersManager) NewBatchDetailsJob(ctx context.Context, items []models.VacancyRef, priority queue.Priority) *jobs.BatchDetailsJob {
+	job := &jobs.BatchDetailsJob{
+		BaseJob: jobs.BaseJob{
+			ID:         pkg.QuickUUID(),
+			ResultChan: make(chan *jobs.JobOutput, 1), // обязательно - буферизированный канал
+			CreatedAt:  time.Now(),
+			Priority:   priority,
+		},
+		Items: items,
 	}
+	job.Bind(ctx) // отмена и дедлайн вызывающего распространяются на джобу
+	return job
 }
 
-// метод - обёртка, чтобы внутри вызвать функцию-джерерик для нужного типа (тип: список вакансий)
-func (pm *ParsersManager) waitForJobSearchVacansiesResult(ctx context.Context, resChan <-chan *jobs.JobOutput, timeout time.Duration) ([]models.SearchVacanciesResult, error) {
-	// вызываем дженерик с нужным типом
-	result, err := waitJobResult[[]models.SearchVacanciesResult](ctx, resChan, timeout)
+// метод для добавления джобы в очередь, ждёт свободного места в течение таймаута
+// поставленная джоба зарегистрирована как активная (её можно отменить по ID), вызывающий освобождает её через releaseJob
+func (pm *ParsersManager) tryEnqueueJob(ctx context.Context, job interfaces.Job, timeout time.Duration) bool {
+	// при нагрузке на ресурсы часть джоб не принимается сразу, без ожидания места в очереди
+	if err := pm.admitJob(job); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		return false
+	}
 
-	// не проверяем ошибки, так как ошибки и краевые случаи уже обработаны в функции-дженерике
-	return result, err
-}
+	enqueueCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-// метод - обёртка, чтобы внутри вызвать функцию-джерерик для нужного типа (тип: список вакансий)
-func (pm *ParsersManager) waitForJobSearchVacancyDeyailsResult(ctx context.Context, resChan <-chan *jobs.JobOutput, timeout time.Duration) (models.SearchVacancyDetailesResult, error) {
-	// вызываем дженерик с нужным типом
-	result, err := waitJobResult[models.SearchVacancyDetailesResult](ctx, resChan, timeout)
+	// регистрируем до постановки: воркер может взять джобу сразу
+	pm.registerJob(job)
 
-	// не проверяем ошибки, так как ошибки и краевые случаи уже обработаны в функции-дженерике
-	return result, err
+	// очередь будит нас, как только освободится место; ошибка - таймаут, отмена или закрытая очередь
+	if err := pm.jobSearchQueue.Enqueue(enqueueCtx, job); err != nil {
+		pm.releaseJob(job)
+		return false
+	}
+	return true
 }
 
 // функуция на базе дженериков, для получения результатов
@@ -93,18 +93,7 @@ func waitJobResult[T any](ctx context.Context, resChan <-chan *jobs.JobOutput, t
 			return zero, fmt.Errorf("канал результата закрыт\n")
 		}
 
-		// проверяем наличие ошибки
-		if result.Error != nil {
-			return zero, result.Error
-		}
-
-		// проводим type assertion
-		typedResult, ok := result.Data.(T)
-		if !ok {
-			return zero, fmt.Errorf("неверный тип результата\n")
-		}
-
-		return typedResult, nil
+		return jobOutputAs[T](result)
 
 		// проверяем таймаут
 	case <-time.After(timeout):
@@ -115,3 +104,21 @@ func waitJobResult[T any](ctx context.Context, resChan <-chan *jobs.JobOutput, t
 		return zero, ctx.Err()
 	}
 }
+
+// функция на базе дженериков для получения типизированного результата джобы
+func jobOutputAs[T any](result *jobs.JobOutput) (T, error) {
+	var zero T
+
+	// проверяем наличие ошибки
+	if result.Error != nil {
+		return zero, result.Error
+	}
+
+	// проводим type assertion
+	typedResult, ok := result.Data.(T)
+	if !ok {
+		return zero, fmt.Errorf("неверный тип результата\n")
+	}
+
+	return typedResult, nil
+}
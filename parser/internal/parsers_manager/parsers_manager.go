@@ -2,13 +2,22 @@
 package parsers_manager
 
 import (
+	"context"
 	"errors"
 	"math"
 	"parser/configs"
+	"parser/internal/balancer"
 	"parser/internal/circuitbreaker"
+	"parser/internal/deadletter"
+	"parser/internal/domain/models"
 	"parser/internal/inmemory_cache"
 	"parser/internal/interfaces"
+	"parser/internal/notify"
 	"parser/internal/queue"
+	"parser/internal/resources"
+	"parser/internal/retry"
+	"parser/internal/savedsearch"
+	"parser/internal/watchlist"
 	"sync"
 	"time"
 )
@@ -22,13 +31,27 @@ type ParsersManager struct {
 	vacancyDetails       *inmemory_cache.InmemoryShardedCache // кэш для деталей вакансии
 	parsersStatusManager interfaces.ParsersStatusManager      // менеджер сотсояний парверов внутри менеджера
 	circuitBreaker       interfaces.CBInterface               // глобальный circut breaker (используем интерфейс)
+	lastSearchRefs       []models.VacancyRef                  // вакансии последнего поиска (для получения деталей пачкой), под mu
+	lastSearch           *lastSearchQuery                     // запрос последнего мульти-поиска (для уточнения выдачи фасетами), под mu
+	watchlist            *watchlist.Watchlist                 // отслеживаемые вакансии (закрытие, смена зарплаты, правки описания)
+	savedSearches        *savedsearch.Store                   // сохранённые поиски, запускаемые по расписанию
+	notifier             *notify.Notifier                     // уведомления о событиях во внешние каналы
+	jobRetry             *retry.Policy                        // повторы фоновых джоб
+	deadLetters          *deadletter.Store                    // фоновые джобы, исчерпавшие повторы
+	balancer             *balancer.Balancer                   // выбор источников по задержке, ошибкам, нагрузке и состоянию circuit breaker
+	resources            *resources.Monitor                   // контроль памяти, горутин и CPU процесса
 
 	// Поля для управления нагрузкой --------------------------------------------------------------------------
 	semaphore          chan struct{}                                 // Семафор для ограничения одновременных запросов
-	jobSearchQueue     interfaces.FIFOQueueInterface[interfaces.Job] // Очередь заданий (в качестве типа используем интерфейс с дженеником)
+	jobSearchQueue     interfaces.FIFOQueueInterface[interfaces.Job] // Очередь заданий с приоритетами (в качестве типа используем интерфейс с дженеником)
 	workers            int                                           // Количество воркеров
-	stopWorkers        chan struct{}                                 // Сигнал остановки воркеров (когда захотим завершить все воркеры - зкрываем канал)
+	workersCtx         context.Context                               // Контекст воркеров: отмена прерывает ожидание джобы в очереди
+	stopWorkers        context.CancelFunc                            // Остановка воркеров без вычитывания очереди
 	semaSlotGetTimeout time.Duration                                 // таймаут ожидания свободного слота глобального семафора менеджера парсеров
+	semReserve         semaphoreReserve                              // слоты семафора, занятые при нагрузке на ресурсы
+	activeJobs         map[string]interfaces.Job                     // джобы в очереди и в работе по ID (для отмены), под jobsMu
+	coalescer          *coalescer                                    // объединение одинаковых поисков и запросов деталей
+	jobsMu             sync.Mutex                                    // Для реестра активных джоб
 	wg                 sync.WaitGroup                                // Для graceful shutdown
 	mu                 sync.RWMutex                                  // Для потокобезопасности
 	// --------------------------------------------------------------------------------------------------------
@@ -79,6 +102,39 @@ func NewParserManager(config *configs.Config,
 		return nil, errors.New("кэши обязательны")
 	}
 
+	// создаём балансировщик источников
+	sourceBalancer, err := balancer.New(config.Manager.Balancer)
+	if err != nil {
+		return nil, err
+	}
+
+	// проверяем настройки объединения дублей вакансий из разных источников
+	if err := config.Manager.Dedup.Validate(); err != nil {
+		return nil, err
+	}
+
+	// проверяем настройки ранжирования общей выдачи мульти-поиска
+	if err := config.Manager.Ranking.Validate(); err != nil {
+		return nil, err
+	}
+
+	// проверяем настройки фасетов
+	if err := config.Manager.Facets.Validate(); err != nil {
+		return nil, err
+	}
+
+	// загружаем фоновые джобы, исчерпавшие повторы
+	deadLetters, err := deadletter.New(config.Manager.BackgroundJobs.DeadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	// создаём каналы уведомлений (секреты каналов берутся из переменных окружения)
+	notifier, err := notify.New(config.Manager.Notify)
+	if err != nil {
+		return nil, err
+	}
+
 	pm := &ParsersManager{
 		parsers:              parsers,
 		config:               config,
@@ -86,18 +142,60 @@ func NewParserManager(config *configs.Config,
 		vacancyIndex:         vacancyIndex,   // кэш для обратного индекса
 		vacancyDetails:       vacancyDetails, // кэш для деталей отдельной вакансии
 		parsersStatusManager: pStatManager,
+		balancer:             sourceBalancer,
+		notifier:             notifier,
+		jobRetry:             retry.NewPolicy(config.Manager.BackgroundJobs.Retry),
+		deadLetters:          deadLetters,
+		resources:            resources.NewMonitor(config.Manager.Resources, config.Cache.MaxMemoryUsageMB),
 		circuitBreaker:       circuitbreaker.NewCircutBreaker(config.Manager.CircuitBreakerCfg),
 		workers:              pmLoad.numOfWorkers,
 		semaphore:            make(chan struct{}, pmLoad.semaphoreSize),
-		jobSearchQueue:       queue.NewFIFOQueue[interfaces.Job](pmLoad.queueSize), // создаём очередь через конструктор
-		stopWorkers:          make(chan struct{}),
+		jobSearchQueue:       queue.NewPriorityQueue[interfaces.Job](config.Manager.Queue, pmLoad.queueSize), // очередь с классами приоритета, вместимость по умолчанию - на каждый класс
 		semaSlotGetTimeout:   pmLoad.semSlotTimeout,
+		activeJobs:           make(map[string]interfaces.Job),
+		coalescer:            newCoalescer(),
 		// wg и mu автоматически инициализируются нулевыми значениями
 	}
 
+	pm.workersCtx, pm.stopWorkers = context.WithCancel(context.Background())
+
+	// создаём список отслеживаемых вакансий (детали перезапрашиваются у источников в обход кэша)
+	watched, err := watchlist.New(config.Manager.Watchlist, pm.fetchWatchedVacancy)
+	if err != nil {
+		return nil, err
+	}
+	pm.watchlist = watched
+	pm.watchlist.Subscribe(printWatchlistEvent)
+
+	// загружаем сохранённые поиски (запускаются фоновыми джобами с низким приоритетом)
+	saved, err := savedsearch.New(config.Manager.SavedSearches, pm.runSavedSearch)
+	if err != nil {
+		return nil, err
+	}
+	pm.savedSearches = saved
+	pm.savedSearches.Subscribe(printSavedSearchResult)
+
+	// подписываем уведомления на новые вакансии сохранённых поисков, здоровье источников и circuit breaker
+	pm.subscribeNotifications()
+	pm.notifier.Start()
+
 	// Запускаем воркеры для обработки очереди
 	pm.startSearchWorkers()
 
+	// запускаем контроль ресурсов: при нагрузке менеджер ограничивает приём джоб, сужает семафор и чистит кэши
+	pm.resources.Subscribe(pm.onResourceSample)
+	pm.resources.Start()
+
+	// запускаем фоновую перепроверку отслеживаемых вакансий
+	if config.Manager.Watchlist.Enabled {
+		pm.watchlist.Start()
+	}
+
+	// запускаем планировщик сохранённых поисков
+	if config.Manager.SavedSearches.Enabled {
+		pm.savedSearches.Start()
+	}
+
 	return pm, nil
 }
 
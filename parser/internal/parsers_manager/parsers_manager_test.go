@@ -0,0 +1,426 @@
+package parsers_manager
+
+import (
+	"context"
+	"errors"
+	"parser/configs"
+	"parser/internal/cassette"
+	"parser/internal/circuitbreaker"
+	"parser/internal/deadletter"
+	"parser/internal/domain/models"
+	"parser/internal/fakesource"
+	"parser/internal/inmemory_cache"
+	"parser/internal/parser"
+	"parser/internal/parsers_status_manager"
+	"parser/internal/queue"
+	"parser/internal/watchlist"
+	"testing"
+	"time"
+)
+
+// тестовое окружение: менеджер парсеров, который ходит в фейковые HH.ru и SuperJob
+type testEnv struct {
+	pm     *ParsersManager
+	config *configs.Config
+	hh     *fakesource.Server
+	sj     *fakesource.Server
+}
+
+// функция запуска фейковых источников и менеджера парсеров поверх них
+// configure может поправить конфиг менеджера до его создания (nil - конфиг по умолчанию)
+func newTestEnv(t *testing.T, sourceConfig fakesource.Config, configure func(config *configs.Config)) *testEnv {
+	t.Helper()
+
+	hh := fakesource.NewHHServer(sourceConfig)
+	sj := fakesource.NewSJServer(sourceConfig)
+	for _, server := range []*fakesource.Server{hh, sj} {
+		if err := server.Start(); err != nil {
+			t.Fatalf("start %s: %v", server.Name(), err)
+		}
+	}
+
+	config := &configs.Config{
+		API:         configs.APIConfig{ConcSearchTimeout: 10 * time.Second},
+		Cache:       configs.DefaultCacheConfig(),
+		Parsers:     configs.DefaultParsersConfig(),
+		Manager:     configs.DefaultParsersManagerConfig(),
+		HealthChech: configs.DefaultHealthCheckConfig(),
+		Cassette:    cassette.DefaultConfig(),
+	}
+	config.Parsers.HH.BaseURL = hh.BaseURL()
+	config.Parsers.HH.HealthEndPoint = hh.BaseURL() + "?per_page=1"
+	config.Parsers.SuperJob.BaseURL = sj.BaseURL()
+	config.Parsers.SuperJob.HealthEndPoint = sj.BaseURL() + "?count=1"
+	for _, parserConfig := range []*configs.ParserInstanceConfig{config.Parsers.HH, config.Parsers.SuperJob} {
+		parserConfig.RateLimit = 10 * time.Millisecond
+		parserConfig.Cassette = config.Cassette
+	}
+	config.HealthChech.HealthCheckClientConfig.Cassette = config.Cassette
+	if configure != nil {
+		configure(config)
+	}
+
+	hhParser := parser.NewHHParser(config.Parsers.HH)
+	sjParser := parser.NewSJParser(config.Parsers.SuperJob)
+	statusManager := parsers_status_manager.NewParserStatusManager(config, hhParser, sjParser)
+
+	newCache := func() *inmemory_cache.InmemoryShardedCache {
+		return inmemory_cache.NewInmemoryShardedCache(4, time.Minute)
+	}
+	pm, err := NewParserManager(config, 2, newCache(), newCache(), newCache(), statusManager, hhParser, sjParser)
+	if err != nil {
+		t.Fatalf("new parsers manager: %v", err)
+	}
+
+	t.Cleanup(func() {
+		pm.Shutdown()
+		for _, server := range []*fakesource.Server{hh, sj} {
+			_ = server.Close(context.Background())
+		}
+	})
+
+	return &testEnv{pm: pm, config: config, hh: hh, sj: sj}
+}
+
+// функция поиска вакансий через менеджер с проверкой, что ответили оба источника
+func searchBoth(t *testing.T, pm *ParsersManager, text string) map[string]models.SearchVacanciesResult {
+	t.Helper()
+
+	results, err := pm.searchVacancies(context.Background(), models.SearchParams{Text: text, PerPage: 5}, queue.PriorityHigh)
+	if err != nil {
+		t.Fatalf("search %q: %v", text, err)
+	}
+
+	byParser := make(map[string]models.SearchVacanciesResult, len(results))
+	for _, result := range results {
+		if result.Error != nil {
+			t.Fatalf("search %q: %s: %v", text, result.ParserName, result.Error)
+		}
+		byParser[result.ParserName] = result
+	}
+	for _, name := range []string{"HH.ru", "SuperJob.ru"} {
+		if len(byParser[name].Vacancies) == 0 {
+			t.Fatalf("search %q: no vacancies from %s (results: %d)", text, name, len(results))
+		}
+	}
+	return byParser
+}
+
+func TestParsersManagerSearchAgainstFakeSources(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), nil)
+
+	first := searchBoth(t, env.pm, "go")
+	requests := env.hh.Stats().Requests
+
+	// повторный поиск отдаётся из кэша менеджера - в источник не ходим
+	second := searchBoth(t, env.pm, "go")
+	if got := env.hh.Stats().Requests; got != requests {
+		t.Errorf("cached search hit the source: requests %d -> %d", requests, got)
+	}
+	if len(second["HH.ru"].Vacancies) != len(first["HH.ru"].Vacancies) {
+		t.Errorf("cached search returned %d HH vacancies, want %d", len(second["HH.ru"].Vacancies), len(first["HH.ru"].Vacancies))
+	}
+}
+
+func TestParsersManagerDetailsArchivedAndRemoved(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), nil)
+
+	found := searchBoth(t, env.pm, "go")
+
+	tests := []struct {
+		name         string
+		source       string
+		server       *fakesource.Server
+		change       func(server *fakesource.Server, id string)
+		wantArchived bool
+		wantErr      bool
+	}{
+		{name: "active HH", source: "HH.ru", server: env.hh},
+		{name: "archived HH", source: "HH.ru", server: env.hh, change: (*fakesource.Server).Archive, wantArchived: true},
+		{name: "removed HH", source: "HH.ru", server: env.hh, change: (*fakesource.Server).Remove, wantErr: true},
+		{name: "archived SuperJob", source: "SuperJob.ru", server: env.sj, change: (*fakesource.Server).Archive, wantArchived: true},
+		{name: "removed SuperJob", source: "SuperJob.ru", server: env.sj, change: (*fakesource.Server).Remove, wantErr: true},
+	}
+
+	used := make(map[string]int)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// каждой проверке - своя вакансия, чтобы не получить детали из кэша менеджера
+			vacancies := found[tt.source].Vacancies
+			if used[tt.source] >= len(vacancies) {
+				t.Fatalf("not enough %s vacancies", tt.source)
+			}
+			id := vacancies[used[tt.source]].ID
+			used[tt.source]++
+
+			if tt.change != nil {
+				tt.change(tt.server, id)
+			}
+
+			details, err := env.pm.executeSearchVacancyDetailes(context.Background(), id, tt.source, queue.PriorityHigh)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("details %s/%s: want error, got %+v", tt.source, id, details)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("details %s/%s: %v", tt.source, id, err)
+			}
+			if details.Archived != tt.wantArchived {
+				t.Errorf("details %s/%s: archived = %v, want %v", tt.source, id, details.Archived, tt.wantArchived)
+			}
+		})
+	}
+}
+
+func TestFakeSourceArchivedRateHidesVacanciesFromSearch(t *testing.T) {
+	sourceConfig := fakesource.DefaultConfig()
+	sourceConfig.ArchivedRate = 0.5
+	sourceConfig.RemovedRate = 0.5
+	env := newTestEnv(t, sourceConfig, nil)
+
+	results, err := env.pm.searchVacancies(context.Background(), models.SearchParams{Text: "go", PerPage: 5}, queue.PriorityHigh)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	for _, result := range results {
+		if len(result.Vacancies) != 0 {
+			t.Errorf("%s: every vacancy is archived or removed, got %d in search", result.ParserName, len(result.Vacancies))
+		}
+	}
+}
+
+func TestWatchlistRemovedVacancyDoesNotOpenBreaker(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), func(config *configs.Config) {
+		// breaker размыкается уже после двух сбоев подряд - 404 удалённых вакансий не должны его разомкнуть
+		config.Parsers.HH.CircuitBreaker.FailureThreshold = 2
+		config.Manager.Watchlist.Interval = 0 // перепроверяем вручную
+	})
+
+	found := searchBoth(t, env.pm, "go")
+	vacancies := found["HH.ru"].Vacancies
+	if len(vacancies) < 3 {
+		t.Fatalf("need 3 HH vacancies, got %d", len(vacancies))
+	}
+
+	ctx := context.Background()
+	for _, vacancy := range vacancies[:3] {
+		if _, err := env.pm.watchlist.Add(ctx, "HH.ru", vacancy.ID); err != nil {
+			t.Fatalf("watch %s: %v", vacancy.ID, err)
+		}
+		env.hh.Remove(vacancy.ID)
+	}
+
+	events := env.pm.watchlist.CheckAll(ctx)
+	if len(events) != 3 {
+		t.Fatalf("events = %d, want 3 archived: %v", len(events), events)
+	}
+	for _, event := range events {
+		if event.Type != watchlist.EventArchived {
+			t.Errorf("event %s, want %s", event.Type, watchlist.EventArchived)
+		}
+	}
+
+	hh := env.pm.findParserByName("HH.ru")
+	if state := hh.GetCircuitState(); state != circuitbreaker.StateClosed {
+		t.Errorf("HH breaker is %s after 404s of removed vacancies, want closed", state)
+	}
+
+	// закрытые вакансии до archived_interval не перепроверяются
+	requests := env.hh.Stats().Requests
+	if events := env.pm.watchlist.CheckAll(ctx); len(events) != 0 {
+		t.Errorf("second check events = %v, want none", events)
+	}
+	if got := env.hh.Stats().Requests; got != requests {
+		t.Errorf("archived vacancies were polled again: requests %d -> %d", requests, got)
+	}
+}
+
+func TestFlightContextKeepsDeadlineNotCancellation(t *testing.T) {
+	type key struct{}
+	deadline := time.Now().Add(time.Minute)
+	parent, cancel := context.WithDeadline(context.WithValue(context.Background(), key{}, "caller"), deadline)
+
+	ctx, cancelFlight := flightContext(parent)
+	defer cancelFlight()
+
+	if got, ok := ctx.Deadline(); !ok || !got.Equal(deadline) {
+		t.Errorf("deadline = %v (%v), want %v", got, ok, deadline)
+	}
+	if got := ctx.Value(key{}); got != "caller" {
+		t.Errorf("value = %v, want caller", got)
+	}
+
+	// ведущий вызов ушёл - общая джоба продолжает работать для присоединившихся
+	cancel()
+	if err := ctx.Err(); err != nil {
+		t.Errorf("flight context canceled with the caller: %v", err)
+	}
+
+	cancelFlight()
+	if ctx.Err() == nil {
+		t.Errorf("flight context is not canceled by its own cancel")
+	}
+}
+
+func TestWatchlistFailedCheckGoesToDeadLetter(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), func(config *configs.Config) {
+		config.Parsers.HH.Retry.MaxAttempts = 1
+		config.Manager.Watchlist.Interval = 0 // перепроверяем вручную
+		config.Manager.BackgroundJobs.Retry.MaxAttempts = 2
+		config.Manager.BackgroundJobs.Retry.InitialBackoff = time.Millisecond
+		config.Manager.BackgroundJobs.Retry.MaxBackoff = time.Millisecond
+	})
+
+	found := searchBoth(t, env.pm, "go")
+	vacancy := found["HH.ru"].Vacancies[0]
+
+	ctx := context.Background()
+	if _, err := env.pm.watchlist.Add(ctx, "HH.ru", vacancy.ID); err != nil {
+		t.Fatalf("watch %s: %v", vacancy.ID, err)
+	}
+
+	// источник недоступен: перепроверка исчерпывает повторы и попадает в dead-letter
+	if err := env.hh.Close(ctx); err != nil {
+		t.Fatalf("close HH: %v", err)
+	}
+	env.pm.watchlist.CheckAll(ctx)
+
+	entries := env.pm.ListDeadLetters()
+	if len(entries) != 1 {
+		t.Fatalf("dead-letter entries = %d, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Kind != deadletter.KindDetails || entry.Source != "HH.ru" || entry.VacancyID != vacancy.ID || len(entry.Attempts) != 2 {
+		t.Fatalf("entry = %+v, want details of HH.ru:%s with 2 attempts", entry, vacancy.ID)
+	}
+
+	// повторная неудача той же вакансии дописывается в ту же запись
+	env.pm.watchlist.CheckAll(ctx)
+	entries = env.pm.ListDeadLetters()
+	if len(entries) != 1 || len(entries[0].Attempts) != 4 {
+		t.Fatalf("after second check: %d entries, %d attempts; want 1 entry with 4 attempts", len(entries), len(entries[0].Attempts))
+	}
+
+	// ручной повтор при недоступном источнике добавляет попытку в историю
+	if _, err := env.pm.ReplayDeadLetter(ctx, entry.ID); err == nil {
+		t.Fatal("replay succeeded with the source down")
+	}
+	replayed, err := env.pm.GetDeadLetter(entry.ID)
+	if err != nil {
+		t.Fatalf("entry after failed replay: %v", err)
+	}
+	if last := replayed.Attempts[len(replayed.Attempts)-1]; !last.Replay {
+		t.Errorf("last attempt %+v is not marked as replay", last)
+	}
+}
+
+func TestReplayDetailsDeadLetter(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), nil)
+
+	found := searchBoth(t, env.pm, "go")
+	vacancies := found["SuperJob.ru"].Vacancies
+	env.sj.Remove(vacancies[1].ID)
+
+	ctx := context.Background()
+	for _, vacancy := range vacancies[:2] {
+		entry := env.pm.deadLetters.Add(deadletter.Entry{
+			Kind:      deadletter.KindDetails,
+			Origin:    watchlistOrigin,
+			Source:    "SuperJob.ru",
+			VacancyID: vacancy.ID,
+			Attempts:  []deadletter.Attempt{{At: time.Now(), Error: "timeout"}},
+		})
+
+		// и полученные детали, и 404 удалённой вакансии - ответ источника: запись удаляется
+		if _, err := env.pm.ReplayDeadLetter(ctx, entry.ID); err != nil {
+			t.Fatalf("replay %s: %v", vacancy.ID, err)
+		}
+		if _, err := env.pm.GetDeadLetter(entry.ID); !errors.Is(err, deadletter.ErrNotFound) {
+			t.Errorf("entry for %s is still in dead-letter after a successful replay", vacancy.ID)
+		}
+	}
+}
+
+func TestReplaySearchDeadLetter(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), nil)
+	ctx := context.Background()
+
+	add := func(source string) deadletter.Entry {
+		return env.pm.deadLetters.Add(deadletter.Entry{
+			Kind:     deadletter.KindSearch,
+			Origin:   "сохранённый поиск",
+			Params:   models.SearchParams{Text: "go", PerPage: 5, Sources: []string{source}},
+			Attempts: []deadletter.Attempt{{At: time.Now(), Error: "timeout"}},
+		})
+	}
+	down, up := add("HH.ru"), add("SuperJob.ru")
+
+	if _, err := env.pm.ReplayDeadLetter(ctx, "missing"); !errors.Is(err, deadletter.ErrNotFound) {
+		t.Errorf("replay of an unknown ID: err = %v, want deadletter.ErrNotFound", err)
+	}
+
+	// источник недоступен: запись остаётся, ручная попытка дописывается в историю
+	if err := env.hh.Close(ctx); err != nil {
+		t.Fatalf("close HH: %v", err)
+	}
+	if _, err := env.pm.ReplayDeadLetter(ctx, down.ID); err == nil {
+		t.Fatal("replay succeeded with the source down")
+	}
+	failed, err := env.pm.GetDeadLetter(down.ID)
+	if err != nil {
+		t.Fatalf("entry after failed replay: %v", err)
+	}
+	if len(failed.Attempts) != 2 || !failed.Attempts[1].Replay {
+		t.Fatalf("attempts = %+v, want the original one plus a replay", failed.Attempts)
+	}
+
+	// источник доступен: поиск выполняется, запись удаляется
+	summary, err := env.pm.ReplayDeadLetter(ctx, up.ID)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if summary == "" {
+		t.Error("empty replay summary")
+	}
+	if entries := env.pm.ListDeadLetters(); len(entries) != 1 || entries[0].ID != down.ID {
+		t.Errorf("dead-letter entries after replays = %+v, want only %s", entries, down.ID)
+	}
+}
+
+func TestRemoveAndPurgeDeadLetters(t *testing.T) {
+	env := newTestEnv(t, fakesource.DefaultConfig(), nil)
+
+	var ids []string
+	for _, text := range []string{"go", "python", "java"} {
+		entry := env.pm.deadLetters.Add(deadletter.Entry{
+			Kind:     deadletter.KindSearch,
+			Params:   models.SearchParams{Text: text},
+			Attempts: []deadletter.Attempt{{At: time.Now(), Error: "timeout"}},
+		})
+		ids = append(ids, entry.ID)
+	}
+
+	if err := env.pm.RemoveDeadLetter(ids[1]); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := env.pm.RemoveDeadLetter(ids[1]); !errors.Is(err, deadletter.ErrNotFound) {
+		t.Errorf("second remove: err = %v, want deadletter.ErrNotFound", err)
+	}
+
+	entries := env.pm.ListDeadLetters()
+	if len(entries) != 2 || entries[0].ID != ids[0] || entries[1].ID != ids[2] {
+		t.Fatalf("entries = %+v, want %s and %s in order", entries, ids[0], ids[2])
+	}
+
+	if purged := env.pm.PurgeDeadLetters(); purged != 2 {
+		t.Errorf("purged = %d, want 2", purged)
+	}
+	if entries := env.pm.ListDeadLetters(); len(entries) != 0 {
+		t.Errorf("entries after purge = %d, want 0", len(entries))
+	}
+	if purged := env.pm.PurgeDeadLetters(); purged != 0 {
+		t.Errorf("purge of an empty dead-letter = %d, want 0", purged)
+	}
+}
@@ -3,23 +3,40 @@ package parsers_manager
 import (
 	"context"
 	"fmt"
+	"parser/internal/balancer"
 	"parser/internal/domain/models"
+	"parser/internal/queue"
+	"slices"
 )
 
-// метод получения списка парсеров, доступных для поиска, согласно статусам в мэнеджере состояния парсеров
-func (pm *ParsersManager) selectParsersForSearch() []string {
-	// Сначала берем здоровые парсеры
-	healthyParsers := pm.getHealthyParsers()
-	if len(healthyParsers) > 0 {
-		fmt.Printf("нашлись здоровые парсеры: %v\n", healthyParsers)
-		fmt.Printf("Количество здоровых парсеров: %v\n", len(healthyParsers))
-		return healthyParsers
+// метод получения списка парсеров для поиска: балансировщик учитывает статусы в мэнеджере состояния парсеров,
+// состояние circuit breaker, наблюдаемые задержку, долю ошибок и нагрузку источников, а также класс приоритета джобы
+// limited - часть доступных парсеров не выбрана из-за класса приоритета; sources - в каких источниках искать (пусто - во всех)
+func (pm *ParsersManager) selectParsersForSearch(priority queue.Priority, sources []string) (selected []string, limited bool) {
+	healthy := make(map[string]bool)
+	for _, name := range pm.getHealthyParsers() {
+		healthy[name] = true
+	}
+	if len(healthy) == 0 {
+		// Если все парсеры нездоровы, балансировщик возьмёт все
+		fmt.Println("⚠️  Все парсеры в нездоровом состоянии, пробуем перезапуск...")
+	}
+
+	candidates := make([]balancer.Candidate, 0, len(pm.parsers))
+	for _, parser := range pm.parsers {
+		if len(sources) > 0 && !slices.Contains(sources, parser.GetName()) {
+			continue
+		}
+		candidates = append(candidates, balancer.Candidate{
+			Name:    parser.GetName(),
+			Healthy: healthy[parser.GetName()],
+			Breaker: parser.GetCircuitState(),
+		})
 	}
 
-	// Если все парсеры нездоровы, берем все
-	fmt.Println("⚠️  Все парсеры в нездоровом состоянии, пробуем перезапуск...")
-	fmt.Printf("доступные парсеры с неизвестным состоянием: %v\n", pm.getAllParsersNames())
-	return pm.getAllParsersNames()
+	selected, limited = pm.balancer.Select(candidates, priority)
+	fmt.Printf("балансировщик выбрал парсеры (приоритет %s): %v\n", priority, selected)
+	return selected, limited
 }
 
 // метод, который позволит асинхронно провести поиск по заданным параметрам среди списка переданных парсеров, учитывается контектс с таймаутом
@@ -21,24 +21,29 @@ func (pm *ParsersManager) searchWorker(id int) {
 	defer pm.wg.Done()
 
 	for {
-		select {
-		case <-pm.stopWorkers: // канал для остановки всех воркеров
-			// Получен сигнал остановки
-			//fmt.Printf("Worker #%d: received stop signal\n", id)
+		// воркер спит, пока в очереди нет джоб; выходит, когда очередь закрыта и вычитана или воркеры остановлены
+		job, err := pm.jobSearchQueue.Dequeue(pm.workersCtx)
+		if err != nil {
+			//fmt.Printf("Worker #%d: stopped (%v)\n", id, err)
 			return
-		default:
-			job, ok := pm.jobSearchQueue.Dequeue()
-			if ok {
-				fmt.Printf("woker #%d - взял задачу из очереди и начал обработку\n", id)
-
-				// проверяем тип джобы и вызываем соответствующий обработчик
-				switch j := job.(type) {
-				case *jobs.SearchJob:
-					pm.proccessSearchJob(j) // конкурентно ищем вакансии по всем доступным парсерам
-				case *jobs.FetchDetailsJob:
-					pm.proccessDetailsJob(j) // делаем запрос в конкретный сервис по конкретному ID
-				}
-			}
+		}
+
+		// вызывающий уже отказался от результата - не тратим на джобу слот семафора и квоту источников
+		if err := job.Err(); err != nil {
+			job.Complete(nil, err)
+			continue
+		}
+
+		fmt.Printf("woker #%d - взял задачу из очереди (приоритет %s) и начал обработку\n", id, job.GetPriority())
+
+		// проверяем тип джобы и вызываем соответствующий обработчик
+		switch j := job.(type) {
+		case *jobs.SearchJob:
+			pm.proccessSearchJob(j) // конкурентно ищем вакансии по всем доступным парсерам
+		case *jobs.FetchDetailsJob:
+			pm.proccessDetailsJob(j) // делаем запрос в конкретный сервис по конкретному ID
+		case *jobs.BatchDetailsJob:
+			pm.proccessBatchDetailsJob(j) // получаем детали пачки вакансий из разных сервисов
 		}
 	}
 }
@@ -54,15 +59,17 @@ func (pm *ParsersManager) proccessSearchJob(job *jobs.SearchJob) {
 		defer func() {
 			<-pm.semaphore // Освобождаем слот
 		}()
-		// Используем глобальный Circuit Breaker
-		err = pm.circuitBreaker.Execute(func() error {
+		// Используем глобальный Circuit Breaker, запросы к источникам идут под контекстом джобы
+		err = pm.executeWithCB(job.Context(), func() error {
 			var err error
-			results, err = pm.executeSearch(context.Background(), job.Params)
+			results, err = pm.executeSearch(job.Context(), job.Params, job.GetPriority())
 			return err
 		})
 
 		results, err = pm.handleSearchResult(results, err, job.Params)
 
+	case <-job.Context().Done():
+		err = context.Cause(job.Context())
 	case <-time.After(pm.semaSlotGetTimeout):
 		err = fmt.Errorf("❌ Таймаут ожидания свободного слота глобального семафора менеджера парсеров")
 	}
@@ -84,14 +91,16 @@ func (pm *ParsersManager) proccessDetailsJob(job *jobs.FetchDetailsJob) {
 			<-pm.semaphore // Освобождаем слот
 		}()
 
-		// Используем глобальный Circuit Breaker
-		err = pm.circuitBreaker.Execute(func() error {
+		// Используем глобальный Circuit Breaker, запрос к источнику идёт под контекстом джобы
+		err = pm.executeWithCB(job.Context(), func() error {
 			var err error
-			result, err = pm.searchVacancyDetailes(context.Background(), job.VacancyID, job.Source)
+			result, err = pm.searchVacancyDetailes(job.Context(), job.VacancyID, job.Source, job.Fresh)
 			return err
 		})
 
 		//result, err = pm.handleSearchVacancyDetailesResult(result, err)
+	case <-job.Context().Done():
+		err = context.Cause(job.Context())
 	case <-time.After(pm.semaSlotGetTimeout):
 		err = fmt.Errorf("❌ Таймаут ожидания свободного слота глобального семафора менеджера парсеров")
 	}
@@ -100,13 +109,47 @@ func (pm *ParsersManager) proccessDetailsJob(job *jobs.FetchDetailsJob) {
 	job.Complete(result, err)
 }
 
+// метод для обработки работы для воркера, получение детальной информации по пачке вакансий
+// пачка занимает один слот глобального семафора, параллелизм внутри неё ограничен по источникам
+func (pm *ParsersManager) proccessBatchDetailsJob(job *jobs.BatchDetailsJob) {
+
+	var results []models.BatchDetailsItemResult
+	var err error
+
+	select {
+	case pm.semaphore <- struct{}{}:
+		// Получили слот в семафоре менеджера парсеров
+		defer func() {
+			<-pm.semaphore // Освобождаем слот
+		}()
+
+		ctx, cancel := context.WithTimeout(job.Context(), pm.config.Manager.BatchDetails.Timeout)
+		defer cancel()
+
+		results = pm.fetchBatchDetails(ctx, job.Items)
+	case <-job.Context().Done():
+		err = context.Cause(job.Context())
+	case <-time.After(pm.semaSlotGetTimeout):
+		err = fmt.Errorf("❌ Таймаут ожидания свободного слота глобального семафора менеджера парсеров")
+	}
+
+	// Отправляем результат
+	job.Complete(results, err)
+}
+
 // метод для остановки всех воркеров
 func (pm *ParsersManager) Shutdown() {
 	fmt.Println("============================================================================")
 	fmt.Println("Initiating shutdown...")
 
-	// Закрываем канал - все воркеры получат сигнал
-	close(pm.stopWorkers)
+	// останавливаем фоновую перепроверку отслеживаемых вакансий, планировщик сохранённых поисков и контроль ресурсов
+	// до закрытия очереди: джобы прерванного запуска отменяются, а не попадают в dead-letter из-за закрытой очереди
+	pm.watchlist.Stop()
+	pm.savedSearches.Stop()
+	pm.resources.Stop()
+
+	// Закрываем очередь - новые джобы не принимаются, воркеры дорабатывают оставшиеся и завершаются
+	pm.jobSearchQueue.Close()
 
 	// Ожидаем завершения всех воркеров
 	done := make(chan struct{})
@@ -125,5 +168,10 @@ func (pm *ParsersManager) Shutdown() {
 	case <-time.After(10 * time.Second):
 		fmt.Println("Warning: shutdown timeout, some workers may still be running")
 	}
-	// Закрываем очередь ---- нужно доработать
+
+	// останавливаем уведомления последними: события, случившиеся пока дорабатывали воркеры, ещё уходят в каналы
+	pm.notifier.Stop()
+
+	// не ждём остаток очереди: воркеры, не успевшие её вычитать, завершатся после текущей джобы
+	pm.stopWorkers()
 }
@@ -0,0 +1,142 @@
+package parsers_manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"parser/internal/interfaces"
+	"parser/internal/queue"
+	"parser/internal/resources"
+	"sync"
+)
+
+// структура резерва слотов глобального семафора: занятые резервом слоты недоступны воркерам,
+// так при нагрузке на ресурсы сужается количество одновременно выполняемых джоб
+type semaphoreReserve struct {
+	adjustMu sync.Mutex // изменения резерва выполняются по одному
+
+	mu     sync.Mutex
+	held   int           // сколько слотов занято резервом
+	cancel func()        // остановка горутины, добирающей слоты до цели
+	done   chan struct{} // закрывается, когда горутина добора завершилась
+}
+
+// метод реакции менеджера на замер ресурсов: сужение семафора при смене уровня и чистка кэшей под нагрузкой
+func (pm *ParsersManager) onResourceSample(prev resources.Level, status resources.Status) {
+	if status.Level != prev {
+		pm.setSemaphoreReserve(pm.semaphoreReserveFor(status.Level))
+	}
+
+	if status.Level == resources.LevelNormal {
+		return
+	}
+
+	caches := []interface {
+		EvictExpired() int
+		EvictFraction(fraction float64) int
+	}{pm.searchCache, pm.vacancyIndex, pm.vacancyDetails}
+
+	evicted := 0
+	for _, cache := range caches {
+		evicted += cache.EvictExpired()
+		if status.Level == resources.LevelCritical {
+			evicted += cache.EvictFraction(pm.config.Manager.Resources.EvictFraction)
+		}
+	}
+	if evicted > 0 {
+		fmt.Printf("🧹 Нагрузка %s: из кэшей удалено элементов: %d\n", status.Level, evicted)
+	}
+}
+
+// метод расчёта количества слотов глобального семафора, которые нужно занять резервом на данном уровне нагрузки
+// хотя бы один слот всегда остаётся свободным для джоб
+func (pm *ParsersManager) semaphoreReserveFor(level resources.Level) int {
+	var capacity float64
+	switch level {
+	case resources.LevelElevated:
+		capacity = pm.config.Manager.Resources.ElevatedCapacity
+	case resources.LevelCritical:
+		capacity = pm.config.Manager.Resources.CriticalCapacity
+	default:
+		return 0
+	}
+	// доля вне (0, 1) означает, что семафор на этом уровне не сужается
+	if capacity <= 0 || capacity >= 1 {
+		return 0
+	}
+
+	size := cap(pm.semaphore)
+	available := max(int(math.Ceil(capacity*float64(size))), 1)
+	return max(size-available, 0)
+}
+
+// метод изменения резерва слотов глобального семафора
+// освобождение происходит сразу, а занятие - по мере того, как воркеры возвращают слоты
+func (pm *ParsersManager) setSemaphoreReserve(target int) {
+	reserve := &pm.semReserve
+	reserve.adjustMu.Lock()
+	defer reserve.adjustMu.Unlock()
+
+	// останавливаем предыдущий добор и дожидаемся его: он мог успеть занять слот
+	reserve.mu.Lock()
+	cancel, done := reserve.cancel, reserve.done
+	reserve.cancel, reserve.done = nil, nil
+	reserve.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	reserve.mu.Lock()
+	defer reserve.mu.Unlock()
+
+	for reserve.held > target {
+		<-pm.semaphore
+		reserve.held--
+	}
+
+	if reserve.held == target {
+		fmt.Printf("🧮 Глобальный семафор: доступно слотов %d из %d\n", cap(pm.semaphore)-target, cap(pm.semaphore))
+		return
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	doneCh := make(chan struct{})
+	reserve.cancel, reserve.done = cancelFn, doneCh
+
+	go func(need int) {
+		defer close(doneCh)
+		for i := 0; i < need; i++ {
+			select {
+			case pm.semaphore <- struct{}{}:
+				reserve.mu.Lock()
+				reserve.held++
+				reserve.mu.Unlock()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}(target - reserve.held)
+
+	fmt.Printf("🧮 Глобальный семафор сужается: будет доступно слотов %d из %d\n", cap(pm.semaphore)-target, cap(pm.semaphore))
+}
+
+// метод получения количества слотов глобального семафора, занятых резервом
+func (pm *ParsersManager) semaphoreReserved() int {
+	pm.semReserve.mu.Lock()
+	defer pm.semReserve.mu.Unlock()
+	return pm.semReserve.held
+}
+
+// метод контроля допуска джобы в очередь с учётом нагрузки на ресурсы
+// при повышенной нагрузке не принимаются фоновые джобы, при критической - всё, кроме интерактивных
+func (pm *ParsersManager) admitJob(job interfaces.Job) error {
+	level := pm.resources.Level()
+
+	switch {
+	case level == resources.LevelCritical && job.GetPriority() != queue.PriorityHigh,
+		level == resources.LevelElevated && job.GetPriority() == queue.PriorityLow:
+		return fmt.Errorf("нагрузка на ресурсы %s: джобы с приоритетом %s временно не принимаются", level, job.GetPriority())
+	}
+	return nil
+}
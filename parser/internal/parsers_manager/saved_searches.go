@@ -0,0 +1,228 @@
+package parsers_manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/internal/savedsearch"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// метод меню сохранённых поисков
+func (pm *ParsersManager) ManageSavedSearches(scanner *bufio.Scanner) error {
+	fmt.Println("\n⭐ Сохранённые поиски")
+	fmt.Println("1. Сохранить поиск")
+	fmt.Println("2. Удалить поиск")
+	fmt.Println("3. Список поисков")
+	fmt.Println("4. Запустить сейчас")
+	fmt.Println("5. Новые вакансии")
+	fmt.Print("Выберите действие: ")
+
+	if !scanner.Scan() {
+		return fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+
+	ctx := context.Background()
+
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+		search, err := pm.readSavedSearch(scanner)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Поиск «%s» сохранён (%s), первый запуск запомнит текущие вакансии\n", search.Name, search.Schedule)
+	case "2":
+		name, err := readLine(scanner, "Имя поиска: ")
+		if err != nil {
+			return err
+		}
+		if err := pm.savedSearches.Remove(name); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Поиск «%s» удалён\n", name)
+	case "3":
+		printSavedSearches(pm.savedSearches.List())
+	case "4":
+		name, err := readLine(scanner, "Имя поиска: ")
+		if err != nil {
+			return err
+		}
+		fmt.Println("⏳ Выполняем поиск...")
+		// результат выводит подписчик (printSavedSearchResult)
+		if _, err := pm.savedSearches.Run(ctx, name); err != nil {
+			return err
+		}
+	case "5":
+		name, err := readLine(scanner, "Имя поиска: ")
+		if err != nil {
+			return err
+		}
+		unread, err := pm.savedSearches.TakeUnread(name)
+		if err != nil {
+			return err
+		}
+		if len(unread) == 0 {
+			fmt.Println("Новых вакансий нет")
+			return nil
+		}
+		fmt.Printf("🆕 Новые вакансии поиска «%s»: %d\n", name, len(unread))
+		printSavedPostings(unread)
+	default:
+		fmt.Println("❌ Неверный выбор")
+	}
+
+	return nil
+}
+
+// метод чтения и сохранения нового поиска
+func (pm *ParsersManager) readSavedSearch(scanner *bufio.Scanner) (savedsearch.Search, error) {
+	name, err := readLine(scanner, "Имя поиска: ")
+	if err != nil {
+		return savedsearch.Search{}, err
+	}
+
+	var params models.SearchParams
+	if params.Text, err = readLine(scanner, "Поисковый запрос: "); err != nil {
+		return savedsearch.Search{}, err
+	}
+
+	params.PerPage = 20
+	countStr, err := readLine(scanner, "Количество вакансий на источник (max 50, Enter - 20): ")
+	if err != nil {
+		return savedsearch.Search{}, err
+	}
+	if countStr != "" {
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count <= 0 || count > 50 {
+			return savedsearch.Search{}, fmt.Errorf("❌ Неверное количество вакансий: %q\n", countStr)
+		}
+		params.PerPage = count
+	}
+
+	scheduleStr, err := readLine(scanner, "Расписание (every 2h / daily 09:00, Enter - daily 09:00): ")
+	if err != nil {
+		return savedsearch.Search{}, err
+	}
+	if scheduleStr == "" {
+		scheduleStr = "daily 09:00"
+	}
+	schedule, err := savedsearch.ParseSchedule(scheduleStr)
+	if err != nil {
+		return savedsearch.Search{}, err
+	}
+
+	sourcesStr, err := readLine(scanner, fmt.Sprintf("Источники через запятую (%s; Enter - все): ", strings.Join(pm.GetParserNames(), ", ")))
+	if err != nil {
+		return savedsearch.Search{}, err
+	}
+	var sources []string
+	for _, source := range strings.Split(sourcesStr, ",") {
+		if source = strings.TrimSpace(source); source == "" {
+			continue
+		}
+		if !slices.Contains(pm.GetParserNames(), source) {
+			return savedsearch.Search{}, fmt.Errorf("неизвестный источник %q (доступны: %s)", source, strings.Join(pm.GetParserNames(), ", "))
+		}
+		sources = append(sources, source)
+	}
+
+	return pm.savedSearches.Add(name, params, schedule, sources)
+}
+
+// метод выполнения сохранённого поиска: в каждый источник отдельная фоновая джоба с низким приоритетом
+// (балансировщик отправляет фоновые джобы в один источник, поэтому источники задаются явно)
+// неудачная джоба источника повторяется, исчерпавшая повторы - попадает в dead-letter
+func (pm *ParsersManager) runSavedSearch(ctx context.Context, name string, params models.SearchParams, sources []string) ([]models.SearchVacanciesResult, error) {
+	if len(sources) == 0 {
+		sources = pm.GetParserNames()
+	}
+
+	results := make([]models.SearchVacanciesResult, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sourceParams := params
+			sourceParams.Sources = []string{source}
+			found, err := pm.runBackgroundSearch(ctx, fmt.Sprintf("сохранённый поиск «%s»", name), sourceParams)
+
+			if err != nil {
+				results[i] = models.SearchVacanciesResult{ParserName: source, Error: err}
+				return
+			}
+			results[i] = models.SearchVacanciesResult{ParserName: source, Error: fmt.Errorf("источник не участвовал в поиске")}
+			for _, result := range found {
+				if result.ParserName == source {
+					results[i] = result
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// функция вывода результата запуска сохранённого поиска в консоль
+func printSavedSearchResult(result savedsearch.Result) {
+	for _, message := range result.Errors {
+		fmt.Printf("⚠️  Сохранённый поиск «%s»: %s\n", result.Name, message)
+	}
+
+	switch {
+	case result.Baseline:
+		fmt.Printf("⭐ Сохранённый поиск «%s»: первый запуск, запомнено вакансий: %d (дальше - только новые)\n", result.Name, result.Found)
+	case len(result.New) == 0:
+		fmt.Printf("⭐ Сохранённый поиск «%s»: новых вакансий нет\n", result.Name)
+	default:
+		fmt.Printf("🔔 Сохранённый поиск «%s»: новых вакансий: %d (меню 8 -> 5)\n", result.Name, len(result.New))
+		printSavedPostings(result.New)
+	}
+}
+
+// функция вывода кратких данных вакансий
+func printSavedPostings(postings []savedsearch.Posting) {
+	for i, posting := range postings {
+		fmt.Printf("      %d. %s - %s, company:%s, %s\n", i+1, posting.Name, posting.Salary, posting.Company, posting.Area)
+		fmt.Printf("         %s: URL:[ %s ], ID:%s\n", posting.Source, posting.URL, posting.ID)
+	}
+}
+
+// функция вывода списка сохранённых поисков
+func printSavedSearches(searches []savedsearch.Search) {
+	if len(searches) == 0 {
+		fmt.Println("Сохранённых поисков нет")
+		return
+	}
+
+	for i, search := range searches {
+		sources := "все источники"
+		if len(search.Sources) > 0 {
+			sources = strings.Join(search.Sources, ", ")
+		}
+		lastRun := "ещё не запускался"
+		if !search.LastRun.IsZero() {
+			lastRun = "запущен " + formatDate(search.LastRun)
+		}
+		fmt.Printf("   %d. «%s»: %q по %d на источник, %s, %s\n", i+1, search.Name, search.Params.Text, search.Params.PerPage, search.Schedule, sources)
+		fmt.Printf("      %s, следующий запуск %s, непросмотренных новых: %d\n", lastRun, formatDate(search.NextRun), len(search.Unread))
+		if search.LastError != "" {
+			fmt.Printf("      ⚠️  последний запуск не удался: %s\n", search.LastError)
+		}
+	}
+}
+
+// функция чтения строки ввода
+func readLine(scanner *bufio.Scanner, prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !scanner.Scan() {
+		return "", fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
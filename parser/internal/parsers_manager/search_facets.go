@@ -0,0 +1,144 @@
+package parsers_manager
+
+import (
+	"bufio"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/internal/facets"
+	"parser/internal/ranking"
+	"parser/internal/skills"
+	"strconv"
+	"strings"
+)
+
+// запрос последнего мульти-поиска: по нему выдача берётся из поискового кэша и уточняется фасетами без новых запросов к источникам
+type lastSearchQuery struct {
+	params   models.SearchParams
+	stack    []string         // фильтр по стеку
+	sortMode ranking.SortMode // сортировка общей выдачи
+}
+
+// значение фасета, которое можно выбрать по номеру
+type facetChoice struct {
+	dimension facets.Dimension
+	value     string
+}
+
+// метод запоминания запроса последнего мульти-поиска
+func (pm *ParsersManager) rememberLastSearchQuery(params models.SearchParams, stack []string, sortMode ranking.SortMode) {
+	pm.mu.Lock()
+	pm.lastSearch = &lastSearchQuery{params: params, stack: stack, sortMode: sortMode}
+	pm.mu.Unlock()
+}
+
+// Главный метод уточнения выдачи последнего мульти-поиска по фасетам
+// выдача берётся из поискового кэша, поэтому выбор значений фасетов не делает запросов к источникам
+func (pm *ParsersManager) RefineLastSearch(scanner *bufio.Scanner) error {
+	fmt.Println("\n🧭 Уточнение результатов последнего поиска")
+
+	pm.mu.RLock()
+	query := pm.lastSearch
+	pm.mu.RUnlock()
+	if query == nil {
+		return fmt.Errorf("поиск ещё не выполнялся (пункт меню 1)")
+	}
+
+	searchHash, err := pm.generateSearchHash(query.params)
+	if err != nil {
+		return err
+	}
+	cached, ok := pm.searchCache.GetItem(searchHash)
+	if !ok {
+		return fmt.Errorf("Данные устарели, сделайте повторный запрос (пункт меню 1)\n")
+	}
+	results, ok := cached.([]models.SearchVacanciesResult)
+	if !ok {
+		return fmt.Errorf("Type assertion after refine search ---> failed!\n")
+	}
+
+	if len(query.stack) > 0 {
+		results = skills.FilterResults(results, query.stack)
+	}
+	merged := pm.mergeResults(results, query.params.Text, query.sortMode)
+	config := pm.config.Manager.Facets
+
+	fmt.Printf("🔎 Запрос: %q, %s\n", query.params.Text, query.sortMode.Title())
+
+	selection := facets.Selection{}
+	for {
+		narrowed := facets.Filter(merged, selection, config)
+		choices := printFacets(facets.Compute(narrowed, config), selection, len(narrowed), config.MaxValues)
+
+		fmt.Print("Номер значения - уточнить (повторно - снять), в - показать вакансии, с - сбросить, Enter - выход: ")
+		if !scanner.Scan() {
+			return nil
+		}
+
+		input := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		switch input {
+		case "":
+			return nil
+		case "в", "v":
+			fmt.Printf("\n🔗 Вакансии, %s:\n", query.sortMode.Title())
+			printRankedVacancies(narrowed)
+			continue
+		case "с", "c":
+			selection = facets.Selection{}
+			continue
+		}
+
+		number, err := strconv.Atoi(input)
+		if err != nil || number < 1 || number > len(choices) {
+			fmt.Printf("❌ Нет значения с номером %q\n", input)
+			continue
+		}
+		choice := choices[number-1]
+		if selection[choice.dimension] == choice.value {
+			delete(selection, choice.dimension)
+		} else {
+			selection[choice.dimension] = choice.value
+		}
+	}
+}
+
+// функция вывода в консоль фасетов выдачи, возвращает значения в порядке их номеров
+// maxValues - сколько самых частых значений выводить по каждому признаку (0 - все), выбранное значение выводится всегда
+func printFacets(computed []facets.Facet, selection facets.Selection, total, maxValues int) []facetChoice {
+	if len(selection) > 0 {
+		selected := make([]string, 0, len(selection))
+		for _, dimension := range facets.Dimensions {
+			if value, ok := selection[dimension]; ok {
+				selected = append(selected, fmt.Sprintf("%s: %s", dimension.Title(), value))
+			}
+		}
+		fmt.Printf("\n🧭 Фасеты (%d вакансий, выбрано: %s):\n", total, strings.Join(selected, "; "))
+	} else {
+		fmt.Printf("\n🧭 Фасеты (%d вакансий):\n", total)
+	}
+
+	var choices []facetChoice
+	for _, facet := range computed {
+		if len(facet.Values) == 0 {
+			continue
+		}
+
+		items := make([]string, 0, len(facet.Values))
+		for i, value := range facet.Values {
+			selected := selection[facet.Dimension] == value.Value
+			if maxValues > 0 && i >= maxValues && !selected {
+				continue
+			}
+			choices = append(choices, facetChoice{dimension: facet.Dimension, value: value.Value})
+			mark := ""
+			if selected {
+				mark = "✓"
+			}
+			items = append(items, fmt.Sprintf("[%d]%s %s (%d)", len(choices), mark, value.Value, value.Count))
+		}
+		if hidden := len(facet.Values) - len(items); hidden > 0 {
+			items = append(items, fmt.Sprintf("... ещё %d", hidden))
+		}
+		fmt.Printf("   %s: %s\n", facet.Dimension.Title(), strings.Join(items, ", "))
+	}
+	return choices
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"parser/internal/domain/models"
 	"parser/internal/interfaces"
-	"strconv"
+	"parser/internal/jobs"
+	"parser/internal/pipeline"
+	"parser/internal/queue"
 	"strings"
 	"time"
 )
@@ -67,6 +69,7 @@ func (pm *ParsersManager) GetVacancyDetails(scanner *bufio.Scanner) error {
 						targetVacancy.Company = vacancyRes.Company
 						targetVacancy.Area = vacancyRes.Area
 						targetVacancy.URL = vacancyRes.URL
+						targetVacancy.Skills = vacancyRes.Skills
 					}
 				}
 			}
@@ -91,92 +94,85 @@ func (pm *ParsersManager) GetFullVacancyDetails(scanner *bufio.Scanner) error {
 
 	ctx := context.Background()
 
-	result, err := pm.executeSearchVacancyDetailes(ctx, vacancyID, source)
+	result, err := pm.executeSearchVacancyDetailes(ctx, vacancyID, source, queue.PriorityHigh)
 	if err != nil {
 		return err
 	}
 
-	// делаем несколько проверок. Проверка на nil результат, проверка на пустой слайс
-
-	// создаём переменную для искомой вакансии
-	var targetVacancy models.Vacancy
-
-	salary := strconv.Itoa(result.Salary.From) // переводим зарплату из int в string
-
-	targetVacancy.Company = result.Employer.Name
-	targetVacancy.Job = result.Name
-	targetVacancy.Description = result.Description
-	targetVacancy.Salary = &salary
-	targetVacancy.Area = result.Area.Name
-	targetVacancy.ID = result.ID
-	targetVacancy.URL = result.Url
-
-	printVacancyDetails(targetVacancy)
+	printVacancyDetails(detailsToVacancy(result))
 	return nil
 }
 
 // метод менджера парсеров, который формирует джобу для поиска деталей по конкретной вакансии, добавляет эту джобу в очередь и получает результат поиска в канал
-// возвращает результат поиска или ошибку
-func (pm *ParsersManager) executeSearchVacancyDetailes(ctx context.Context, vacancyID, source string) (models.SearchVacancyDetailesResult, error) {
-	// создаём новую джобу необходимого типа (в данном случае джоба поиска расширенной инфы по конкретной вакансии)
-	job := pm.NewFetchVacancyJob(source, vacancyID)
-
-	// Пытаемся добавить в очередь с таймаутом и повторными попытками
-	success := pm.tryEnqueueJob(ctx, job, 5*time.Second)
-
-	// проверяем успешность добавления в очередь
-	if !success {
-		return models.SearchVacancyDetailesResult{}, fmt.Errorf("❌ Джоба не была добавлена в очередь")
+// возвращает результат поиска или ошибку, priority - класс приоритета джобы в очереди
+func (pm *ParsersManager) executeSearchVacancyDetailes(ctx context.Context, vacancyID, source string, priority queue.Priority) (models.SearchVacancyDetailesResult, error) {
+	// создаём новую джобу необходимого типа (в данном случае джоба поиска расширенной инфы по конкретной вакансии),
+	// если детали этой вакансии ещё не запрашиваются, и дожидаемся результатов из очереди с учётом таймаута
+	output, err := pm.runCoalesced(ctx, flightDetails, detailsCacheKey(source, vacancyID), priority, func(ctx context.Context) (interfaces.Job, <-chan *jobs.JobOutput) {
+		job := pm.NewFetchVacancyJob(ctx, source, vacancyID, priority)
+		return job, job.ResultChan
+	}, 30*time.Second)
+	if err != nil {
+		return models.SearchVacancyDetailesResult{}, err
 	}
 
-	// дожидаемся результатов из очереди с учётом таймаута
-	result, err := pm.waitForJobSearchVacancyDeyailsResult(ctx, job.ResultChan, 30*time.Second)
-
-	// специально тут не обрабатываем ошибку, они уже обработаны выше
-	return result, err
+	return jobOutputAs[models.SearchVacancyDetailesResult](output)
 }
 
 // Основная логика поиска деталей конкретной вакансии
-func (pm *ParsersManager) searchVacancyDetailes(ctx context.Context, vacancyID, source string) (models.SearchVacancyDetailesResult, error) {
+// fresh - не брать детали из кэша (перепроверка отслеживаемой вакансии), свежий результат всё равно кэшируется
+func (pm *ParsersManager) searchVacancyDetailes(ctx context.Context, vacancyID, source string, fresh bool) (models.SearchVacancyDetailesResult, error) {
 	// Проверяем кэш деталей вакансии
-	// пытаемся найти в кэше данные по заданному хэш ключу
-	cached, found := pm.vacancyDetails.GetItem(vacancyID)
-	if found {
-		// необходим type assertion
-		checkedCached, ok := cached.(models.SearchVacancyDetailesResult)
-		if !ok {
-			return models.SearchVacancyDetailesResult{}, fmt.Errorf("⚠️  Type assertion для кэшированных данных деталей вакансии -  не удался\n")
+	if !fresh {
+		cached, found, err := pm.tryGetDetailsFromCache(source, vacancyID)
+		if err != nil {
+			return models.SearchVacancyDetailesResult{}, err
+		}
+		if found {
+			return cached, nil
 		}
-		return checkedCached, nil
 	}
 
-	// делаем проверку того, что источник(парсер) находтся в "живом состоянии"
-	// согласно менеджеру статусов парсверов
-	_, parserIsHeathy := pm.parsersStatusManager.GetParserStatus(source)
-
 	// если в кэше ничего не было найдно, то выполняем запрос в конкретном парсере
-	var parserForRequest interfaces.Parser
-	//выбираем нужный парсер
-	for _, parser := range pm.parsers {
-		if parser.GetName() == source && parserIsHeathy == true {
-			parserForRequest = parser
-			break
-		}
+	parserForRequest, err := pm.parserByName(source)
+	if err != nil {
+		return models.SearchVacancyDetailesResult{}, err
 	}
 
-	// делаем запрос выбранный сервис
+	// делаем запрос выбранный сервис (балансировщик учитывает задержку и ошибки источника)
+	done := pm.balancer.Track(source)
 	vacancyDetails, err := parserForRequest.SearchVacanciesDetailes(ctx, vacancyID)
+	// 404 - вакансия удалена, источник при этом исправен
+	if pipeline.IsNotFound(err) {
+		done(nil)
+	} else {
+		done(sourceError(ctx, err))
+	}
 
 	if err != nil {
 		return models.SearchVacancyDetailesResult{}, err
 	}
 
 	// кэшируем результат в кэш для результатов поиска деталей вакансии по конкретному ID
-	pm.cacheDetailsResult(vacancyID, vacancyDetails)
+	pm.cacheDetailsResult(source, vacancyID, vacancyDetails)
 
 	return vacancyDetails, nil
 }
 
+// метод выбора парсера по имени источника с проверкой того, что источник отслеживается менеджером статусов парсеров
+func (pm *ParsersManager) parserByName(source string) (interfaces.Parser, error) {
+	for _, parser := range pm.parsers {
+		if parser.GetName() != source {
+			continue
+		}
+		if _, tracked := pm.parsersStatusManager.GetParserStatus(source); !tracked {
+			return nil, fmt.Errorf("источник %s недоступен: нет статуса в менеджере статусов парсеров", source)
+		}
+		return parser, nil
+	}
+	return nil, fmt.Errorf("неизвестный источник %q (доступны: %s)", source, strings.Join(pm.GetParserNames(), ", "))
+}
+
 // метод получения имени источника и ID вакансии из ввода
 func (pm *ParsersManager) getCompositeIDFromInput(scanner *bufio.Scanner) (string, string, error) {
 	fmt.Print("Введите ID вакансии: ")
@@ -227,6 +223,10 @@ func printVacancyDetails(vacancy models.Vacancy) {
 	fmt.Printf("🔗 Ссылка: %s\n", vacancy.URL)
 	fmt.Printf("🆔 ID: %s\n", vacancy.ID)
 
+	if len(vacancy.Skills) > 0 {
+		fmt.Printf("🛠  Навыки: %s\n", strings.Join(vacancy.Skills, ", "))
+	}
+
 	// Обрезаем описание для читаемости
 	if len(vacancy.Description) > 1500 {
 		vacancy.Description = vacancy.Description[:1500] + "..."
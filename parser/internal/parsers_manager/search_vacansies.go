@@ -7,32 +7,38 @@ import (
 	"context"
 	"fmt"
 	"parser/internal/domain/models"
+	"parser/internal/interfaces"
+	"parser/internal/jobs"
+	"parser/internal/queue"
 	"time"
 )
 
 // метод менджера парсеров, который формирует джобу для поиска списка вакансий, добавляет эту джобу в очередь и получает результат поиска в канал
 // возвращает результат поиска или ошибку
-func (pm *ParsersManager) searchVacancies(ctx context.Context, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
-	// создаём новую джобу необходимого типа (в данном случае джоба поиска списка вакансий)
-	job := pm.NewSearchJob(params)
-
-	// Пытаемся добавить в очередь с таймаутом и повторными попытками
-	success := pm.tryEnqueueJob(ctx, job, 5*time.Second)
-
-	// проверяем успешность добавления в очередь
-	if !success {
-		return []models.SearchVacanciesResult{}, fmt.Errorf("❌ Джоба не была добавлена в очередь")
+// priority - класс приоритета джобы в очереди (интерактивный поиск пользователя - queue.PriorityHigh)
+func (pm *ParsersManager) searchVacancies(ctx context.Context, params models.SearchParams, priority queue.Priority) ([]models.SearchVacanciesResult, error) {
+	// одинаковые поиски (тот же хэш параметров) выполняются одной джобой
+	searchHash, err := genHashFromSearchParam(params)
+	if err != nil {
+		return []models.SearchVacanciesResult{}, fmt.Errorf("❌ Ошибка при генерации поискового хэша: %v\n", err)
 	}
 
-	// дожидаемся результатов из очереди с учётом таймаута
-	result, err := pm.waitForJobSearchVacansiesResult(ctx, job.ResultChan, 30*time.Second)
+	// создаём новую джобу необходимого типа (в данном случае джоба поиска списка вакансий), если такой поиск ещё не выполняется,
+	// и дожидаемся результатов из очереди с учётом таймаута
+	output, err := pm.runCoalesced(ctx, flightSearch, searchHash, priority, func(ctx context.Context) (interfaces.Job, <-chan *jobs.JobOutput) {
+		job := pm.NewSearchJob(ctx, params, priority)
+		return job, job.ResultChan
+	}, 30*time.Second)
+	if err != nil {
+		return []models.SearchVacanciesResult{}, err
+	}
 
-	// специально тут не обрабатываем ошибку, они уже обработаны выше
-	return result, err
+	// результат общий для всех объединённых вызовов - его не изменяем
+	return jobOutputAs[[]models.SearchVacanciesResult](output)
 }
 
 // Основная логика поиска списка вакансий по всем доступным парсерам
-func (pm *ParsersManager) executeSearch(ctx context.Context, params models.SearchParams) ([]models.SearchVacanciesResult, error) {
+func (pm *ParsersManager) executeSearch(ctx context.Context, params models.SearchParams, priority queue.Priority) ([]models.SearchVacanciesResult, error) {
 
 	// Проверяем кэш
 	if cachedResults, found := pm.tryGetFromCache(params); found {
@@ -42,7 +48,7 @@ func (pm *ParsersManager) executeSearch(ctx context.Context, params models.Searc
 	}
 
 	// Получаем список парсеров для использования
-	parsersToUse := pm.selectParsersForSearch()
+	parsersToUse, limited := pm.selectParsersForSearch(priority, params.Sources)
 	if len(parsersToUse) == 0 {
 		return nil, fmt.Errorf("❌ Нет доступных парсеров для поиска")
 	}
@@ -58,7 +64,8 @@ func (pm *ParsersManager) executeSearch(ctx context.Context, params models.Searc
 	successfulResults := pm.filterSuccessfulResults(searchResults)
 
 	// Кэшируем только если есть хотя бы один успешный результат
-	if len(successfulResults) > 0 {
+	// результат поиска по части источников (ограничение для класса приоритета) не кэшируем: он выглядел бы как полный
+	if len(successfulResults) > 0 && !limited {
 		pm.cacheSearchResults(params, successfulResults)
 	} else {
 		// Ни один парсер не вернул результатов
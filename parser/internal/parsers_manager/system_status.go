@@ -0,0 +1,131 @@
+package parsers_manager
+
+import (
+	"fmt"
+	"parser/internal/pipeline"
+	"parser/internal/queue"
+	"strings"
+	"time"
+)
+
+// метод вывода в консоль состояния системы: парсеры, балансировщик, очередь и нагрузка на ресурсы
+func (pm *ParsersManager) PrintSystemStatus() {
+	fmt.Println("\n" + strings.Repeat("=", 50))
+	fmt.Println("📊 Состояние системы")
+	fmt.Println(strings.Repeat("=", 50))
+
+	fmt.Println("Парсеры:")
+	for _, name := range pm.getAllParsersNames() {
+		status, ok := pm.parsersStatusManager.GetParserStatus(name)
+		if !ok {
+			fmt.Printf("   %s: нет данных\n", name)
+			continue
+		}
+
+		health := "✅ здоров"
+		if !status.IsHealthy {
+			health = "❌ нездоров"
+		}
+		fmt.Printf("   %s: %s, circuit breaker %s, проверен %s\n", name, health, status.CircuitState, formatStatusTime(status.LastCheck))
+		if status.LastError != nil {
+			fmt.Printf("      последняя ошибка: %v\n", status.LastError)
+		}
+		for _, report := range status.SchemaDrift.Reports {
+			if report.HasDrift() {
+				fmt.Printf("      дрейф схемы (%s): %s\n", report.Schema, report.Summary())
+			}
+		}
+		pm.printHedgeStats(name)
+	}
+
+	fmt.Printf("Балансировщик (%s):\n", pm.config.Manager.Balancer.Strategy)
+	stats := pm.balancer.Stats()
+	for _, name := range pm.getAllParsersNames() {
+		source := stats[name]
+		fmt.Printf("   %s: в работе %d, запросов %d, задержка %v, ошибки %.0f%%\n",
+			name, source.InFlight, source.Requests, source.LatencyEWMA.Round(time.Millisecond), source.ErrorRateEMA*100)
+	}
+
+	if sized, ok := pm.jobSearchQueue.(interface{ SizeByPriority() map[queue.Priority]int }); ok {
+		sizes := sized.SizeByPriority()
+		fmt.Printf("Очередь: high %d, normal %d, low %d\n", sizes[queue.PriorityHigh], sizes[queue.PriorityNormal], sizes[queue.PriorityLow])
+	} else {
+		fmt.Printf("Очередь: %d\n", pm.jobSearchQueue.Size())
+	}
+
+	pm.printActiveJobs()
+
+	coalesced := pm.coalescer.snapshot()
+	fmt.Println("Объединение одинаковых запросов:")
+	for _, kind := range []flightKind{flightSearch, flightDetails} {
+		stats := coalesced[kind]
+		fmt.Printf("   %s: выполнено джоб %d, присоединилось вызовов %d (сэкономлено обращений к источникам)\n", kind, stats.Executed, stats.Coalesced)
+	}
+
+	resourceStatus := pm.resources.Status()
+	if !resourceStatus.Enabled {
+		fmt.Println("Ресурсы: контроль выключен")
+	} else {
+		sample := resourceStatus.Sample
+		cpu := "не измеряется"
+		if sample.CPU >= 0 {
+			cpu = fmt.Sprintf("%.0f%%", sample.CPU*100)
+		}
+		fmt.Printf("Ресурсы: нагрузка %s с %s, память %dMB, горутин %d, CPU %s\n",
+			resourceStatus.Level, formatStatusTime(resourceStatus.Since), sample.HeapBytes>>20, sample.Goroutines, cpu)
+		if len(resourceStatus.Reasons) > 0 {
+			fmt.Printf("   превышены пороги: %s\n", strings.Join(resourceStatus.Reasons, "; "))
+		}
+	}
+	fmt.Printf("Глобальный семафор: доступно слотов %d из %d, занято джобами %d\n",
+		cap(pm.semaphore)-pm.semaphoreReserved(), cap(pm.semaphore), len(pm.semaphore)-pm.semaphoreReserved())
+
+	fmt.Printf("Фоновые джобы: до %d попыток, в dead-letter %d (меню 9)\n", pm.jobRetry.MaxAttempts(), pm.deadLetters.Len())
+	pm.printNotifyStats()
+
+	fmt.Println(strings.Repeat("=", 50))
+}
+
+// метод вывода счётчиков дублирующих запросов источника (если парсер их ведёт и они были)
+func (pm *ParsersManager) printHedgeStats(name string) {
+	parser, err := pm.parserByName(name)
+	if err != nil {
+		return
+	}
+	hedging, ok := parser.(interface{ GetHedgeStats() pipeline.HedgeSnapshot })
+	if !ok {
+		return
+	}
+
+	stats := hedging.GetHedgeStats()
+	if stats.Hedged == 0 {
+		return
+	}
+	fmt.Printf("      дублирующие запросы: %d из %d, ответили первыми: %d, пауза: поиск %v, детали %v\n", stats.Hedged, stats.Requests, stats.Wins,
+		stats.Delay[pipeline.KindSearch].Round(time.Millisecond), stats.Delay[pipeline.KindDetails].Round(time.Millisecond))
+}
+
+// метод вывода счётчиков каналов уведомлений
+func (pm *ParsersManager) printNotifyStats() {
+	if !pm.notifier.Enabled() {
+		fmt.Println("Уведомления: выключены")
+		return
+	}
+
+	fmt.Println("Уведомления:")
+	for _, sink := range pm.notifier.Stats() {
+		fmt.Printf("   %s (%s): доставлено %d, не доставлено %d, повторов %d, отброшено %d, последняя доставка %s\n",
+			sink.Name, sink.Type, sink.Sent, sink.Failed, sink.Retries, sink.Dropped, formatStatusTime(sink.LastSent))
+		if sink.LastError != "" {
+			fmt.Printf("      последняя ошибка: %s\n", sink.LastError)
+		}
+	}
+}
+
+// функция форматирования времени для вывода состояния
+func formatStatusTime(t time.Time) string {
+	if t.IsZero() {
+		return "никогда"
+	}
+	return t.Format("15:04:05")
+}
@@ -0,0 +1,169 @@
+package parsers_manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/internal/interfaces"
+	"parser/internal/jobs"
+	"parser/internal/queue"
+	"parser/internal/watchlist"
+	"strings"
+	"time"
+)
+
+// метод меню отслеживания вакансий
+func (pm *ParsersManager) ManageWatchlist(scanner *bufio.Scanner) error {
+	fmt.Println("\n👀 Отслеживание вакансий")
+	fmt.Println("1. Добавить вакансии")
+	fmt.Println("2. Убрать вакансию")
+	fmt.Println("3. Список отслеживаемых")
+	fmt.Println("4. История изменений вакансии")
+	fmt.Println("5. Проверить сейчас")
+	fmt.Print("Выберите действие: ")
+
+	if !scanner.Scan() {
+		return fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+
+	ctx := context.Background()
+
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+		refs, err := readVacancyRefs(scanner, "Введите вакансии в формате источник:ID через пробел или запятую: ")
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			item, err := pm.watchlist.Add(ctx, ref.Source, ref.VacancyID)
+			if err != nil {
+				fmt.Printf("❌ %s:%s - %v\n", ref.Source, ref.VacancyID, err)
+				continue
+			}
+			fmt.Printf("✅ %s:%s «%s» добавлена в отслеживание\n", item.Source, item.VacancyID, item.Snapshot.Name)
+		}
+	case "2":
+		ref, err := readVacancyRef(scanner)
+		if err != nil {
+			return err
+		}
+		if err := pm.watchlist.Remove(ref.Source, ref.VacancyID); err != nil {
+			return err
+		}
+		fmt.Printf("✅ %s:%s больше не отслеживается\n", ref.Source, ref.VacancyID)
+	case "3":
+		printWatchlist(pm.watchlist.List())
+	case "4":
+		ref, err := readVacancyRef(scanner)
+		if err != nil {
+			return err
+		}
+		history, err := pm.watchlist.History(ref.Source, ref.VacancyID)
+		if err != nil {
+			return err
+		}
+		if len(history) == 0 {
+			fmt.Println("Изменений пока не было")
+		}
+		for _, event := range history {
+			fmt.Printf("   %s  %s\n", event.At.Format("02.01.2006 15:04"), event)
+		}
+	case "5":
+		fmt.Println("⏳ Проверяем отслеживаемые вакансии...")
+		events := pm.watchlist.CheckAll(ctx)
+		fmt.Printf("🎯 Изменений: %d\n", len(events))
+	default:
+		return fmt.Errorf("❌ Неверный выбор\n")
+	}
+
+	return nil
+}
+
+// источник фоновых перепроверок в записях dead-letter
+const watchlistOrigin = "отслеживание вакансий"
+
+// метод получения актуальных деталей отслеживаемой вакансии у источника джобой через очередь менеджера
+// (приоритет, контроль допуска, глобальный семафор и circuit breaker - как у остальных запросов);
+// фоновая перепроверка идёт с низким приоритетом и повторами фоновых джоб (исчерпавшая повторы попадает в dead-letter),
+// добавление вакансии пользователем - с обычным приоритетом, одной попыткой
+func (pm *ParsersManager) fetchWatchedVacancy(ctx context.Context, source, vacancyID string, background bool) (models.SearchVacancyDetailesResult, error) {
+	if _, err := pm.parserByName(source); err != nil {
+		return models.SearchVacancyDetailesResult{}, err
+	}
+
+	if background {
+		return pm.runBackgroundDetails(ctx, watchlistOrigin, source, vacancyID)
+	}
+	return pm.attemptWatchedVacancy(ctx, source, vacancyID, queue.PriorityNormal)
+}
+
+// метод одной попытки получения деталей отслеживаемой вакансии
+// кэш деталей не используется (иначе изменения были бы видны только после истечения TTL), но обновляется свежими данными
+func (pm *ParsersManager) attemptWatchedVacancy(ctx context.Context, source, vacancyID string, priority queue.Priority) (models.SearchVacancyDetailesResult, error) {
+	output, err := pm.runCoalesced(ctx, flightWatch, detailsCacheKey(source, vacancyID), priority, func(ctx context.Context) (interfaces.Job, <-chan *jobs.JobOutput) {
+		job := pm.NewFetchVacancyJob(ctx, source, vacancyID, priority)
+		job.Fresh = true
+		return job, job.ResultChan
+	}, 30*time.Second)
+	if err != nil {
+		return models.SearchVacancyDetailesResult{}, err
+	}
+
+	return jobOutputAs[models.SearchVacancyDetailesResult](output)
+}
+
+// функция вывода события отслеживаемой вакансии в консоль
+func printWatchlistEvent(event watchlist.Event) {
+	fmt.Printf("🔔 %s\n", event)
+}
+
+// функция вывода списка отслеживаемых вакансий
+func printWatchlist(items []watchlist.Item) {
+	if len(items) == 0 {
+		fmt.Println("Список отслеживания пуст")
+		return
+	}
+
+	for i, item := range items {
+		status := "открыта"
+		name := ""
+		if item.Snapshot != nil {
+			name = item.Snapshot.Name
+			if item.Snapshot.Archived {
+				status = "закрыта: " + item.Snapshot.ArchivedReason
+			}
+		}
+		fmt.Printf("   %d. %s:%s «%s» - %s, проверена %s, изменений: %d\n",
+			i+1, item.Source, item.VacancyID, name, status, item.LastCheck.Format("02.01.2006 15:04"), len(item.History))
+		if item.LastError != "" {
+			fmt.Printf("      ⚠️  последняя проверка не удалась: %s\n", item.LastError)
+		}
+	}
+}
+
+// функция чтения списка пар источник:ID
+func readVacancyRefs(scanner *bufio.Scanner, prompt string) ([]models.VacancyRef, error) {
+	fmt.Print(prompt)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("❌ Проблема со сканированием ввода\n")
+	}
+
+	refs, err := parseVacancyRefs(scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("❌ Список вакансий пуст\n")
+	}
+	return refs, nil
+}
+
+// функция чтения одной пары источник:ID
+func readVacancyRef(scanner *bufio.Scanner) (models.VacancyRef, error) {
+	refs, err := readVacancyRefs(scanner, "Введите вакансию в формате источник:ID: ")
+	if err != nil {
+		return models.VacancyRef{}, err
+	}
+	return refs[0], nil
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"parser/configs"
+	"parser/internal/cassette"
+	"parser/internal/proxy"
 	"time"
 )
 
@@ -20,24 +22,51 @@ func NewHttpHealthCheckClient(conf *configs.HealthCheckConfig) *HttpHealthCheckC
 	if conf == nil {
 		conf = configs.DefaultHealthCheckConfig()
 	}
+	return newHttpHealthCheckClient(conf, "health_check", conf.HealthCheckClientConfig.Proxy)
+}
+
+// NewSourceHealthCheckClient создаёт клиент health check источника, который ходит через прокси источника
+// (если у источника свой egress, проверка напрямую или через общий прокси не отражает его доступность)
+func NewSourceHealthCheckClient(conf *configs.HealthCheckConfig, source string, proxyConfig proxy.Config) *HttpHealthCheckClient {
+	if conf == nil {
+		conf = configs.DefaultHealthCheckConfig()
+	}
+	return newHttpHealthCheckClient(conf, "health_check_"+source, proxyConfig)
+}
+
+// функция, которая создаёт клиент health check с заданным прокси и именем кассеты
+func newHttpHealthCheckClient(conf *configs.HealthCheckConfig, name string, proxyConfig proxy.Config) *HttpHealthCheckClient {
+	transport := &http.Transport{
+		MaxConnsPerHost:       conf.HealthCheckClientConfig.MaxConnPerHost,
+		MaxIdleConnsPerHost:   conf.HealthCheckClientConfig.MaxIdleConns,
+		IdleConnTimeout:       conf.HealthCheckClientConfig.IdleConnTimeout,
+		TLSHandshakeTimeout:   conf.HealthCheckClientConfig.TLSHandshakeTimeout,
+		ExpectContinueTimeout: conf.HealthCheckClientConfig.ExpectContinueTimeout,
+	}
+
+	// настраиваем прокси для health check запросов (если задан в конфиге)
+	roundTripper, _, err := proxy.Configure(transport, proxyConfig)
+	if err != nil {
+		fmt.Printf("⚠️  [%s] некорректный конфиг прокси, запросы пойдут напрямую: %v\n", name, err)
+		roundTripper = transport
+	}
+
+	// health check пишет и воспроизводит свою кассету, чтобы статусы парсеров были детерминированы и офлайн
+	// тело ответа health check не разбирается, поэтому его размер не ограничиваем
+	roundTripper = cassette.Wrap(roundTripper, name, conf.HealthCheckClientConfig.Cassette, 0)
 
 	return &HttpHealthCheckClient{
 		client: &http.Client{
-			Timeout: conf.HealthCheckClientConfig.TimeOut, // общий таймаут клиента
-			Transport: &http.Transport{
-				MaxConnsPerHost:       conf.HealthCheckClientConfig.MaxConnPerHost,
-				MaxIdleConnsPerHost:   conf.HealthCheckClientConfig.MaxIdleConns,
-				IdleConnTimeout:       conf.HealthCheckClientConfig.IdleConnTimeout,
-				TLSHandshakeTimeout:   conf.HealthCheckClientConfig.TLSHandshakeTimeout,
-				ExpectContinueTimeout: conf.HealthCheckClientConfig.ExpectContinueTimeout,
-			},
+			Timeout:   conf.HealthCheckClientConfig.TimeOut, // общий таймаут клиента
+			Transport: roundTripper,
 		},
 		timeout: conf.RequestTimeOut, // таймаут на запрос
 	}
 }
 
 // метод для выполнения тестовго запроса для проверки healthCheck
-func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string) (time.Duration, bool, error) {
+// headers - заголовки источника (User-Agent и т.п.), чтобы проверка не отличалась от рабочих запросов парсера
+func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string, headers http.Header) (time.Duration, bool, error) {
 	// создаём кнтекст с таймаутом для контроля времени запроса
 	reqCtx, cancel := context.WithTimeout(ctx, h.timeout)
 	defer cancel()
@@ -50,8 +79,15 @@ func (h *HttpHealthCheckClient) CheckHealth(ctx context.Context, endpoint string
 		return 0, false, fmt.Errorf("Failed to create request with context for health check: %v", err)
 	}
 
-	// устанавливаем заголовок
-	req.Header.Set("User-Agent", "ParserHealthCheck/1.0")
+	// устанавливаем заголовки источника, а если User-Agent не задан - свой
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", "ParserHealthCheck/1.0")
+	}
 
 	// делаем запрос с помощью клиента
 	resp, err := h.client.Do(req)
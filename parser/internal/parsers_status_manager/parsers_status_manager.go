@@ -5,8 +5,10 @@ package parsers_status_manager
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"parser/configs"
 	"parser/internal/interfaces"
+	"parser/internal/proxy"
 	"sync"
 	"time"
 )
@@ -15,20 +17,38 @@ import (
 // ключ - это имя экземпляра парсера
 type ParserStatusManager struct {
 	parsersStats map[string]*interfaces.ParserStatus // мапа статусов парсеров
+	probeHeaders map[string]http.Header              // заголовки для health check запросов каждого источника
+	parsers      map[string]interfaces.Parser        // парсеры (источник отчётов о дрейфе схемы ответов)
 	config       *configs.Config                     // конфиг
-	client       interfaces.HealthClient             // клиент для проверки
+	client       interfaces.HealthClient             // клиент для проверки (общий прокси health check)
+	clients      map[string]interfaces.HealthClient  // клиенты источников со своим прокси (ходят через прокси источника)
 	initComplete chan struct{}                       // Сигнал завершения инициализации
+	handlers     []HealthHandler                     // подписчики на смену здоровья парсеров, под mu
 	stopChan     chan struct{}
 	mu           sync.RWMutex
 	wg           sync.WaitGroup
 }
 
+// HealthHandler - обработчик смены здоровья парсера
+// healthy=false - парсер стал нездоровым (reason - последняя ошибка), healthy=true - парсер восстановился
+type HealthHandler = func(name string, healthy bool, reason error)
+
+// изменение здоровья парсера, о котором нужно сообщить подписчикам
+type healthChange struct {
+	name    string
+	healthy bool
+	reason  error
+}
+
 // конструктор для нового менеджера статусов парсеров
 func NewParserStatusManager(conf *configs.Config, parsers ...interfaces.Parser) *ParserStatusManager {
 	psm := &ParserStatusManager{
 		parsersStats: make(map[string]*interfaces.ParserStatus),
+		probeHeaders: make(map[string]http.Header),
+		parsers:      make(map[string]interfaces.Parser),
 		config:       conf, // конфиг для коиента health check
 		client:       NewHttpHealthCheckClient(conf.HealthChech),
+		clients:      make(map[string]interfaces.HealthClient),
 		initComplete: make(chan struct{}),
 		stopChan:     make(chan struct{}),
 	}
@@ -43,6 +63,16 @@ func NewParserStatusManager(conf *configs.Config, parsers ...interfaces.Parser)
 			HealthEndpoint: parser.GetHealthEndPoint(),
 			IsHealthy:      false, // Начальное состояние - не здоров
 		}
+		// health check ходит в источник с теми же заголовками, что и парсер
+		psm.probeHeaders[parser.GetName()] = parser.GetRequestHeaders()
+		psm.parsers[parser.GetName()] = parser
+
+		// прокси источника - необязательная возможность парсера: если он задан, проверка идёт через него
+		if proxied, ok := parser.(interface{ GetProxyConfig() proxy.Config }); ok {
+			if proxyConfig := proxied.GetProxyConfig(); proxyConfig.IsEnabled() || proxyConfig.FromEnvironment {
+				psm.clients[parser.GetName()] = NewSourceHealthCheckClient(conf.HealthChech, parser.GetName(), proxyConfig)
+			}
+		}
 	}
 
 	// Запускаем фоновую горутину для опроса
@@ -81,6 +111,14 @@ func (psm *ParserStatusManager) startHealthChecker() {
 	}()
 }
 
+// метод выбора клиента health check источника: свой (через прокси источника) или общий
+func (psm *ParserStatusManager) healthClient(name string) interfaces.HealthClient {
+	if client, ok := psm.clients[name]; ok {
+		return client
+	}
+	return psm.client
+}
+
 // метод, который конкурентно опрашивает сервисы на предмет проверки состояния
 func (psm *ParserStatusManager) performHealthCheck() {
 	// создаём внутреннюю структуру для сбора результатов инициализации
@@ -99,10 +137,10 @@ func (psm *ParserStatusManager) performHealthCheck() {
 	psm.mu.RLock()
 	for name, status := range psm.parsersStats {
 		wg.Add(1)
-		go func(n string, endpoint string) {
+		go func(n string, endpoint string, headers http.Header, client interfaces.HealthClient) {
 			defer wg.Done()
 
-			_, healthy, err := psm.client.CheckHealth(context.Background(), endpoint)
+			_, healthy, err := client.CheckHealth(context.Background(), endpoint, headers)
 
 			// Определяем статус инициализации
 			initDone := true // После первой проверки считаем инициализированным
@@ -115,7 +153,7 @@ func (psm *ParserStatusManager) performHealthCheck() {
 				err:      err,
 			}
 
-		}(name, status.HealthEndpoint)
+		}(name, status.HealthEndpoint, psm.probeHeaders[name], psm.healthClient(name))
 	}
 	psm.mu.RUnlock()
 
@@ -127,14 +165,24 @@ func (psm *ParserStatusManager) performHealthCheck() {
 
 	// Собираем результаты
 	for result := range results {
+		var change *healthChange
+
 		psm.mu.Lock()
 		if parser, exists := psm.parsersStats[result.name]; exists {
+			wasHealthy, wasInitialized := parser.IsHealthy, parser.Initialized
 			parser.IsHealthy = result.healthy
 			parser.LastCheck = time.Now()
 			parser.Initialized = result.initDone
+			if !result.healthy && result.err != nil {
+				parser.LastError = result.err
+			}
+			psm.applySchemaDrift(parser)
+			psm.applyCircuitState(parser)
+			change = detectHealthChange(parser, wasHealthy, wasInitialized)
 		}
 		psm.mu.Unlock()
 
+		psm.notifyHealthChange(change)
 	}
 	// Все проверки завершены, все статусы обновлены
 
@@ -145,7 +193,6 @@ func (psm *ParserStatusManager) performHealthCheck() {
 func (psm *ParserStatusManager) UpdateStatus(name string, success bool, err error) {
 	// так как мэнеджер статуса парсеров основан на мапе, все панипуляции проводит под мьютексом
 	psm.mu.Lock()
-	defer psm.mu.Unlock()
 
 	status, exists := psm.parsersStats[name] // пытаемся получить статус парсера по ключу
 	// если его нету, то добавляем новый в менеджер статуса парсеров
@@ -158,6 +205,7 @@ func (psm *ParserStatusManager) UpdateStatus(name string, success bool, err erro
 		psm.parsersStats[name] = status
 	}
 
+	wasHealthy, wasInitialized := status.IsHealthy, status.Initialized
 	status.LastCheck = time.Now()
 
 	if success {
@@ -165,12 +213,78 @@ func (psm *ParserStatusManager) UpdateStatus(name string, success bool, err erro
 		status.ErrorCount = 0
 		status.IsHealthy = true
 		status.LastError = nil
+		// успешный запрос не означает корректные данные: если пропали критичные поля - парсер остаётся нездоровым
+		psm.applySchemaDrift(status)
 	} else {
 		status.ErrorCount++
 		status.SuccessCount = 0
 		status.IsHealthy = false
 		status.LastError = err
 	}
+	psm.applyCircuitState(status)
+	change := detectHealthChange(status, wasHealthy, wasInitialized)
+	psm.mu.Unlock()
+
+	// подписчики вызываются без мьютекса: обработчик может сам читать статусы парсеров
+	psm.notifyHealthChange(change)
+}
+
+// SubscribeHealth добавляет обработчик смены здоровья парсеров
+// о первой проверке после старта не сообщается - сообщается только о переходах уже проинициализированного парсера
+func (psm *ParserStatusManager) SubscribeHealth(handler HealthHandler) {
+	psm.mu.Lock()
+	defer psm.mu.Unlock()
+	psm.handlers = append(psm.handlers, handler)
+}
+
+// функция определения смены здоровья парсера (вызывается под мьютексом)
+func detectHealthChange(status *interfaces.ParserStatus, wasHealthy, wasInitialized bool) *healthChange {
+	if !wasInitialized || wasHealthy == status.IsHealthy {
+		return nil
+	}
+	return &healthChange{name: status.Name, healthy: status.IsHealthy, reason: status.LastError}
+}
+
+// метод рассылки смены здоровья парсера подписчикам (вызывается без мьютекса)
+func (psm *ParserStatusManager) notifyHealthChange(change *healthChange) {
+	if change == nil {
+		return
+	}
+
+	psm.mu.RLock()
+	handlers := append([]HealthHandler(nil), psm.handlers...)
+	psm.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(change.name, change.healthy, change.reason)
+	}
+}
+
+// метод обновления в статусе парсера состояния его circuit breaker (вызывается под мьютексом)
+func (psm *ParserStatusManager) applyCircuitState(status *interfaces.ParserStatus) {
+	if parser, ok := psm.parsers[status.Name]; ok {
+		status.CircuitState = parser.GetCircuitState().String()
+	}
+}
+
+// метод обновления в статусе парсера находок детектора дрейфа схемы (вызывается под мьютексом)
+// если пропали критичные поля и конфиг парсера этого требует - парсер помечается нездоровым
+func (psm *ParserStatusManager) applySchemaDrift(status *interfaces.ParserStatus) {
+	parser, ok := psm.parsers[status.Name]
+	if !ok {
+		return
+	}
+
+	status.SchemaDrift = parser.GetSchemaDrift()
+	if status.SchemaDrift.FailsHealth {
+		status.IsHealthy = false
+		for _, report := range status.SchemaDrift.Reports {
+			if report.HasCritical() {
+				status.LastError = fmt.Errorf("response schema drift (%s): %s", report.Schema, report.Summary())
+				break
+			}
+		}
+	}
 }
 
 // GetHealthyParsers возвращает список здоровых парсеров
@@ -0,0 +1,38 @@
+package pipeline
+
+import "time"
+
+// имена встроенных middleware (используются в конфиге парсера, секция middleware)
+const (
+	NameCircuitBreaker = "circuit_breaker"
+	NameSemaphore      = "semaphore"
+	NameRetry          = "retry"
+	NameRateLimiter    = "rate_limiter"
+	NameLogging        = "logging"
+	NameMetrics        = "metrics"
+	NameFaultInjection = "fault_injection"
+	NameHedge          = "hedge"
+)
+
+// DefaultMiddleware возвращает порядок middleware по умолчанию: историческая логика BaseParser плюс сбор метрик
+// rate limiter стоит внутри повторов, поэтому квоту источника расходует каждая попытка;
+// дублирующие запросы - внутри circuit breaker (он видит один общий результат), но снаружи семафора и rate limiter:
+// каждая попытка занимает свой слот и расходует свою квоту
+func DefaultMiddleware() []string {
+	return []string{
+		NameMetrics,
+		NameCircuitBreaker,
+		NameHedge,
+		NameSemaphore,
+		NameRetry,
+		NameRateLimiter,
+	}
+}
+
+// FaultInjectionConfig - конфигурация имитации сбоев (для проверки поведения повторов и circuit breaker)
+type FaultInjectionConfig struct {
+	ErrorRate   float64       `yaml:"error_rate"`   // доля запросов, завершающихся ошибкой
+	ErrorStatus int           `yaml:"error_status"` // HTTP статус имитируемой ошибки (по умолчанию 503)
+	LatencyRate float64       `yaml:"latency_rate"` // доля запросов с дополнительной задержкой
+	Latency     time.Duration `yaml:"latency"`      // величина дополнительной задержки
+}
@@ -0,0 +1,224 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HedgeConfig - конфигурация дублирующих (hedged) запросов к медленному источнику
+type HedgeConfig struct {
+	Enabled    bool          `yaml:"enabled"`     // включены ли дублирующие запросы
+	Delay      time.Duration `yaml:"delay"`       // минимальная пауза перед дублирующим запросом (она же - пока не набралась статистика)
+	Percentile float64       `yaml:"percentile"`  // перцентиль недавних задержек, после которого отправляется дублирующий запрос
+	Window     int           `yaml:"window"`      // сколько последних задержек учитывается
+	MinSamples int           `yaml:"min_samples"` // сколько замеров нужно, чтобы доверять перцентилю
+}
+
+// DefaultHedgeConfig возвращает конфигурацию по умолчанию (дублирующие запросы выключены)
+func DefaultHedgeConfig() HedgeConfig {
+	return HedgeConfig{
+		Enabled:    false,
+		Delay:      300 * time.Millisecond,
+		Percentile: 0.95,
+		Window:     100,
+		MinSamples: 20,
+	}
+}
+
+// HedgeSnapshot - счётчики дублирующих запросов
+type HedgeSnapshot struct {
+	Requests int64                         // запросов, прошедших через middleware
+	Hedged   int64                         // сколько раз отправлен дублирующий запрос
+	Wins     int64                         // сколько раз дублирующий запрос ответил первым
+	Delay    map[RequestKind]time.Duration // текущая пауза перед дублирующим запросом по типам запросов
+}
+
+// HedgeStats - окно недавних задержек источника (по типам запросов) и счётчики дублирующих запросов
+type HedgeStats struct {
+	config HedgeConfig
+
+	mu        sync.Mutex
+	latencies map[RequestKind][]time.Duration // кольцевой буфер задержек успешных попыток
+	next      map[RequestKind]int             // позиция записи в буфере
+	requests  int64
+	hedged    int64
+	wins      int64
+}
+
+// NewHedgeStats создаёт пустую статистику
+func NewHedgeStats(config HedgeConfig) *HedgeStats {
+	if config.Window <= 0 {
+		config.Window = DefaultHedgeConfig().Window
+	}
+	if config.Percentile <= 0 || config.Percentile >= 1 {
+		config.Percentile = DefaultHedgeConfig().Percentile
+	}
+	return &HedgeStats{
+		config:    config,
+		latencies: make(map[RequestKind][]time.Duration),
+		next:      make(map[RequestKind]int),
+	}
+}
+
+// метод учёта задержки успешной попытки
+func (s *HedgeStats) observe(kind RequestKind, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window := s.latencies[kind]
+	if len(window) < s.config.Window {
+		s.latencies[kind] = append(window, latency)
+		return
+	}
+	window[s.next[kind]] = latency
+	s.next[kind] = (s.next[kind] + 1) % s.config.Window
+}
+
+// метод расчёта паузы перед дублирующим запросом: перцентиль недавних задержек, но не меньше Delay
+func (s *HedgeStats) delay(kind RequestKind) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.delayLocked(kind)
+}
+
+// метод расчёта паузы (вызывается под мьютексом)
+func (s *HedgeStats) delayLocked(kind RequestKind) time.Duration {
+	window := s.latencies[kind]
+	if len(window) == 0 || len(window) < s.config.MinSamples {
+		return s.config.Delay
+	}
+
+	sorted := append([]time.Duration(nil), window...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := min(int(s.config.Percentile*float64(len(sorted))), len(sorted)-1)
+	return max(sorted[index], s.config.Delay)
+}
+
+// метод учёта запроса: hedged - отправлялся ли дублирующий, won - ответил ли он первым
+func (s *HedgeStats) count(hedged, won bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if hedged {
+		s.hedged++
+	}
+	if won {
+		s.wins++
+	}
+}
+
+// Snapshot возвращает копию счётчиков
+func (s *HedgeStats) Snapshot() HedgeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delays := make(map[RequestKind]time.Duration, 2)
+	for _, kind := range []RequestKind{KindSearch, KindDetails} {
+		delays[kind] = s.delayLocked(kind)
+	}
+	return HedgeSnapshot{Requests: s.requests, Hedged: s.hedged, Wins: s.wins, Delay: delays}
+}
+
+// результат одной из попыток
+type hedgeAttempt struct {
+	result *Result
+	err    error
+	hedged bool
+}
+
+// Hedge - middleware дублирующих запросов: если ответ не пришёл за перцентиль недавних задержек источника,
+// отправляется второй такой же запрос, берётся ответ, пришедший первым, а другой запрос отменяется
+// обе попытки проходят через всё, что стоит в цепочке после неё (семафор, повторы, rate limiter источника),
+// поэтому дубль занимает свой слот и расходует свою квоту; пауза перед дублем и замер задержки отсчитываются
+// с момента, когда попытка прошла эти ожидания и ушла к источнику (хук отправки цепочки Chain)
+// ошибку первой попытки до отправки второй не дублируем - ошибки обрабатывают повторы;
+// после отправки второй ответ источника с HTTP статусом ошибки тоже считается ответом, а сетевой сбой - нет
+func Hedge(config HedgeConfig, stats *HedgeStats, source string) Middleware {
+	return func(next Handler) Handler {
+		if !config.Enabled {
+			return next
+		}
+
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			attemptCtx, cancel := context.WithCancel(ctx)
+			defer cancel() // отменяем попытку, ответившую второй
+
+			attempts := make(chan hedgeAttempt, 2)
+			launch := func(hedged bool, sent func()) {
+				go func() {
+					var sentAt atomic.Int64 // время последней отправки к источнику (повтор внутри попытки отправляет заново)
+					attemptReq := *req
+					result, err := next(withSentHook(attemptCtx, func() {
+						sentAt.Store(time.Now().UnixNano())
+						sent()
+					}), &attemptReq)
+					if start := sentAt.Load(); err == nil && start != 0 {
+						stats.observe(req.Kind, time.Since(time.Unix(0, start)))
+					}
+					attempts <- hedgeAttempt{result: result, err: err, hedged: hedged}
+				}()
+			}
+
+			// пауза перед дублем отсчитывается с первой отправки исходной попытки, а не с момента вызова:
+			// ожидание семафора и квоты не считается медленным ответом источника
+			primarySent := make(chan struct{})
+			var sentOnce sync.Once
+			launch(false, func() { sentOnce.Do(func() { close(primarySent) }) })
+
+			delay := stats.delay(req.Kind)
+			var timer *time.Timer
+			var timerC <-chan time.Time
+			defer func() {
+				if timer != nil {
+					timer.Stop()
+				}
+			}()
+
+			sentC := primarySent
+			pending, hedged := 1, false
+			var firstErr error
+
+			for {
+				select {
+				case <-sentC:
+					sentC = nil
+					timer = time.NewTimer(delay)
+					timerC = timer.C
+
+				case <-timerC:
+					if ctx.Err() != nil {
+						continue // вызывающий ушёл - дублировать нечего, ждём завершения попытки
+					}
+					fmt.Printf("🪃 [%s] %s: нет ответа за %v, отправляем дублирующий запрос\n", source, req.Kind, delay.Round(time.Millisecond))
+					launch(true, func() {})
+					pending++
+					hedged = true
+
+				case attempt := <-attempts:
+					pending--
+					// ответ источника (в том числе с HTTP статусом ошибки) - это ответ, пришедший первым
+					var statusErr StatusCoder
+					if attempt.err == nil || errors.As(attempt.err, &statusErr) {
+						stats.count(hedged, attempt.hedged)
+						return attempt.result, attempt.err
+					}
+					if firstErr == nil {
+						firstErr = attempt.err
+					}
+					// сетевой сбой одной попытки: пока другая ещё в работе - ждём её
+					if pending > 0 {
+						continue
+					}
+					stats.count(hedged, false)
+					return nil, firstErr
+				}
+			}
+		}
+	}
+}
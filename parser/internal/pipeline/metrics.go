@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MetricsSnapshot - счётчики запросов одного типа
+type MetricsSnapshot struct {
+	Requests    int64
+	Errors      int64
+	AvgLatency  time.Duration
+	MaxLatency  time.Duration
+	LastLatency time.Duration
+}
+
+// Metrics - счётчики запросов парсера по типам запросов
+type Metrics struct {
+	mu     sync.Mutex
+	byKind map[RequestKind]*kindMetrics
+}
+
+type kindMetrics struct {
+	requests     int64
+	errors       int64
+	totalLatency time.Duration
+	maxLatency   time.Duration
+	lastLatency  time.Duration
+}
+
+// NewMetrics создаёт пустые счётчики
+func NewMetrics() *Metrics {
+	return &Metrics{byKind: make(map[RequestKind]*kindMetrics)}
+}
+
+// метод учёта одного запроса
+func (m *Metrics) observe(kind RequestKind, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters, ok := m.byKind[kind]
+	if !ok {
+		counters = &kindMetrics{}
+		m.byKind[kind] = counters
+	}
+
+	counters.requests++
+	if err != nil {
+		counters.errors++
+	}
+	counters.totalLatency += latency
+	counters.lastLatency = latency
+	counters.maxLatency = max(counters.maxLatency, latency)
+}
+
+// Snapshot возвращает копию счётчиков
+func (m *Metrics) Snapshot() map[RequestKind]MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[RequestKind]MetricsSnapshot, len(m.byKind))
+	for kind, counters := range m.byKind {
+		snapshot[kind] = MetricsSnapshot{
+			Requests:    counters.requests,
+			Errors:      counters.errors,
+			AvgLatency:  counters.totalLatency / time.Duration(counters.requests),
+			MaxLatency:  counters.maxLatency,
+			LastLatency: counters.lastLatency,
+		}
+	}
+	return snapshot
+}
+
+// Collect - middleware, собирающая метрики в m
+// стоя снаружи цепочки, учитывает запрос целиком (с ожиданием семафора и повторами)
+func Collect(m *Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+			m.observe(req.Kind, time.Since(start), err)
+			return result, err
+		}
+	}
+}
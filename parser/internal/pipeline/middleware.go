@@ -0,0 +1,155 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"parser/internal/interfaces"
+	"sync"
+	"time"
+)
+
+// CircuitBreaker - middleware, выполняющая запрос через circuit breaker источника
+// 404 на запрос деталей - корректный ответ источника (вакансия удалена), а не сбой: breaker его не учитывает
+// не учитываются и ошибки отменённого запроса (вызывающий ушёл или отменил джобу) - источник в них не виноват
+func CircuitBreaker(cb interfaces.CBInterface) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			var result *Result
+			var ignored error // ошибка, которую вернём вызывающему, но не засчитаем breaker'у
+			err := cb.Execute(func() error {
+				var err error
+				result, err = next(ctx, req)
+				if err != nil && (ctx.Err() != nil || req.Kind == KindDetails && IsNotFound(err)) {
+					ignored = err
+					return nil
+				}
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			if ignored != nil {
+				return nil, ignored
+			}
+			return result, nil
+		}
+	}
+}
+
+// IsNotFound сообщает, что источник ответил 404 (например, вакансия удалена)
+func IsNotFound(err error) bool {
+	var statusErr StatusCoder
+	return errors.As(err, &statusErr) && statusErr.HTTPStatus() == http.StatusNotFound
+}
+
+// Semaphore - middleware, ограничивающая количество одновременных запросов к источнику
+// wait - сколько ждать свободного слота, прежде чем считать источник перегруженным
+func Semaphore(semaphore chan struct{}, wait time.Duration, source string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("context canceled while waiting for semaphore: %w", ctx.Err())
+			case <-time.After(wait):
+				return nil, fmt.Errorf("semaphore timeout: %s API is busy", source)
+			}
+			defer func() { <-semaphore }()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// RateLimiter - middleware, ожидающая rate limiter источника перед запросом
+// skip - запросы, которые не расходуют квоту источника (например, ответ отдаст транспортный кэш), может быть nil
+func RateLimiter(limiter interfaces.RateLimiter, skip func(url string) bool) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			if skip == nil || !skip(req.URL) {
+				// ожидание квоты прерывается отменой запроса (например, дублирующий запрос проиграл или истёк дедлайн)
+				if err := limiter.Wait(ctx); err != nil {
+					return nil, fmt.Errorf("rate limiter: %w", err)
+				}
+				// токен и отмена могли прийти одновременно - не ходим в источник зря
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Logging - middleware, логирующая каждый запрос с длительностью и результатом
+func Logging(source string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+			duration := time.Since(start).Round(time.Millisecond)
+
+			if err != nil {
+				fmt.Printf("📡 [%s] %s %s - ошибка за %v: %v\n", source, req.Kind, req.URL, duration, err)
+			} else {
+				fmt.Printf("📡 [%s] %s %s - успешно за %v\n", source, req.Kind, req.URL, duration)
+			}
+			return result, err
+		}
+	}
+}
+
+// InjectedFault - ошибка, которую вернула middleware имитации сбоев
+// ведёт себя как ответ источника с HTTP статусом, поэтому на неё реагируют повторы и circuit breaker
+type InjectedFault struct {
+	Status int
+}
+
+func (e *InjectedFault) Error() string {
+	return fmt.Sprintf("injected fault: API returned status %d", e.Status)
+}
+
+// HTTPStatus возвращает имитируемый HTTP статус
+func (e *InjectedFault) HTTPStatus() int {
+	return e.Status
+}
+
+// RetryAfterDelay - имитируемый сбой не просит паузы
+func (e *InjectedFault) RetryAfterDelay() time.Duration {
+	return 0
+}
+
+// FaultInjection - middleware, имитирующая сбои и задержки источника
+func FaultInjection(config FaultInjectionConfig, seed int64) Middleware {
+	status := config.ErrorStatus
+	if status < 400 || status > 599 {
+		status = http.StatusServiceUnavailable
+	}
+
+	var mu sync.Mutex
+	rnd := rand.New(rand.NewSource(seed))
+	roll := func() float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return rnd.Float64()
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			if config.Latency > 0 && roll() < config.LatencyRate {
+				select {
+				case <-time.After(config.Latency):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			if roll() < config.ErrorRate {
+				return nil, &InjectedFault{Status: status}
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"parser/internal/circuitbreaker"
+	ratelimiter "parser/internal/rate_limiter"
+	"parser/internal/retry"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ошибка источника с HTTP статусом (как ответ API с ошибкой)
+type statusError struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string                  { return fmt.Sprintf("API returned status %d", e.status) }
+func (e *statusError) HTTPStatus() int                { return e.status }
+func (e *statusError) RetryAfterDelay() time.Duration { return e.retryAfter }
+
+// обработчик-заглушка: отдаёт ошибки из списка по порядку, затем - успешный результат
+type stubHandler struct {
+	mu    sync.Mutex
+	errs  []error
+	calls int
+}
+
+func (h *stubHandler) handle(ctx context.Context, req *Request) (*Result, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls++
+	if h.calls <= len(h.errs) {
+		return nil, h.errs[h.calls-1]
+	}
+	return &Result{}, nil
+}
+
+func (h *stubHandler) callCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls
+}
+
+// rate limiter-заглушка: считает ожидания квоты
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error { l.waits.Add(1); return ctx.Err() }
+func (l *countingLimiter) Stop()                          {}
+
+// rate limiter-заглушка: каждая квота выдаётся с задержкой
+type slowLimiter struct {
+	delay time.Duration
+	waits atomic.Int32
+}
+
+func (l *slowLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	time.Sleep(l.delay)
+	return nil
+}
+func (l *slowLimiter) Stop() {}
+
+func TestRetry(t *testing.T) {
+	policy := retry.NewPolicy(retry.RetryConfig{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		Multiplier:           2,
+		MaxRetryAfter:        200 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	})
+
+	tests := []struct {
+		name        string
+		errs        []error
+		timeout     time.Duration // дедлайн вызывающего (0 - без дедлайна)
+		wantCalls   int
+		wantErr     bool
+		wantErrText string
+		minDuration time.Duration
+	}{
+		{
+			name:      "success on first attempt",
+			wantCalls: 1,
+		},
+		{
+			name:      "retryable status then success",
+			errs:      []error{&statusError{status: http.StatusServiceUnavailable}},
+			wantCalls: 2,
+		},
+		{
+			name:      "non-retryable status is not retried",
+			errs:      []error{&statusError{status: http.StatusNotFound}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "attempts are exhausted",
+			errs: []error{
+				&statusError{status: http.StatusServiceUnavailable},
+				&statusError{status: http.StatusServiceUnavailable},
+				&statusError{status: http.StatusServiceUnavailable},
+			},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:        "Retry-After is honoured",
+			errs:        []error{&statusError{status: http.StatusTooManyRequests, retryAfter: 50 * time.Millisecond}},
+			wantCalls:   2,
+			minDuration: 50 * time.Millisecond,
+		},
+		{
+			name:      "Retry-After longer than max_retry_after is not retried",
+			errs:      []error{&statusError{status: http.StatusTooManyRequests, retryAfter: time.Second}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:        "pause that does not fit the deadline aborts at once",
+			errs:        []error{&statusError{status: http.StatusTooManyRequests, retryAfter: 150 * time.Millisecond}},
+			timeout:     50 * time.Millisecond,
+			wantCalls:   1,
+			wantErr:     true,
+			wantErrText: context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			stub := &stubHandler{errs: tt.errs}
+			start := time.Now()
+			_, err := Retry(policy, "test")(stub.handle)(ctx, &Request{Kind: KindSearch})
+			elapsed := time.Since(start)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && !strings.Contains(fmt.Sprint(err), tt.wantErrText) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.wantErrText)
+			}
+			if stub.callCount() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", stub.callCount(), tt.wantCalls)
+			}
+			if elapsed < tt.minDuration {
+				t.Errorf("finished in %v, want at least %v", elapsed, tt.minDuration)
+			}
+			if tt.timeout > 0 && elapsed >= tt.timeout {
+				t.Errorf("finished in %v, want to give up before the deadline %v", elapsed, tt.timeout)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	tests := []struct {
+		name         string
+		kind         RequestKind
+		err          error
+		cancelled    bool // контекст вызывающего отменён
+		wantFailures uint
+	}{
+		{name: "source failure counts", kind: KindSearch, err: &statusError{status: http.StatusBadGateway}, wantFailures: 1},
+		{name: "details 404 is ignored", kind: KindDetails, err: &statusError{status: http.StatusNotFound}},
+		{name: "search 404 counts", kind: KindSearch, err: &statusError{status: http.StatusNotFound}, wantFailures: 1},
+		{name: "cancelled request is ignored", kind: KindSearch, err: fmt.Errorf("request: %w", context.Canceled), cancelled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := circuitbreaker.NewCircutBreaker(circuitbreaker.CircuitBreakerConfig{FailureThreshold: 1})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			stub := &stubHandler{errs: []error{tt.err}}
+			_, err := CircuitBreaker(cb)(stub.handle)(ctx, &Request{Kind: tt.kind})
+
+			// ошибка в любом случае доходит до вызывающего
+			if !errors.Is(err, tt.err) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+			if _, _, failures := cb.GetStats(); failures != tt.wantFailures {
+				t.Errorf("breaker failures = %d, want %d", failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	tests := []struct {
+		name      string
+		occupied  bool // все слоты семафора заняты
+		cancelled bool // контекст вызывающего отменён
+		wantCalls int
+		wantErr   string
+		wantErrIs error
+	}{
+		{name: "free slot", wantCalls: 1},
+		{name: "busy semaphore times out", occupied: true, wantErr: "semaphore timeout"},
+		{name: "cancelled while waiting", occupied: true, cancelled: true, wantErrIs: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			semaphore := make(chan struct{}, 1)
+			if tt.occupied {
+				semaphore <- struct{}{}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			stub := &stubHandler{}
+			_, err := Semaphore(semaphore, 20*time.Millisecond, "test")(stub.handle)(ctx, &Request{Kind: KindSearch})
+
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("err = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr == "" && tt.wantErrIs == nil && err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+			if stub.callCount() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", stub.callCount(), tt.wantCalls)
+			}
+			// слот, занятый запросом, освобождается после него
+			if !tt.occupied && len(semaphore) != 0 {
+				t.Errorf("semaphore slot was not released")
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name      string
+		skip      func(url string) bool
+		cancelled bool
+		wantWaits int32
+		wantCalls int
+	}{
+		{name: "no skip func uses the limiter", wantWaits: 1, wantCalls: 1},
+		{name: "revalidation uses the limiter", skip: func(string) bool { return false }, wantWaits: 1, wantCalls: 1},
+		{name: "fresh cache hit skips the limiter", skip: func(string) bool { return true }, wantCalls: 1},
+		{name: "request cancelled while waiting is not sent", cancelled: true, wantWaits: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &countingLimiter{}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			stub := &stubHandler{}
+			_, err := RateLimiter(limiter, tt.skip)(stub.handle)(ctx, &Request{Kind: KindSearch, URL: "http://source/vacancies"})
+
+			if tt.cancelled != (err != nil) {
+				t.Errorf("err = %v, cancelled %v", err, tt.cancelled)
+			}
+			if got := limiter.waits.Load(); got != tt.wantWaits {
+				t.Errorf("limiter waits = %d, want %d", got, tt.wantWaits)
+			}
+			if stub.callCount() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", stub.callCount(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRateLimiterCancelledWhileWaiting(t *testing.T) {
+	// следующий токен - только через час: без учёта контекста запрос завис бы
+	limiter := ratelimiter.NewChannelRateLimiter(time.Hour)
+	defer limiter.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	stub := &stubHandler{}
+	done := make(chan error, 1)
+	go func() {
+		_, err := RateLimiter(limiter, nil)(stub.handle)(ctx, &Request{Kind: KindSearch})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled request is still waiting for a token")
+	}
+	if stub.callCount() != 0 {
+		t.Errorf("cancelled request reached the source")
+	}
+}
+
+func TestHedge(t *testing.T) {
+	const hedgeDelay = 20 * time.Millisecond
+
+	tests := []struct {
+		name           string
+		primaryDelay   time.Duration // сколько отвечает первая попытка
+		primaryErr     error
+		hedgeDelay     time.Duration // сколько отвечает дублирующая попытка
+		wantHedged     int64
+		wantWins       int64
+		wantCancelled  bool // проигравшая попытка должна быть отменена
+		wantErr        bool
+		wantCallsAtEnd int32
+	}{
+		{
+			name:           "fast primary is not hedged",
+			primaryDelay:   0,
+			wantCallsAtEnd: 1,
+		},
+		{
+			name:           "hedge wins and the primary is cancelled",
+			primaryDelay:   time.Second,
+			hedgeDelay:     0,
+			wantHedged:     1,
+			wantWins:       1,
+			wantCancelled:  true,
+			wantCallsAtEnd: 2,
+		},
+		{
+			name:           "primary wins and the hedge is cancelled",
+			primaryDelay:   40 * time.Millisecond,
+			hedgeDelay:     time.Second,
+			wantHedged:     1,
+			wantCancelled:  true,
+			wantCallsAtEnd: 2,
+		},
+		{
+			name:           "status error of the primary is the answer",
+			primaryDelay:   40 * time.Millisecond,
+			primaryErr:     &statusError{status: http.StatusServiceUnavailable},
+			hedgeDelay:     time.Second,
+			wantHedged:     1,
+			wantCancelled:  true,
+			wantErr:        true,
+			wantCallsAtEnd: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewHedgeStats(HedgeConfig{Enabled: true, Delay: hedgeDelay, MinSamples: 1000})
+
+			var calls atomic.Int32
+			cancelled := make(chan struct{}, 2)
+			handler := func(ctx context.Context, req *Request) (*Result, error) {
+				delay, err := tt.primaryDelay, tt.primaryErr
+				if calls.Add(1) > 1 {
+					delay, err = tt.hedgeDelay, nil
+				}
+
+				select {
+				case <-time.After(delay):
+					return &Result{}, err
+				case <-ctx.Done():
+					cancelled <- struct{}{}
+					return nil, ctx.Err()
+				}
+			}
+
+			hedge := Chain(handler, Hedge(HedgeConfig{Enabled: true, Delay: hedgeDelay}, stats, "test"))
+			_, err := hedge(context.Background(), &Request{Kind: KindDetails})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantCancelled {
+				select {
+				case <-cancelled:
+				case <-time.After(time.Second):
+					t.Errorf("losing attempt was not cancelled")
+				}
+			}
+			if got := calls.Load(); got != tt.wantCallsAtEnd {
+				t.Errorf("attempts = %d, want %d", got, tt.wantCallsAtEnd)
+			}
+
+			snapshot := stats.Snapshot()
+			if snapshot.Requests != 1 || snapshot.Hedged != tt.wantHedged || snapshot.Wins != tt.wantWins {
+				t.Errorf("stats = %+v, want requests 1, hedged %d, wins %d", snapshot, tt.wantHedged, tt.wantWins)
+			}
+		})
+	}
+}
+
+func TestHedgeDisabledPassesThrough(t *testing.T) {
+	stub := &stubHandler{}
+	_, err := Hedge(HedgeConfig{Enabled: false}, NewHedgeStats(HedgeConfig{}), "test")(stub.handle)(context.Background(), &Request{Kind: KindSearch})
+	if err != nil || stub.callCount() != 1 {
+		t.Errorf("err = %v, calls = %d, want single pass-through call", err, stub.callCount())
+	}
+}
+
+func TestHedgeAttemptsPassLimiters(t *testing.T) {
+	const (
+		hedgeDelay = 20 * time.Millisecond
+		quotaWait  = 3 * hedgeDelay
+	)
+
+	// в порядке по умолчанию hedge стоит выше семафора и rate limiter
+	names := DefaultMiddleware()
+	position := make(map[string]int, len(names))
+	for i, name := range names {
+		position[name] = i
+	}
+	if position[NameHedge] > position[NameRateLimiter] || position[NameHedge] > position[NameSemaphore] {
+		t.Fatalf("default middleware %v: hedge must be above semaphore and rate_limiter", names)
+	}
+
+	semaphore := make(chan struct{}, 2)
+	limiter := &slowLimiter{delay: quotaWait}
+	stats := NewHedgeStats(HedgeConfig{Enabled: true, Delay: hedgeDelay, MinSamples: 1000})
+
+	var calls, maxSlots atomic.Int32
+	sentAt := make([]time.Time, 2)
+	handler := func(ctx context.Context, req *Request) (*Result, error) {
+		call := calls.Add(1)
+		sentAt[call-1] = time.Now()
+		if slots := int32(len(semaphore)); slots > maxSlots.Load() {
+			maxSlots.Store(slots)
+		}
+		if call > 1 {
+			return &Result{}, nil // дубль отвечает сразу
+		}
+		select {
+		case <-time.After(time.Second):
+			return &Result{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	chain := Chain(handler,
+		Hedge(HedgeConfig{Enabled: true, Delay: hedgeDelay}, stats, "test"),
+		Semaphore(semaphore, time.Second, "test"),
+		RateLimiter(limiter, nil),
+	)
+
+	start := time.Now()
+	if _, err := chain(context.Background(), &Request{Kind: KindSearch}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("attempts = %d, want 2", got)
+	}
+	if got := limiter.waits.Load(); got != 2 {
+		t.Errorf("rate limiter waits = %d, want 2 (each attempt spends its own quota)", got)
+	}
+	if got := maxSlots.Load(); got != 2 {
+		t.Errorf("semaphore slots in use = %d, want 2 (each attempt holds its own slot)", got)
+	}
+	// пауза перед дублем отсчитывается после получения квоты исходной попыткой, а дубль сам ждёт квоту
+	if gap := sentAt[1].Sub(sentAt[0]); gap < hedgeDelay+quotaWait {
+		t.Errorf("hedge was sent %v after the primary, want at least %v (delay after sending plus its own quota wait)", gap, hedgeDelay+quotaWait)
+	}
+	if elapsed := sentAt[0].Sub(start); elapsed < quotaWait {
+		t.Errorf("primary was sent after %v, want at least the quota wait %v", elapsed, quotaWait)
+	}
+	if snapshot := stats.Snapshot(); snapshot.Hedged != 1 || snapshot.Wins != 1 {
+		t.Errorf("stats = %+v, want hedged 1, wins 1", snapshot)
+	}
+}
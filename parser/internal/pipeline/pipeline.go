@@ -0,0 +1,68 @@
+// цепочка middleware для запросов парсера к источнику
+// каждый этап (circuit breaker, семафор, повторы, rate limiter, логирование, метрики, имитация сбоев) -
+// отдельная middleware, порядок и состав задаются для каждого парсера в конфиге
+package pipeline
+
+import (
+	"context"
+	"parser/internal/domain/models"
+)
+
+// RequestKind - тип запроса к источнику
+type RequestKind string
+
+const (
+	KindSearch  RequestKind = "search"  // поиск списка вакансий
+	KindDetails RequestKind = "details" // детали конкретной вакансии
+)
+
+// Request - запрос, проходящий через цепочку
+type Request struct {
+	Kind      RequestKind
+	Source    string              // имя парсера (источника)
+	URL       string              // URL запроса к API источника
+	Params    models.SearchParams // параметры поиска (для KindSearch)
+	VacancyID string              // ID вакансии (для KindDetails)
+	Attempt   int                 // номер попытки (проставляет middleware повторов, 0 - повторов нет)
+}
+
+// Result - результат запроса (заполнено поле, соответствующее типу запроса)
+type Result struct {
+	Vacancies []models.Vacancy
+	Details   models.SearchVacancyDetailesResult
+}
+
+// Handler - обработчик запроса (последний в цепочке выполняет HTTP запрос и разбор ответа)
+type Handler func(ctx context.Context, req *Request) (*Result, error)
+
+// Middleware - обёртка над обработчиком
+type Middleware func(next Handler) Handler
+
+// Chain собирает цепочку: первая middleware в списке - внешняя (выполняется первой)
+// перед вызовом последнего обработчика срабатывает хук отправки из контекста (см. withSentHook)
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	handler = notifySent(handler)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// ключ контекста для хука отправки запроса к источнику
+type sentHookKey struct{}
+
+// withSentHook возвращает контекст, в котором hook вызывается каждый раз, когда запрос прошёл все middleware
+// (получил слот семафора, квоту rate limiter и т.д.) и уходит к источнику; повторы вызывают его заново
+func withSentHook(ctx context.Context, hook func()) context.Context {
+	return context.WithValue(ctx, sentHookKey{}, hook)
+}
+
+// notifySent - обёртка последнего обработчика цепочки, вызывающая хук отправки
+func notifySent(handler Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Result, error) {
+		if hook, ok := ctx.Value(sentHookKey{}).(func()); ok {
+			hook()
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,99 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"parser/internal/retry"
+	"time"
+)
+
+// StatusCoder - ошибка, соответствующая HTTP ответу источника (по ней политика повторов принимает решение)
+type StatusCoder interface {
+	error
+	HTTPStatus() int
+	RetryAfterDelay() time.Duration // пауза, которую запросил сервер (0 - не запрашивал)
+}
+
+// Retry - middleware повторов согласно политике парсера
+// всё, что стоит в цепочке после неё (rate limiter, HTTP запрос, разбор), выполняется на каждой попытке
+func Retry(policy *retry.Policy, source string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Result, error) {
+			var lastErr error
+
+			for attempt := 1; attempt <= policy.MaxAttempts(); attempt++ {
+				// не начинаем попытку, если вызывающий уже отказался от результата
+				if err := ctx.Err(); err != nil {
+					return nil, wrapRetryError(attempt-1, lastErr, err)
+				}
+
+				attemptReq := *req
+				attemptReq.Attempt = attempt
+
+				result, err := next(ctx, &attemptReq)
+				if err == nil {
+					return result, nil
+				}
+				lastErr = err
+
+				// решаем, есть ли смысл повторять
+				delay, retryable := retryDelay(ctx, policy, attempt, err)
+				if !retryable || attempt == policy.MaxAttempts() {
+					break
+				}
+
+				// если пауза не укладывается в дедлайн вызывающего - выходим сразу, не тратя время
+				if !retry.FitsDeadline(ctx, delay) {
+					return nil, wrapRetryError(attempt, lastErr, context.DeadlineExceeded)
+				}
+
+				fmt.Printf("🔁 [%s] попытка %d/%d не удалась (%v), повтор через %v\n",
+					source, attempt, policy.MaxAttempts(), err, delay.Round(time.Millisecond))
+
+				if err := retry.Sleep(ctx, delay); err != nil {
+					return nil, wrapRetryError(attempt, lastErr, err)
+				}
+			}
+
+			return nil, lastErr
+		}
+	}
+}
+
+// функция определения паузы перед следующей попыткой и признака, что ошибку можно повторять
+func retryDelay(ctx context.Context, policy *retry.Policy, attempt int, err error) (time.Duration, bool) {
+	// ошибки, вызванные отменой контекста вызывающего, не повторяем
+	if ctx.Err() != nil {
+		return 0, false
+	}
+
+	var statusErr StatusCoder
+	if errors.As(err, &statusErr) {
+		if !policy.IsRetryableStatus(statusErr.HTTPStatus()) {
+			return 0, false
+		}
+
+		// сервер явно сказал, когда приходить - уважаем это, но не ждём дольше разумного
+		if retryAfter := statusErr.RetryAfterDelay(); retryAfter > 0 {
+			if retryAfter > policy.MaxRetryAfter() {
+				return 0, false
+			}
+			return max(retryAfter, policy.Backoff(attempt)), true
+		}
+		return policy.Backoff(attempt), true
+	}
+
+	if !policy.IsRetryableError(err) {
+		return 0, false
+	}
+	return policy.Backoff(attempt), true
+}
+
+// функция формирования ошибки при досрочном прекращении повторов
+func wrapRetryError(attempts int, lastErr, cause error) error {
+	if lastErr == nil {
+		return cause
+	}
+	return fmt.Errorf("%w (retry aborted after %d attempt(s): %v)", lastErr, attempts, cause)
+}
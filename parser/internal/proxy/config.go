@@ -0,0 +1,34 @@
+package proxy
+
+import "time"
+
+// стратегии выбора прокси из пула
+const (
+	StrategyRoundRobin = "round_robin" // по кругу, пропуская исключённые прокси
+	StrategyHealth     = "health"      // прокси с наименьшим числом ошибок и наименьшей задержкой
+)
+
+// Config - конфигурация прокси для HTTP клиента (парсера или health check клиента)
+// поддерживаются схемы http://, https:// и socks5:// (socks5h:// - с разрешением имён на стороне прокси)
+type Config struct {
+	URL             string        `yaml:"url"`              // одиночный прокси (например, корпоративный egress прокси)
+	Pool            []string      `yaml:"pool"`             // пул прокси для ротации (используется вместе с URL, если оба заданы)
+	Strategy        string        `yaml:"strategy"`         // стратегия выбора из пула: round_robin | health
+	FromEnvironment bool          `yaml:"from_environment"` // брать прокси из HTTP_PROXY / HTTPS_PROXY / NO_PROXY, если URL и Pool пустые
+	FailureLimit    int           `yaml:"failure_limit"`    // сколько ошибок подряд, чтобы временно исключить прокси из пула
+	EjectDuration   time.Duration `yaml:"eject_duration"`   // на сколько исключается неисправный прокси
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию (прокси не используется)
+func DefaultConfig() Config {
+	return Config{
+		Strategy:      StrategyRoundRobin,
+		FailureLimit:  2,
+		EjectDuration: 30 * time.Second,
+	}
+}
+
+// IsEnabled сообщает, задан ли в конфиге хотя бы один прокси
+func (c Config) IsEnabled() bool {
+	return c.URL != "" || len(c.Pool) > 0
+}
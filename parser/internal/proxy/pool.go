@@ -0,0 +1,234 @@
+// пул прокси с ротацией и временным исключением неисправных прокси
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoHealthyProxy - все прокси пула временно исключены
+var ErrNoHealthyProxy = errors.New("no healthy proxy available")
+
+// состояние отдельного прокси в пуле
+type proxyState struct {
+	url           *url.URL
+	failures      int           // ошибок подряд
+	ejectedUntil  time.Time     // до какого момента прокси исключён из ротации
+	latencyEWMA   time.Duration // сглаженная задержка успешных запросов
+	totalFailures uint64
+	totalRequests uint64
+}
+
+// ProxyStats - статистика отдельного прокси (для вывода состояния)
+type ProxyStats struct {
+	URL           string
+	Ejected       bool
+	Failures      int
+	LatencyEWMA   time.Duration
+	TotalRequests uint64
+	TotalFailures uint64
+}
+
+// Pool - пул прокси
+type Pool struct {
+	proxies       []*proxyState
+	strategy      string
+	failureLimit  int
+	ejectDuration time.Duration
+	next          int // курсор для round robin
+	mu            sync.Mutex
+}
+
+// конструктор пула прокси из конфига
+func NewPool(config Config) (*Pool, error) {
+	defaults := DefaultConfig()
+	if config.Strategy == "" {
+		config.Strategy = defaults.Strategy
+	}
+	if config.FailureLimit <= 0 {
+		config.FailureLimit = defaults.FailureLimit
+	}
+	if config.EjectDuration <= 0 {
+		config.EjectDuration = defaults.EjectDuration
+	}
+	if config.Strategy != StrategyRoundRobin && config.Strategy != StrategyHealth {
+		return nil, fmt.Errorf("unknown proxy strategy: %s", config.Strategy)
+	}
+
+	rawURLs := config.Pool
+	if config.URL != "" {
+		rawURLs = append([]string{config.URL}, rawURLs...)
+	}
+	if len(rawURLs) == 0 {
+		return nil, errors.New("proxy pool is empty")
+	}
+
+	pool := &Pool{
+		strategy:      config.Strategy,
+		failureLimit:  config.FailureLimit,
+		ejectDuration: config.EjectDuration,
+	}
+
+	for _, raw := range rawURLs {
+		proxyURL, err := parseProxyURL(raw)
+		if err != nil {
+			return nil, err
+		}
+		pool.proxies = append(pool.proxies, &proxyState{url: proxyURL})
+	}
+
+	return pool, nil
+}
+
+// функция разбора и проверки адреса прокси
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", raw, err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (http, https, socks5 expected)", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: host is empty", raw)
+	}
+	return proxyURL, nil
+}
+
+// Size возвращает количество прокси в пуле
+func (p *Pool) Size() int {
+	return len(p.proxies)
+}
+
+// Pick выбирает прокси согласно стратегии, пропуская исключённые
+// exclude - прокси, которые уже были опробованы для текущего запроса
+func (p *Pool) Pick(exclude map[string]struct{}) (*url.URL, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var candidates []*proxyState
+	for _, state := range p.proxies {
+		if _, skip := exclude[state.url.String()]; skip {
+			continue
+		}
+		if now.Before(state.ejectedUntil) {
+			continue
+		}
+		candidates = append(candidates, state)
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoHealthyProxy
+	}
+
+	if p.strategy == StrategyHealth {
+		return pickHealthiest(candidates).url, nil
+	}
+
+	// round robin: сдвигаем курсор по всему пулу, чтобы нагрузка распределялась равномерно
+	chosen := candidates[p.next%len(candidates)]
+	p.next++
+	return chosen.url, nil
+}
+
+// функция выбора "самого здорового" прокси: меньше ошибок подряд, затем меньше задержка
+func pickHealthiest(candidates []*proxyState) *proxyState {
+	best := candidates[0]
+	for _, state := range candidates[1:] {
+		if state.failures < best.failures ||
+			(state.failures == best.failures && state.latencyEWMA < best.latencyEWMA) {
+			best = state
+		}
+	}
+	return best
+}
+
+// ReportSuccess отмечает успешный запрос через прокси
+func (p *Pool) ReportSuccess(proxyURL *url.URL, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.find(proxyURL)
+	if state == nil {
+		return
+	}
+
+	state.totalRequests++
+	state.failures = 0
+	if state.latencyEWMA == 0 {
+		state.latencyEWMA = latency
+	} else {
+		// коэффициент сглаживания 0.2: новые замеры влияют, но не "дёргают" оценку
+		state.latencyEWMA = time.Duration(0.8*float64(state.latencyEWMA) + 0.2*float64(latency))
+	}
+}
+
+// ReportFailure отмечает ошибку прокси; после FailureLimit ошибок подряд прокси временно исключается
+// возвращает true, если прокси был исключён
+func (p *Pool) ReportFailure(proxyURL *url.URL) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	state := p.find(proxyURL)
+	if state == nil {
+		return false
+	}
+
+	state.totalRequests++
+	state.totalFailures++
+	state.failures++
+	if state.failures >= p.failureLimit {
+		state.ejectedUntil = time.Now().Add(p.ejectDuration)
+		state.failures = 0
+		return true
+	}
+	return false
+}
+
+// Stats возвращает статистику по всем прокси пула
+func (p *Pool) Stats() []ProxyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	stats := make([]ProxyStats, len(p.proxies))
+	for i, state := range p.proxies {
+		stats[i] = ProxyStats{
+			URL:           redactURL(state.url),
+			Ejected:       now.Before(state.ejectedUntil),
+			Failures:      state.failures,
+			LatencyEWMA:   state.latencyEWMA,
+			TotalRequests: state.totalRequests,
+			TotalFailures: state.totalFailures,
+		}
+	}
+	return stats
+}
+
+// метод поиска состояния прокси по адресу (вызывается под мьютексом)
+func (p *Pool) find(proxyURL *url.URL) *proxyState {
+	for _, state := range p.proxies {
+		if state.url == proxyURL || state.url.String() == proxyURL.String() {
+			return state
+		}
+	}
+	return nil
+}
+
+// функция скрытия логина/пароля прокси при выводе
+func redactURL(proxyURL *url.URL) string {
+	if proxyURL.User == nil {
+		return proxyURL.String()
+	}
+	redacted := *proxyURL
+	redacted.User = url.UserPassword("***", "***")
+	return redacted.String()
+}
@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ключ контекста запроса, в котором передаётся выбранный прокси
+type proxyCtxKey struct{}
+
+// Transport - http.RoundTripper, который выбирает прокси из пула для каждого запроса
+// при ошибке самого прокси запрос повторяется через другой прокси, а неисправный прокси временно исключается,
+// поэтому проблемы прокси не доходят до circuit breaker источника
+type Transport struct {
+	base *http.Transport
+	pool *Pool
+}
+
+// Configure настраивает прокси для транспорта согласно конфигу
+// возвращает транспорт, который нужно установить в http.Client, и пул прокси (nil - если пул не используется)
+func Configure(base *http.Transport, config Config) (http.RoundTripper, *Pool, error) {
+	if !config.IsEnabled() {
+		if config.FromEnvironment {
+			base.Proxy = http.ProxyFromEnvironment
+		}
+		return base, nil, nil
+	}
+
+	pool, err := NewPool(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// транспорт берёт прокси из контекста запроса - его туда кладёт Transport.RoundTrip
+	base.Proxy = proxyFromContext
+
+	return &Transport{base: base, pool: pool}, pool, nil
+}
+
+// функция для http.Transport.Proxy: прокси, выбранный для конкретного запроса
+func proxyFromContext(req *http.Request) (*url.URL, error) {
+	proxyURL, _ := req.Context().Value(proxyCtxKey{}).(*url.URL)
+	return proxyURL, nil
+}
+
+// RoundTrip выполняет запрос через прокси из пула
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tried := make(map[string]struct{}, t.pool.Size())
+	var lastErr error
+
+	for attempt := 0; attempt < t.pool.Size(); attempt++ {
+		proxyURL, err := t.pool.Pick(tried)
+		if err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w (last proxy error: %v)", err, lastErr)
+			}
+			return nil, err
+		}
+		tried[proxyURL.String()] = struct{}{}
+
+		outReq, err := cloneForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		outReq = outReq.WithContext(context.WithValue(req.Context(), proxyCtxKey{}, proxyURL))
+
+		start := time.Now()
+		resp, err := t.base.RoundTrip(outReq)
+
+		switch {
+		case err == nil && resp.StatusCode != http.StatusProxyAuthRequired:
+			t.pool.ReportSuccess(proxyURL, time.Since(start))
+			return resp, nil
+		case err == nil:
+			// прокси не принял учётные данные - это проблема прокси, а не источника
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+			err = fmt.Errorf("proxy %s: %s", redactURL(proxyURL), resp.Status)
+		case !isProxyError(err) || req.Context().Err() != nil:
+			// ошибка источника или отмена запроса - возвращаем как есть, прокси не виноват
+			return nil, err
+		}
+
+		lastErr = err
+		if t.pool.ReportFailure(proxyURL) {
+			fmt.Printf("🧦 Прокси %s временно исключён из пула: %v\n", redactURL(proxyURL), err)
+		}
+	}
+
+	return nil, fmt.Errorf("all proxies failed: %w", lastErr)
+}
+
+// Pool возвращает пул прокси транспорта
+func (t *Transport) Pool() *Pool {
+	return t.pool
+}
+
+// функция подготовки запроса для повторной попытки (тело запроса нужно пересоздать)
+func cloneForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("proxy retry: request body cannot be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+// функция определения, что ошибка возникла на стороне прокси (соединение с прокси, CONNECT, SOCKS рукопожатие)
+func isProxyError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+		return true
+	}
+	return strings.Contains(err.Error(), "socks connect")
+}
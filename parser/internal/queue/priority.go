@@ -0,0 +1,55 @@
+package queue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority - класс приоритета элемента очереди (меньше значение - выше приоритет)
+type Priority int
+
+const (
+	PriorityHigh   Priority = iota // интерактивные запросы пользователя
+	PriorityNormal                 // тяжёлые запросы пользователя (пачки)
+	PriorityLow                    // фоновые задачи (обновления, перепроверки)
+
+	numPriorities = iota
+)
+
+// Prioritized - элемент, который знает свой класс приоритета
+type Prioritized interface {
+	GetPriority() Priority
+}
+
+// String возвращает имя класса приоритета (используется в конфиге и логах)
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	default:
+		return fmt.Sprintf("priority(%d)", int(p))
+	}
+}
+
+// ParsePriority возвращает класс приоритета по имени
+func ParsePriority(name string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "high":
+		return PriorityHigh, nil
+	case "normal", "":
+		return PriorityNormal, nil
+	case "low":
+		return PriorityLow, nil
+	default:
+		return PriorityNormal, fmt.Errorf("unknown priority %q (expected high, normal or low)", name)
+	}
+}
+
+// метод приведения неизвестного значения к ближайшему допустимому классу
+func (p Priority) normalize() Priority {
+	return min(max(p, PriorityHigh), PriorityLow)
+}
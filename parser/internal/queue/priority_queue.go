@@ -0,0 +1,242 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PriorityQueueConfig - конфигурация очереди с приоритетами
+type PriorityQueueConfig struct {
+	AgingInterval  time.Duration `yaml:"aging_interval"`  // за каждый такой интервал ожидания элемент поднимается на один класс (0 - без старения)
+	HighCapacity   int           `yaml:"high_capacity"`   // вместимость класса high (0 - по умолчанию)
+	NormalCapacity int           `yaml:"normal_capacity"` // вместимость класса normal (0 - по умолчанию)
+	LowCapacity    int           `yaml:"low_capacity"`    // вместимость класса low (0 - по умолчанию)
+}
+
+// элемент очереди с временем постановки (нужно для старения)
+type queuedItem[T any] struct {
+	item       T
+	enqueuedAt time.Time
+}
+
+// PriorityQueue - очередь с классами приоритета: FIFO внутри класса, выбор между классами с учётом старения,
+// чтобы элементы низких классов не ждали бесконечно при постоянном потоке высокоприоритетных
+//
+// ожидающих будят сигнальные каналы ёмкостью 1 (эстафета): проснувшийся забирает сигнал и,
+// если после него ещё остались элементы/место, передаёт сигнал следующему - так не будятся все ожидающие разом
+type PriorityQueue[T Prioritized] struct {
+	mu            sync.Mutex
+	classes       [numPriorities][]queuedItem[T]
+	capacity      [numPriorities]int
+	agingInterval time.Duration
+	closed        bool
+	now           func() time.Time
+
+	notEmpty chan struct{}                // сигнал ожидающим Dequeue: появился элемент
+	notFull  [numPriorities]chan struct{} // сигнал ожидающим Enqueue: в классе освободилось место
+	done     chan struct{}                // закрывается в Close, будит всех ожидающих
+}
+
+// NewPriorityQueue - конструктор очереди с приоритетами
+// defaultCapacity - вместимость классов, для которых она не задана в конфиге
+func NewPriorityQueue[T Prioritized](config PriorityQueueConfig, defaultCapacity int) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{
+		agingInterval: config.AgingInterval,
+		now:           time.Now,
+		notEmpty:      make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+
+	for class, capacity := range [numPriorities]int{config.HighCapacity, config.NormalCapacity, config.LowCapacity} {
+		if capacity <= 0 {
+			capacity = defaultCapacity
+		}
+		q.capacity[class] = capacity
+		q.notFull[class] = make(chan struct{}, 1)
+	}
+	return q
+}
+
+// метод для добавления нового элемента в очередь
+// ждёт места в классе элемента; ошибка - очередь закрыта (ErrClosed) или контекст отменён
+func (q *PriorityQueue[T]) Enqueue(ctx context.Context, item T) error {
+	class := item.GetPriority().normalize()
+
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if len(q.classes[class]) < q.capacity[class] {
+			q.classes[class] = append(q.classes[class], queuedItem[T]{item: item, enqueuedAt: q.now()})
+			// место ещё осталось - передаём сигнал следующему ожидающему этого класса
+			if len(q.classes[class]) < q.capacity[class] {
+				signal(q.notFull[class])
+			}
+			q.mu.Unlock()
+
+			signal(q.notEmpty)
+			return nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.notFull[class]:
+		case <-q.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// метод для получения элемента из очереди, ждёт появления элемента, закрытия очереди или отмены контекста
+// после закрытия очередь отдаёт оставшиеся элементы и только потом ErrClosed
+func (q *PriorityQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	var zeroVal T
+
+	for {
+		q.mu.Lock()
+		item, class, ok := q.pop()
+		if ok {
+			// элементы ещё остались - передаём сигнал следующему ожидающему
+			if q.size() > 0 {
+				signal(q.notEmpty)
+			}
+			q.mu.Unlock()
+
+			signal(q.notFull[class])
+			return item, nil
+		}
+		closed := q.closed
+		q.mu.Unlock()
+
+		if closed {
+			return zeroVal, ErrClosed
+		}
+
+		select {
+		case <-q.notEmpty:
+		case <-q.done:
+		case <-ctx.Done():
+			return zeroVal, ctx.Err()
+		}
+	}
+}
+
+// метод извлечения элемента (вызывается под мьютексом)
+// берётся голова класса с наилучшим эффективным приоритетом: класс минус количество интервалов старения, которые элемент прождал;
+// при равенстве побеждает более высокий класс
+func (q *PriorityQueue[T]) pop() (T, int, bool) {
+	var zeroVal T
+
+	now := q.now()
+	best := -1
+	bestEffective := 0
+	for class := range q.classes {
+		if len(q.classes[class]) == 0 {
+			continue
+		}
+
+		effective := class
+		if q.agingInterval > 0 {
+			effective -= int(now.Sub(q.classes[class][0].enqueuedAt) / q.agingInterval)
+		}
+		if best == -1 || effective < bestEffective {
+			best, bestEffective = class, effective
+		}
+	}
+
+	if best == -1 {
+		return zeroVal, 0, false // очередь пуста
+	}
+
+	head := q.classes[best][0]
+	q.classes[best][0] = queuedItem[T]{} // не держим ссылку на выданный элемент
+	q.classes[best] = q.classes[best][1:]
+	return head.item, best, true
+}
+
+// метод подсчёта элементов во всех классах (вызывается под мьютексом)
+func (q *PriorityQueue[T]) size() int {
+	size := 0
+	for _, items := range q.classes {
+		size += len(items)
+	}
+	return size
+}
+
+// функция неблокирующей отправки сигнала: если сигнал уже ждёт получателя, второй не нужен
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// метод для получения размера очереди в данный момент
+func (q *PriorityQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size()
+}
+
+// SizeByPriority возвращает количество элементов в каждом классе
+func (q *PriorityQueue[T]) SizeByPriority() map[Priority]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sizes := make(map[Priority]int, numPriorities)
+	for class, items := range q.classes {
+		sizes[Priority(class)] = len(items)
+	}
+	return sizes
+}
+
+// Remove удаляет из очереди элементы, для которых match вернул true, и возвращает количество удалённых
+func (q *PriorityQueue[T]) Remove(match func(item T) bool) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	removed := 0
+	for class, items := range q.classes {
+		kept := items[:0]
+		for _, queued := range items {
+			if match(queued.item) {
+				removed++
+				continue
+			}
+			kept = append(kept, queued)
+		}
+		if len(kept) == len(items) {
+			continue
+		}
+		clear(items[len(kept):]) // не держим ссылки на удалённые элементы
+		q.classes[class] = kept
+		signal(q.notFull[class])
+	}
+	return removed
+}
+
+// Close закрывает очередь: добавление элементов невозможно, а чтение вернет оставшиеся элементы
+func (q *PriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// Clear очищает очередь
+func (q *PriorityQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for class := range q.classes {
+		q.classes[class] = nil
+		signal(q.notFull[class])
+	}
+}
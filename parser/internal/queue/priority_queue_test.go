@@ -0,0 +1,279 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// тестовый элемент очереди
+type testItem struct {
+	name     string
+	priority Priority
+}
+
+func (i testItem) GetPriority() Priority {
+	return i.priority
+}
+
+// очередь с управляемыми часами (для проверки старения)
+func newTestQueue(config PriorityQueueConfig, capacity int) (*PriorityQueue[testItem], *time.Time) {
+	q := NewPriorityQueue[testItem](config, capacity)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func mustEnqueue(t *testing.T, q *PriorityQueue[testItem], items ...testItem) {
+	t.Helper()
+	for _, item := range items {
+		if err := q.Enqueue(context.Background(), item); err != nil {
+			t.Fatalf("Enqueue(%s): %v", item.name, err)
+		}
+	}
+}
+
+func dequeueNames(t *testing.T, q *PriorityQueue[testItem], n int) []string {
+	t.Helper()
+	names := make([]string, 0, n)
+	for range n {
+		item, err := q.Dequeue(context.Background())
+		if err != nil {
+			t.Fatalf("Dequeue: %v", err)
+		}
+		names = append(names, item.name)
+	}
+	return names
+}
+
+func equalNames(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	q, _ := newTestQueue(PriorityQueueConfig{}, 10)
+	mustEnqueue(t, q,
+		testItem{"low-1", PriorityLow},
+		testItem{"normal-1", PriorityNormal},
+		testItem{"high-1", PriorityHigh},
+		testItem{"normal-2", PriorityNormal},
+		testItem{"high-2", PriorityHigh},
+		testItem{"unknown", Priority(42)}, // неизвестный класс приводится к low
+	)
+
+	want := []string{"high-1", "high-2", "normal-1", "normal-2", "low-1", "unknown"}
+	if got := dequeueNames(t, q, len(want)); !equalNames(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueAging(t *testing.T) {
+	tests := []struct {
+		name   string
+		aging  time.Duration
+		waited time.Duration
+		want   []string
+	}{
+		{name: "no aging: low waits for all high", waited: time.Hour, want: []string{"high-1", "high-2", "low"}},
+		{name: "one interval: low ties with normal and still loses to high", aging: time.Minute, waited: time.Minute, want: []string{"high-1", "high-2", "low"}},
+		{name: "two intervals: low ties with high and loses the tie", aging: time.Minute, waited: 2 * time.Minute, want: []string{"high-1", "high-2", "low"}},
+		{name: "three intervals: low is promoted above fresh high", aging: time.Minute, waited: 3 * time.Minute, want: []string{"low", "high-1", "high-2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, now := newTestQueue(PriorityQueueConfig{AgingInterval: tt.aging}, 10)
+			mustEnqueue(t, q, testItem{"low", PriorityLow})
+			*now = now.Add(tt.waited)
+			mustEnqueue(t, q, testItem{"high-1", PriorityHigh}, testItem{"high-2", PriorityHigh})
+
+			// при равном эффективном приоритете побеждает более высокий класс,
+			// поэтому low обгоняет high, только прождав больше двух интервалов (low - high = 2 класса)
+			if got := dequeueNames(t, q, len(tt.want)); !equalNames(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityQueueAgingPreventsStarvation(t *testing.T) {
+	q, now := newTestQueue(PriorityQueueConfig{AgingInterval: time.Minute}, 100)
+	mustEnqueue(t, q, testItem{"low", PriorityLow})
+
+	// постоянный поток high: на каждом шаге приходит новый элемент, а один забирается
+	for step := range 10 {
+		*now = now.Add(30 * time.Second)
+		mustEnqueue(t, q, testItem{"high", PriorityHigh})
+
+		item, err := q.Dequeue(context.Background())
+		if err != nil {
+			t.Fatalf("Dequeue: %v", err)
+		}
+		if item.name == "low" {
+			if step < 5 {
+				t.Errorf("low was promoted at step %d, before waiting three aging intervals", step)
+			}
+			return
+		}
+	}
+	t.Fatal("low-priority item was starved by a steady stream of high-priority items")
+}
+
+func TestPriorityQueueEnqueueBlocksWhenFull(t *testing.T) {
+	q, _ := newTestQueue(PriorityQueueConfig{HighCapacity: 1}, 10)
+	mustEnqueue(t, q, testItem{"high-1", PriorityHigh})
+
+	// другие классы не заблокированы заполненным high
+	mustEnqueue(t, q, testItem{"low", PriorityLow})
+
+	enqueued := make(chan error, 1)
+	go func() {
+		enqueued <- q.Enqueue(context.Background(), testItem{"high-2", PriorityHigh})
+	}()
+
+	select {
+	case err := <-enqueued:
+		t.Fatalf("Enqueue into a full class returned %v without waiting", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if got := dequeueNames(t, q, 1); got[0] != "high-1" {
+		t.Fatalf("dequeued %v, want high-1", got)
+	}
+
+	select {
+	case err := <-enqueued:
+		if err != nil {
+			t.Fatalf("blocked Enqueue: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue was not unblocked after a slot was freed")
+	}
+
+	want := []string{"high-2", "low"}
+	if got := dequeueNames(t, q, 2); !equalNames(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueContextCancel(t *testing.T) {
+	tests := []struct {
+		name string
+		full bool
+		wait func(ctx context.Context, q *PriorityQueue[testItem]) error
+	}{
+		{
+			name: "enqueue into a full class",
+			full: true,
+			wait: func(ctx context.Context, q *PriorityQueue[testItem]) error {
+				return q.Enqueue(ctx, testItem{"second", PriorityNormal})
+			},
+		},
+		{
+			name: "dequeue from an empty queue",
+			wait: func(ctx context.Context, q *PriorityQueue[testItem]) error {
+				_, err := q.Dequeue(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := newTestQueue(PriorityQueueConfig{}, 1)
+			if tt.full {
+				mustEnqueue(t, q, testItem{"first", PriorityNormal})
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+
+			result := make(chan error, 1)
+			go func() { result <- tt.wait(ctx, q) }()
+
+			select {
+			case err := <-result:
+				t.Fatalf("returned %v before cancellation", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			cancel()
+			select {
+			case err := <-result:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("err = %v, want context.Canceled", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("cancellation did not unblock the call")
+			}
+		})
+	}
+}
+
+func TestPriorityQueueRemove(t *testing.T) {
+	q, _ := newTestQueue(PriorityQueueConfig{LowCapacity: 2}, 10)
+	mustEnqueue(t, q,
+		testItem{"keep-high", PriorityHigh},
+		testItem{"drop-high", PriorityHigh},
+		testItem{"drop-low", PriorityLow},
+		testItem{"keep-low", PriorityLow},
+	)
+
+	// ожидающий места в заполненном классе low
+	enqueued := make(chan error, 1)
+	go func() {
+		enqueued <- q.Enqueue(context.Background(), testItem{"late-low", PriorityLow})
+	}()
+
+	removed := q.Remove(func(item testItem) bool { return item.name[:4] == "drop" })
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	// освободившееся место будит ожидающего
+	select {
+	case err := <-enqueued:
+		if err != nil {
+			t.Fatalf("blocked Enqueue: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue was not unblocked by Remove")
+	}
+
+	sizes := q.SizeByPriority()
+	if sizes[PriorityHigh] != 1 || sizes[PriorityNormal] != 0 || sizes[PriorityLow] != 2 {
+		t.Errorf("sizes = %v, want high 1, normal 0, low 2", sizes)
+	}
+
+	want := []string{"keep-high", "keep-low", "late-low"}
+	if got := dequeueNames(t, q, len(want)); !equalNames(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if removed := q.Remove(func(testItem) bool { return true }); removed != 0 {
+		t.Errorf("removed from an empty queue = %d, want 0", removed)
+	}
+}
+
+func TestPriorityQueueClose(t *testing.T) {
+	q, _ := newTestQueue(PriorityQueueConfig{}, 10)
+	mustEnqueue(t, q, testItem{"left", PriorityNormal})
+	q.Close()
+
+	if err := q.Enqueue(context.Background(), testItem{"late", PriorityNormal}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Enqueue after Close: err = %v, want ErrClosed", err)
+	}
+	// оставшиеся элементы отдаются и после закрытия
+	if got := dequeueNames(t, q, 1); got[0] != "left" {
+		t.Errorf("dequeued %v, want left", got)
+	}
+	if _, err := q.Dequeue(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Dequeue from a closed empty queue: err = %v, want ErrClosed", err)
+	}
+}
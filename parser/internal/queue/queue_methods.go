@@ -1,34 +1,44 @@
 package queue
 
-import "sync/atomic"
+import (
+	"context"
+	"sync/atomic"
+)
 
-// метод для добавления нового элемента в очередь
-func (q *FIFOQueue[T]) Enqueue(item T) bool {
+// метод для добавления нового элемента в очередь, ждёт свободного места, пока очередь не закрыта и контекст не отменён
+func (q *FIFOQueue[T]) Enqueue(ctx context.Context, item T) error {
 	// Атомарная проверка - не блокирует другие горутины
 	if atomic.LoadInt32(&q.closed) == 1 {
-		return false
+		return ErrClosed
 	}
 
 	select {
 	case q.items <- item:
-		return true
-	default:
-		return false // очередь переполнена
+		return nil
+	case <-q.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
-// метод для получения элемента из очереди
-func (q *FIFOQueue[T]) Dequeue() (T, bool) {
+// метод для получения элемента из очереди, ждёт появления элемента, закрытия очереди или отмены контекста
+// после закрытия очередь отдаёт оставшиеся элементы и только потом ErrClosed
+func (q *FIFOQueue[T]) Dequeue(ctx context.Context) (T, error) {
 	var zeroVal T
 	select {
-	case item, ok := <-q.items:
-		if ok {
-			return item, true
-		} else {
-			return zeroVal, false
+	case item := <-q.items:
+		return item, nil
+	case <-ctx.Done():
+		return zeroVal, ctx.Err()
+	case <-q.done:
+		// select выбирает случайно среди готовых веток - дочитываем то, что осталось после закрытия
+		select {
+		case item := <-q.items:
+			return item, nil
+		default:
+			return zeroVal, ErrClosed
 		}
-	default:
-		return zeroVal, false // очередь пуста
 	}
 }
 
@@ -42,17 +52,12 @@ func (q *FIFOQueue[T]) Size() int {
 func (q *FIFOQueue[T]) Close() {
 	// CAS гарантирует, что закрываем только один раз
 	if atomic.CompareAndSwapInt32(&q.closed, 0, 1) {
-		close(q.items)
+		close(q.done)
 	}
 }
 
 // Clear безопасно очищает очередь
 func (q *FIFOQueue[T]) Clear() {
-	// Быстрая проверка без блокировки
-	if atomic.LoadInt32(&q.closed) == 1 {
-		return
-	}
-
 	// Вычитываем все элементы неблокирующим способом
 	for {
 		select {
@@ -1,15 +1,23 @@
 package queue
 
+import "errors"
+
+// ErrClosed - очередь закрыта: добавлять нельзя, а при чтении - элементы закончились
+var ErrClosed = errors.New("queue is closed")
+
 // структура для очереди
+// канал элементов никогда не закрывается (иначе Enqueue, гонящийся с Close, паникует) - о закрытии сообщает канал done
 type FIFOQueue[T any] struct {
 	items  chan T
-	closed int32 // 0 = открыт, 1 = закрыт
+	done   chan struct{} // закрывается в Close, будит всех ожидающих
+	closed int32         // 0 = открыт, 1 = закрыт
 }
 
 // конструктор для очереди
 func NewFIFOQueue[T any](capacity int) *FIFOQueue[T] {
 	return &FIFOQueue[T]{
 		items:  make(chan T, capacity),
+		done:   make(chan struct{}),
 		closed: 0, // при создании экзмепляра очереди устанавливаем в флаг 0. Канал открыт
 	}
 }
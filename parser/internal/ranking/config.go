@@ -0,0 +1,43 @@
+package ranking
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config - конфигурация ранжирования общей выдачи мульти-поиска
+type Config struct {
+	DefaultSort       SortMode      `yaml:"default_sort"`        // сортировка по умолчанию: relevance, date, salary, company
+	RelevanceWeight   float64       `yaml:"relevance_weight"`    // вес совпадения с текстом запроса в итоговой оценке
+	FreshnessWeight   float64       `yaml:"freshness_weight"`    // вес свежести публикации
+	SalaryWeight      float64       `yaml:"salary_weight"`       // вес наличия зарплаты
+	FreshnessHalfLife time.Duration `yaml:"freshness_half_life"` // через сколько времени свежесть публикации падает вдвое
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		DefaultSort:       SortRelevance,
+		RelevanceWeight:   0.6,
+		FreshnessWeight:   0.25,
+		SalaryWeight:      0.15,
+		FreshnessHalfLife: 72 * time.Hour,
+	}
+}
+
+// Validate проверяет конфиг
+func (c Config) Validate() error {
+	if _, err := ParseSortMode(string(c.DefaultSort)); err != nil {
+		return fmt.Errorf("ranking: default_sort: %w", err)
+	}
+	if c.RelevanceWeight < 0 || c.FreshnessWeight < 0 || c.SalaryWeight < 0 {
+		return fmt.Errorf("ranking: weights must not be negative")
+	}
+	if c.RelevanceWeight+c.FreshnessWeight+c.SalaryWeight == 0 {
+		return fmt.Errorf("ranking: at least one weight must be positive")
+	}
+	if c.FreshnessHalfLife <= 0 {
+		return fmt.Errorf("ranking: freshness_half_life must be positive, got %v", c.FreshnessHalfLife)
+	}
+	return nil
+}
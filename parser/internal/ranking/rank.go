@@ -0,0 +1,163 @@
+// ранжирование общей выдачи мульти-поиска: вакансии всех источников сводятся в один список,
+// каждой вакансии выставляется оценка по совпадению с запросом, свежести публикации и наличию зарплаты
+package ranking
+
+import (
+	"math"
+	"parser/internal/domain/models"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// вклад совпадения слова запроса в зависимости от того, где оно нашлось
+const (
+	matchTitle       = 1.0
+	matchSkills      = 0.7
+	matchDescription = 0.4
+
+	// вклад зарплаты, у которой известна только одна граница вилки
+	salaryOneBound = 0.6
+)
+
+// Rank выставляет вакансиям оценку (MergedVacancy.Score) и сортирует их в выбранном порядке
+// при равенстве основного ключа порядок определяется оценкой, затем исходным порядком (сортировка устойчивая)
+func Rank(vacancies []models.MergedVacancy, query string, mode SortMode, config Config, now time.Time) []models.MergedVacancy {
+	ranked := make([]models.MergedVacancy, len(vacancies))
+	copy(ranked, vacancies)
+
+	queryWords := words(query)
+	for i := range ranked {
+		ranked[i].Score = score(ranked[i].Vacancy, queryWords, config, now)
+	}
+
+	byScore := func(a, b models.MergedVacancy) bool { return a.Score > b.Score }
+
+	var less func(a, b models.MergedVacancy) bool
+	switch mode {
+	case SortDate:
+		less = func(a, b models.MergedVacancy) bool {
+			if !a.PublishedAt.Equal(b.PublishedAt) {
+				// публикации без даты - в конце
+				return a.PublishedAt.After(b.PublishedAt)
+			}
+			return byScore(a, b)
+		}
+	case SortSalary:
+		// валюты не пересчитываются: источники почти всегда отдают зарплату в рублях
+		less = func(a, b models.MergedVacancy) bool {
+			if sa, sb := topSalary(a.Vacancy), topSalary(b.Vacancy); sa != sb {
+				return sa > sb
+			}
+			return byScore(a, b)
+		}
+	case SortCompany:
+		less = func(a, b models.MergedVacancy) bool {
+			if ca, cb := companyKey(a.Company), companyKey(b.Company); ca != cb {
+				// вакансии без работодателя - в конце
+				if ca == "" || cb == "" {
+					return cb == ""
+				}
+				return ca < cb
+			}
+			return byScore(a, b)
+		}
+	default:
+		less = byScore
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool { return less(ranked[i], ranked[j]) })
+	return ranked
+}
+
+// функция итоговой оценки вакансии (0..1)
+func score(vacancy models.Vacancy, queryWords []string, config Config, now time.Time) float64 {
+	total := config.RelevanceWeight + config.FreshnessWeight + config.SalaryWeight
+	if total == 0 {
+		return 0
+	}
+
+	value := config.RelevanceWeight*relevance(vacancy, queryWords) +
+		config.FreshnessWeight*freshness(vacancy.PublishedAt, config.FreshnessHalfLife, now) +
+		config.SalaryWeight*salaryPresence(vacancy)
+	return value / total
+}
+
+// функция оценки совпадения вакансии с запросом: для каждого слова запроса берётся лучшее место, где оно нашлось
+// слово считается найденным, если с него начинается слово вакансии (так "разработчик" находит "разработчика")
+func relevance(vacancy models.Vacancy, queryWords []string) float64 {
+	if len(queryWords) == 0 {
+		return 0
+	}
+
+	title := words(vacancy.Job)
+	skills := words(strings.Join(vacancy.Skills, " "))
+	description := words(vacancy.Description)
+
+	sum := 0.0
+	for _, word := range queryWords {
+		switch {
+		case containsPrefix(title, word):
+			sum += matchTitle
+		case containsPrefix(skills, word):
+			sum += matchSkills
+		case containsPrefix(description, word):
+			sum += matchDescription
+		}
+	}
+	return sum / float64(len(queryWords))
+}
+
+// функция оценки свежести: 1 для только что опубликованной вакансии, вдвое меньше за каждый период halfLife
+// вакансия без даты публикации получает 0
+func freshness(publishedAt time.Time, halfLife time.Duration, now time.Time) float64 {
+	if publishedAt.IsZero() || halfLife <= 0 {
+		return 0
+	}
+	age := now.Sub(publishedAt)
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// функция оценки наличия зарплаты: полная вилка - 1, одна граница - salaryOneBound, не указана - 0
+func salaryPresence(vacancy models.Vacancy) float64 {
+	switch {
+	case vacancy.SalaryFrom > 0 && vacancy.SalaryTo > 0:
+		return 1
+	case vacancy.SalaryFrom > 0 || vacancy.SalaryTo > 0:
+		return salaryOneBound
+	default:
+		return 0
+	}
+}
+
+// функция получения зарплаты для сортировки: верхняя граница вилки, если она известна (0 - не указана)
+func topSalary(vacancy models.Vacancy) int {
+	return max(vacancy.SalaryTo, vacancy.SalaryFrom)
+}
+
+// функция получения ключа сортировки по работодателю
+func companyKey(company string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(company)), "ё", "е")
+}
+
+// функция разбиения строки на слова: нижний регистр, ё -> е, без знаков препинания
+func words(s string) []string {
+	s = strings.ReplaceAll(strings.ToLower(s), "ё", "е")
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// функция проверки, начинается ли какое-либо слово из набора с prefix
+func containsPrefix(set []string, prefix string) bool {
+	for _, word := range set {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package ranking
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortMode - порядок общей выдачи
+type SortMode string
+
+const (
+	SortRelevance SortMode = "relevance" // по итоговой оценке (релевантность запросу, свежесть, наличие зарплаты)
+	SortDate      SortMode = "date"      // сначала свежие публикации
+	SortSalary    SortMode = "salary"    // по зарплате от большей к меньшей
+	SortCompany   SortMode = "company"   // по работодателю в алфавитном порядке
+)
+
+// SortModes - все режимы сортировки в порядке пунктов меню
+var SortModes = []SortMode{SortRelevance, SortDate, SortSalary, SortCompany}
+
+// Title возвращает название режима для вывода пользователю
+func (m SortMode) Title() string {
+	switch m {
+	case SortRelevance:
+		return "по релевантности"
+	case SortDate:
+		return "по дате публикации"
+	case SortSalary:
+		return "по зарплате"
+	case SortCompany:
+		return "по компании"
+	default:
+		return string(m)
+	}
+}
+
+// ParseSortMode разбирает режим сортировки по имени или номеру пункта меню (1..4)
+func ParseSortMode(s string) (SortMode, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, mode := range SortModes {
+		if s == string(mode) || s == fmt.Sprint(i+1) {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort mode %q (available: relevance, date, salary, company)", s)
+}
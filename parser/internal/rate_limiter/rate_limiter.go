@@ -66,7 +66,8 @@ func (rl *ChannelRateLimiter) run(rate time.Duration) {
 }
 
 // метод rate limiter, ожидание, пока не будет доступен токен для чтения
-func (rl *ChannelRateLimiter) Wait() error {
+// отмена ctx (вызывающий ушёл, истёк дедлайн) прерывает ожидание сразу, токен при этом не расходуется
+func (rl *ChannelRateLimiter) Wait(ctx context.Context) error {
 	// проверяем, что rate limiter - не остановлен
 	// делаем это из-под мьютекса (конкурентный доступ)
 	rl.mu.RLock()
@@ -78,6 +79,9 @@ func (rl *ChannelRateLimiter) Wait() error {
 	}
 
 	select {
+	// проверяем отмену контекста вызывающего
+	case <-ctx.Done():
+		return ctx.Err()
 	// проверяем отмену контекста
 	case <-rl.ctx.Done():
 		return errors.New("rate limiter stopped")
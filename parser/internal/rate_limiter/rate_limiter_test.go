@@ -0,0 +1,81 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChannelRateLimiterWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+		stop    bool
+		wantErr error
+	}{
+		{
+			name: "token arrives",
+			rate: 10 * time.Millisecond,
+			ctx:  func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+		},
+		{
+			name: "cancelled caller returns right away",
+			rate: time.Hour,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "caller deadline interrupts the wait",
+			rate: time.Hour,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "stopped limiter",
+			rate: time.Hour,
+			ctx:  func() (context.Context, context.CancelFunc) { return context.WithCancel(context.Background()) },
+			stop: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewChannelRateLimiter(tt.rate)
+			defer limiter.Stop()
+			if tt.stop {
+				limiter.Stop()
+			}
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			start := time.Now()
+			err := limiter.Wait(ctx)
+			elapsed := time.Since(start)
+
+			switch {
+			case tt.stop:
+				if err == nil {
+					t.Errorf("stopped limiter returned a token")
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Errorf("unexpected err: %v", err)
+			}
+			if elapsed > time.Second {
+				t.Errorf("Wait blocked for %v", elapsed)
+			}
+		})
+	}
+}
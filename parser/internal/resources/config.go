@@ -0,0 +1,37 @@
+package resources
+
+import "time"
+
+// Config - конфигурация контроля ресурсов (пороги задаются для повышенной и критической нагрузки)
+type Config struct {
+	Enabled             bool          `yaml:"enabled"`
+	SampleInterval      time.Duration `yaml:"sample_interval"`       // как часто снимаются показатели процесса
+	ElevatedMemoryRatio float64       `yaml:"elevated_memory_ratio"` // доля от max_memory_usage_mb кэшей, с которой нагрузка повышенная
+	CriticalMemoryRatio float64       `yaml:"critical_memory_ratio"` // доля от max_memory_usage_mb кэшей, с которой нагрузка критическая
+	ElevatedGoroutines  int           `yaml:"elevated_goroutines"`   // количество горутин для повышенной нагрузки (0 - не учитывать)
+	CriticalGoroutines  int           `yaml:"critical_goroutines"`   // количество горутин для критической нагрузки (0 - не учитывать)
+	ElevatedCPU         float64       `yaml:"elevated_cpu"`          // загрузка CPU процессом (доля от GOMAXPROCS) для повышенной нагрузки (0 - не учитывать)
+	CriticalCPU         float64       `yaml:"critical_cpu"`          // загрузка CPU процессом для критической нагрузки (0 - не учитывать)
+	RecoverySamples     int           `yaml:"recovery_samples"`      // сколько замеров подряд ниже порога нужно, чтобы снизить уровень (защита от дребезга)
+	ElevatedCapacity    float64       `yaml:"elevated_capacity"`     // доля слотов глобального семафора, доступная при повышенной нагрузке (0 или 1 - не сужать)
+	CriticalCapacity    float64       `yaml:"critical_capacity"`     // доля слотов глобального семафора, доступная при критической нагрузке (0 или 1 - не сужать)
+	EvictFraction       float64       `yaml:"evict_fraction"`        // доля элементов кэшей, удаляемая на каждом замере при критической нагрузке
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Enabled:             true,
+		SampleInterval:      5 * time.Second,
+		ElevatedMemoryRatio: 0.7,
+		CriticalMemoryRatio: 0.9,
+		ElevatedGoroutines:  5000,
+		CriticalGoroutines:  20000,
+		ElevatedCPU:         0.8,
+		CriticalCPU:         0.95,
+		RecoverySamples:     3,
+		ElevatedCapacity:    0.5,
+		CriticalCapacity:    0.25,
+		EvictFraction:       0.25,
+	}
+}
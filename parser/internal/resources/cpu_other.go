@@ -0,0 +1,10 @@
+//go:build !unix
+
+package resources
+
+import "time"
+
+// на платформах без getrusage загрузка CPU не измеряется и в уровне нагрузки не учитывается
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
@@ -0,0 +1,244 @@
+// контроль ресурсов процесса: периодически снимает память кучи, количество горутин и загрузку CPU,
+// определяет уровень нагрузки и сообщает о нём подписчикам (менеджер парсеров ограничивает приём джоб,
+// сужает глобальный семафор и чистит кэши)
+package resources
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// Level - уровень нагрузки на ресурсы процесса
+type Level int
+
+const (
+	LevelNormal   Level = iota // ограничений нет
+	LevelElevated              // повышенная нагрузка: фоновые джобы не принимаются, семафор сужен
+	LevelCritical              // критическая нагрузка: принимаются только интерактивные джобы, кэши вычищаются
+)
+
+// String возвращает имя уровня нагрузки
+func (l Level) String() string {
+	switch l {
+	case LevelNormal:
+		return "normal"
+	case LevelElevated:
+		return "elevated"
+	case LevelCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// имя метрики runtime с объёмом занятой объектами кучи
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// Sample - замер показателей процесса
+type Sample struct {
+	At         time.Time
+	HeapBytes  uint64  // память, занятая объектами кучи
+	Goroutines int     // количество горутин
+	CPU        float64 // загрузка CPU процессом между замерами (доля от GOMAXPROCS, -1 - не измеряется)
+}
+
+// Status - текущее состояние контроля ресурсов
+type Status struct {
+	Enabled bool
+	Level   Level
+	Since   time.Time // с какого момента действует уровень
+	Sample  Sample    // последний замер
+	Reasons []string  // какие пороги превышены в последнем замере
+}
+
+// Handler - обработчик замера: prev - уровень до замера, status - состояние после
+type Handler func(prev Level, status Status)
+
+// Monitor - контроль ресурсов процесса
+type Monitor struct {
+	config         Config
+	maxMemoryBytes uint64 // 0 - память не учитывается
+
+	mu          sync.Mutex
+	status      Status
+	belowCount  int // сколько замеров подряд уровень ниже текущего
+	handlers    []Handler
+	lastCPUTime time.Duration
+	lastCPUAt   time.Time
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewMonitor создаёт контроль ресурсов, maxMemoryMB - лимит памяти (CachesConfig.MaxMemoryUsageMB, 0 - не учитывать)
+func NewMonitor(config Config, maxMemoryMB int) *Monitor {
+	return &Monitor{
+		config:         config,
+		maxMemoryBytes: uint64(max(maxMemoryMB, 0)) << 20,
+		status:         Status{Enabled: config.Enabled, Level: LevelNormal, Since: time.Now()},
+		stopChan:       make(chan struct{}),
+	}
+}
+
+// Subscribe добавляет обработчик, который вызывается после каждого замера
+func (m *Monitor) Subscribe(handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+}
+
+// Level возвращает текущий уровень нагрузки
+func (m *Monitor) Level() Level {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status.Level
+}
+
+// Status возвращает текущее состояние контроля ресурсов
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := m.status
+	status.Reasons = append([]string(nil), m.status.Reasons...)
+	return status
+}
+
+// Start запускает периодические замеры (если контроль ресурсов включён)
+func (m *Monitor) Start() {
+	if !m.config.Enabled || m.config.SampleInterval <= 0 {
+		return
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.config.SampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.Apply(m.sample())
+			case <-m.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop останавливает замеры
+func (m *Monitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+	})
+	m.wg.Wait()
+}
+
+// Apply учитывает замер: пересчитывает уровень нагрузки и вызывает обработчики
+// уровень повышается сразу, а понижается только после RecoverySamples замеров подряд ниже текущего уровня
+func (m *Monitor) Apply(sample Sample) {
+	level, reasons := m.evaluate(sample)
+
+	m.mu.Lock()
+	prev := m.status.Level
+	switch {
+	case level > prev:
+		m.setLevel(level, sample.At)
+	case level < prev:
+		m.belowCount++
+		if m.belowCount >= max(m.config.RecoverySamples, 1) {
+			m.setLevel(level, sample.At)
+		}
+	default:
+		m.belowCount = 0
+	}
+	m.status.Sample = sample
+	m.status.Reasons = reasons
+	status := m.status
+	handlers := append([]Handler(nil), m.handlers...)
+	m.mu.Unlock()
+
+	if status.Level != prev {
+		fmt.Printf("🧮 Уровень нагрузки на ресурсы: %s -> %s %v\n", prev, status.Level, reasons)
+	}
+
+	for _, handler := range handlers {
+		handler(prev, status)
+	}
+}
+
+// метод смены уровня (вызывается под мьютексом)
+func (m *Monitor) setLevel(level Level, at time.Time) {
+	m.status.Level = level
+	m.status.Since = at
+	m.belowCount = 0
+}
+
+// метод определения уровня нагрузки по замеру: берётся худший из показателей
+func (m *Monitor) evaluate(sample Sample) (Level, []string) {
+	level := LevelNormal
+	var reasons []string
+
+	check := func(name string, value, elevated, critical float64, format func(float64) string) {
+		switch {
+		case critical > 0 && value >= critical:
+			level = max(level, LevelCritical)
+			reasons = append(reasons, fmt.Sprintf("%s %s >= %s", name, format(value), format(critical)))
+		case elevated > 0 && value >= elevated:
+			level = max(level, LevelElevated)
+			reasons = append(reasons, fmt.Sprintf("%s %s >= %s", name, format(value), format(elevated)))
+		}
+	}
+
+	if m.maxMemoryBytes > 0 {
+		limit := float64(m.maxMemoryBytes)
+		check("memory", float64(sample.HeapBytes), m.config.ElevatedMemoryRatio*limit, m.config.CriticalMemoryRatio*limit, formatMB)
+	}
+	check("goroutines", float64(sample.Goroutines), float64(m.config.ElevatedGoroutines), float64(m.config.CriticalGoroutines), formatCount)
+	if sample.CPU >= 0 {
+		check("cpu", sample.CPU, m.config.ElevatedCPU, m.config.CriticalCPU, formatPercent)
+	}
+
+	return level, reasons
+}
+
+// метод снятия показателей процесса
+func (m *Monitor) sample() Sample {
+	now := time.Now()
+
+	heap := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(heap)
+	var heapBytes uint64
+	if heap[0].Value.Kind() == metrics.KindUint64 {
+		heapBytes = heap[0].Value.Uint64()
+	}
+
+	cpu := -1.0
+	if cpuTime, ok := processCPUTime(); ok {
+		m.mu.Lock()
+		if !m.lastCPUAt.IsZero() {
+			if wall := now.Sub(m.lastCPUAt); wall > 0 {
+				cpu = float64(cpuTime-m.lastCPUTime) / (float64(wall) * float64(runtime.GOMAXPROCS(0)))
+			}
+		}
+		m.lastCPUTime, m.lastCPUAt = cpuTime, now
+		m.mu.Unlock()
+	}
+
+	return Sample{
+		At:         now,
+		HeapBytes:  heapBytes,
+		Goroutines: runtime.NumGoroutine(),
+		CPU:        cpu,
+	}
+}
+
+func formatMB(value float64) string      { return fmt.Sprintf("%.0fMB", value/(1<<20)) }
+func formatCount(value float64) string   { return fmt.Sprintf("%.0f", value) }
+func formatPercent(value float64) string { return fmt.Sprintf("%.0f%%", value*100) }
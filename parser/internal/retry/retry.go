@@ -0,0 +1,205 @@
+// политика повторных запросов к внешним источникам:
+// экспоненциальная пауза с джиттером, учёт заголовка Retry-After и классификация повторяемых ошибок
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy - политика повторных запросов, построенная на основе конфига
+type Policy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	multiplier     float64
+	jitter         float64
+	maxRetryAfter  time.Duration
+	statusCodes    map[int]struct{}
+	networkErrors  map[string]struct{}
+}
+
+// конструктор политики повторов, незаданные значения заменяются значениями по умолчанию
+func NewPolicy(config RetryConfig) *Policy {
+	defaults := DefaultRetryConfig()
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = defaults.Multiplier
+	}
+	if config.Jitter < 0 || config.Jitter > 1 {
+		config.Jitter = defaults.Jitter
+	}
+	if config.MaxRetryAfter <= 0 {
+		config.MaxRetryAfter = defaults.MaxRetryAfter
+	}
+	if config.RetryableStatusCodes == nil {
+		config.RetryableStatusCodes = defaults.RetryableStatusCodes
+	}
+	if config.RetryableNetworkErrors == nil {
+		config.RetryableNetworkErrors = defaults.RetryableNetworkErrors
+	}
+
+	policy := &Policy{
+		maxAttempts:    config.MaxAttempts,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		multiplier:     config.Multiplier,
+		jitter:         config.Jitter,
+		maxRetryAfter:  config.MaxRetryAfter,
+		statusCodes:    make(map[int]struct{}, len(config.RetryableStatusCodes)),
+		networkErrors:  make(map[string]struct{}, len(config.RetryableNetworkErrors)),
+	}
+
+	for _, code := range config.RetryableStatusCodes {
+		policy.statusCodes[code] = struct{}{}
+	}
+	for _, kind := range config.RetryableNetworkErrors {
+		policy.networkErrors[strings.ToLower(kind)] = struct{}{}
+	}
+
+	return policy
+}
+
+// MaxAttempts возвращает максимальное количество попыток (включая первую)
+func (p *Policy) MaxAttempts() int {
+	return p.maxAttempts
+}
+
+// MaxRetryAfter возвращает максимальную паузу по Retry-After, которую политика готова выдержать
+func (p *Policy) MaxRetryAfter() time.Duration {
+	return p.maxRetryAfter
+}
+
+// Backoff возвращает паузу перед попыткой с номером attempt+1 (attempt - номер неудачной попытки, начиная с 1)
+func (p *Policy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	backoff := float64(p.initialBackoff) * math.Pow(p.multiplier, float64(attempt-1))
+	if backoff > float64(p.maxBackoff) {
+		backoff = float64(p.maxBackoff)
+	}
+
+	// добавляем случайный разброс в пределах [-jitter; +jitter] от паузы
+	if p.jitter > 0 {
+		delta := backoff * p.jitter
+		backoff = backoff - delta + rand.Float64()*2*delta
+	}
+
+	return time.Duration(backoff)
+}
+
+// IsRetryableStatus проверяет, нужно ли повторять запрос при данном HTTP статусе
+func (p *Policy) IsRetryableStatus(code int) bool {
+	_, ok := p.statusCodes[code]
+	return ok
+}
+
+// IsRetryableError проверяет, относится ли сетевая ошибка к повторяемым по конфигу
+// отмену контекста вызывающего проверяет вызывающий код (ctx.Err()), сюда приходят только ошибки транспорта
+func (p *Policy) IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	kind := classifyNetworkError(err)
+	if kind == "" {
+		return false
+	}
+
+	_, ok := p.networkErrors[kind]
+	return ok
+}
+
+// функция определения вида сетевой ошибки (пустая строка - ошибка не сетевая или неизвестного вида)
+func classifyNetworkError(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return NetErrDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return NetErrConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return NetErrConnectionReset
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return NetErrEOF
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return NetErrTimeout
+	}
+	return ""
+}
+
+// ParseRetryAfter разбирает значение заголовка Retry-After (количество секунд или HTTP-дата)
+// возвращает паузу и флаг, удалось ли разобрать заголовок
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	// формат "Retry-After: 120"
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	// формат "Retry-After: Wed, 21 Oct 2015 07:28:00 GMT"
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// Sleep ждёт заданную паузу с учётом отмены контекста
+func Sleep(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FitsDeadline проверяет, успеем ли мы выдержать паузу до истечения дедлайна контекста
+func FitsDeadline(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return true
+	}
+	return time.Until(deadline) > delay
+}
@@ -0,0 +1,43 @@
+package retry
+
+import "time"
+
+// виды сетевых ошибок, которые можно указать в конфиге как повторяемые
+const (
+	NetErrTimeout           = "timeout"            // таймаут соединения/чтения (не таймаут контекста вызывающего!)
+	NetErrConnectionRefused = "connection_refused" // сервер отклонил соединение
+	NetErrConnectionReset   = "connection_reset"   // соединение сброшено сервером
+	NetErrEOF               = "eof"                // соединение закрыто до получения полного ответа
+	NetErrDNS               = "dns"                // ошибка разрешения имени
+)
+
+// RetryConfig - конфигурация политики повторных запросов
+type RetryConfig struct {
+	MaxAttempts            int           `yaml:"max_attempts"`             // максимальное количество попыток (включая первую)
+	InitialBackoff         time.Duration `yaml:"initial_backoff"`          // пауза перед второй попыткой
+	MaxBackoff             time.Duration `yaml:"max_backoff"`              // верхняя граница паузы между попытками
+	Multiplier             float64       `yaml:"multiplier"`               // множитель экспоненциального роста паузы
+	Jitter                 float64       `yaml:"jitter"`                   // доля случайного разброса паузы (0..1), чтобы клиенты не ретраили синхронно
+	MaxRetryAfter          time.Duration `yaml:"max_retry_after"`          // если сервер просит подождать (Retry-After) дольше - не ретраим
+	RetryableStatusCodes   []int         `yaml:"retryable_status_codes"`   // HTTP статусы, при которых запрос повторяется
+	RetryableNetworkErrors []string      `yaml:"retryable_network_errors"` // виды сетевых ошибок, при которых запрос повторяется
+}
+
+// DefaultRetryConfig возвращает конфигурацию повторов по умолчанию
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:          3,
+		InitialBackoff:       500 * time.Millisecond,
+		MaxBackoff:           10 * time.Second,
+		Multiplier:           2,
+		Jitter:               0.2,
+		MaxRetryAfter:        30 * time.Second,
+		RetryableStatusCodes: []int{429, 502, 503, 504},
+		RetryableNetworkErrors: []string{
+			NetErrTimeout,
+			NetErrConnectionRefused,
+			NetErrConnectionReset,
+			NetErrEOF,
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package savedsearch
+
+import "time"
+
+// Config - конфигурация сохранённых поисков
+type Config struct {
+	Enabled       bool          `yaml:"enabled"`        // запуск сохранённых поисков по расписанию (при false - только из меню)
+	CheckInterval time.Duration `yaml:"check_interval"` // как часто планировщик проверяет, каким поискам пора запускаться
+	MaxSearches   int           `yaml:"max_searches"`   // максимальное количество сохранённых поисков
+	SeenLimit     int           `yaml:"seen_limit"`     // сколько последних увиденных вакансий помнит каждый поиск
+	StoragePath   string        `yaml:"storage_path"`   // файл, в котором поиски переживают перезапуск ("" - только в памяти)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Enabled:       true,
+		CheckInterval: time.Minute,
+		MaxSearches:   50,
+		SeenLimit:     5000,
+	}
+}
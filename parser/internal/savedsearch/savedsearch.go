@@ -0,0 +1,383 @@
+// сохранённые поиски: запрос, расписание и источники; планировщик запускает поиски по расписанию,
+// сравнивает найденные вакансии с уже увиденными и сообщает только о новых
+package savedsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"parser/internal/domain/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// таймаут одного запуска сохранённого поиска
+const runTimeout = 2 * time.Minute
+
+var (
+	ErrExists       = errors.New("savedsearch: search with this name already exists")
+	ErrNotFound     = errors.New("savedsearch: search not found")
+	ErrLimitReached = errors.New("savedsearch: saved searches limit reached")
+)
+
+// Runner - функция выполнения сохранённого поиска name в заданных источниках
+type Runner func(ctx context.Context, name string, params models.SearchParams, sources []string) ([]models.SearchVacanciesResult, error)
+
+// Handler - обработчик результатов запусков сохранённых поисков
+type Handler func(Result)
+
+// Posting - краткие данные найденной вакансии
+type Posting struct {
+	Source  string `json:"source"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Company string `json:"company"`
+	Salary  string `json:"salary,omitempty"`
+	Area    string `json:"area,omitempty"`
+	URL     string `json:"url"`
+}
+
+// Search - сохранённый поиск
+type Search struct {
+	Name      string              `json:"name"`
+	Params    models.SearchParams `json:"params"`
+	Schedule  Schedule            `json:"schedule"`
+	Sources   []string            `json:"sources,omitempty"` // пусто - все источники
+	CreatedAt time.Time           `json:"created_at"`
+	NextRun   time.Time           `json:"next_run"`
+	LastRun   time.Time           `json:"last_run,omitempty"`
+	LastError string              `json:"last_error,omitempty"` // ошибка последнего запуска (ни один источник не ответил)
+	Runs      int                 `json:"runs"`                 // успешных запусков (первый только запоминает найденные вакансии)
+	Unread    []Posting           `json:"unread,omitempty"`     // новые вакансии, которые ещё не просмотрены в меню
+	Seen      []string            `json:"seen,omitempty"`       // увиденные вакансии (источник_ID), от старых к новым
+}
+
+// Result - результат запуска сохранённого поиска
+type Result struct {
+	Name     string
+	At       time.Time
+	Baseline bool      // первый запуск: найденные вакансии запомнены, новыми не считаются
+	Found    int       // сколько вакансий вернули источники
+	New      []Posting // вакансии, которых не было в предыдущих запусках
+	Errors   []string  // ошибки отдельных источников
+}
+
+// Store - сохранённые поиски и их планировщик
+type Store struct {
+	config Config
+	run    Runner
+
+	mu       sync.Mutex
+	searches map[string]*Search // ключ - имя поиска
+	handlers []Handler
+
+	runMu    sync.Mutex // запуски не пересекаются: у источников и так общий rate limiter
+	saveMu   sync.Mutex // запись файла не пересекается
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New создаёт сохранённые поиски и загружает их из файла (если он задан в конфиге)
+func New(config Config, run Runner) (*Store, error) {
+	s := &Store{
+		config:   config,
+		run:      run,
+		searches: make(map[string]*Search),
+		stopChan: make(chan struct{}),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Subscribe добавляет обработчик результатов запусков
+func (s *Store) Subscribe(handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// Add сохраняет поиск; первый запуск назначается сразу, чтобы запомнить текущие вакансии
+func (s *Store) Add(name string, params models.SearchParams, schedule Schedule, sources []string) (Search, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Search{}, fmt.Errorf("savedsearch: name must not be empty")
+	}
+
+	now := time.Now()
+	search := &Search{
+		Name:      name,
+		Params:    params,
+		Schedule:  schedule,
+		Sources:   append([]string(nil), sources...),
+		CreatedAt: now,
+		NextRun:   now,
+	}
+
+	s.mu.Lock()
+	if _, ok := s.searches[name]; ok {
+		s.mu.Unlock()
+		return Search{}, ErrExists
+	}
+	if s.config.MaxSearches > 0 && len(s.searches) >= s.config.MaxSearches {
+		s.mu.Unlock()
+		return Search{}, fmt.Errorf("%w (%d)", ErrLimitReached, s.config.MaxSearches)
+	}
+	s.searches[name] = search
+	copied := search.clone()
+	s.mu.Unlock()
+
+	s.persist()
+	return copied, nil
+}
+
+// Remove удаляет сохранённый поиск
+func (s *Store) Remove(name string) error {
+	s.mu.Lock()
+	if _, ok := s.searches[name]; !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	delete(s.searches, name)
+	s.mu.Unlock()
+
+	s.persist()
+	return nil
+}
+
+// List возвращает копию списка, отсортированную по времени создания
+func (s *Store) List() []Search {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	searches := make([]Search, 0, len(s.searches))
+	for _, search := range s.searches {
+		searches = append(searches, search.clone())
+	}
+	sort.Slice(searches, func(i, j int) bool {
+		return searches[i].CreatedAt.Before(searches[j].CreatedAt)
+	})
+	return searches
+}
+
+// TakeUnread возвращает непросмотренные новые вакансии поиска и отмечает их просмотренными
+func (s *Store) TakeUnread(name string) ([]Posting, error) {
+	s.mu.Lock()
+	search, ok := s.searches[name]
+	if !ok {
+		s.mu.Unlock()
+		return nil, ErrNotFound
+	}
+	unread := search.Unread
+	search.Unread = nil
+	s.mu.Unlock()
+
+	if len(unread) > 0 {
+		s.persist()
+	}
+	return unread, nil
+}
+
+// Start запускает планировщик: раз в CheckInterval запускаются поиски, которым пора
+// Stop отменяет идущий запуск (в том числе паузы между повторами его джоб)
+func (s *Store) Start() {
+	if s.config.CheckInterval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		<-s.stopChan
+		cancel()
+	}()
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.config.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.RunDue(ctx)
+			case <-s.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop останавливает планировщик
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+	s.wg.Wait()
+}
+
+// RunDue запускает поиски, время запуска которых наступило (последовательно, в порядке создания)
+func (s *Store) RunDue(ctx context.Context) []Result {
+	var results []Result
+	now := time.Now()
+	for _, search := range s.List() {
+		select {
+		case <-s.stopChan:
+			return results
+		default:
+		}
+		if ctx.Err() != nil {
+			return results
+		}
+		if search.NextRun.After(now) {
+			continue
+		}
+
+		result, err := s.Run(ctx, search.Name)
+		if err != nil {
+			fmt.Printf("⚠️  Сохранённый поиск «%s» не выполнен: %v\n", search.Name, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Run запускает сохранённый поиск вне расписания; следующий запуск отсчитывается от этого
+func (s *Store) Run(ctx context.Context, name string) (Result, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	s.mu.Lock()
+	search, ok := s.searches[name]
+	if !ok {
+		s.mu.Unlock()
+		return Result{}, ErrNotFound
+	}
+	params, sources := search.Params, append([]string(nil), search.Sources...)
+	s.mu.Unlock()
+
+	runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+	results, err := s.run(runCtx, name, params, sources)
+	now := time.Now()
+
+	result := Result{Name: name, At: now}
+	var successful []models.SearchVacanciesResult
+	for _, r := range results {
+		if r.Error != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", r.ParserName, r.Error))
+			continue
+		}
+		successful = append(successful, r)
+	}
+	if err == nil && len(successful) == 0 {
+		err = fmt.Errorf("no source responded: %s", strings.Join(result.Errors, "; "))
+	}
+
+	s.mu.Lock()
+	search, ok = s.searches[name]
+	if !ok {
+		// поиск удалили, пока шёл запуск
+		s.mu.Unlock()
+		return Result{}, ErrNotFound
+	}
+	search.LastRun = now
+	search.NextRun = search.Schedule.Next(now)
+	if err != nil {
+		search.LastError = err.Error()
+		s.mu.Unlock()
+		s.persist()
+		return Result{}, err
+	}
+
+	search.LastError = ""
+	result.Baseline = search.Runs == 0
+	result.New = search.remember(successful, result.Baseline, s.config.SeenLimit)
+	for _, r := range successful {
+		result.Found += len(r.Vacancies)
+	}
+	search.Runs++
+	handlers := append([]Handler(nil), s.handlers...)
+	s.mu.Unlock()
+
+	s.persist()
+
+	// обработчики вызываются без мьютекса: им можно обращаться к поискам
+	for _, handler := range handlers {
+		handler(result)
+	}
+	return result, nil
+}
+
+// метод запоминания найденных вакансий, возвращает новые (при первом запуске новыми не считаются)
+// из увиденных и непросмотренных хранятся последние limit записей
+func (search *Search) remember(results []models.SearchVacanciesResult, baseline bool, limit int) []Posting {
+	seen := make(map[string]struct{}, len(search.Seen))
+	for _, key := range search.Seen {
+		seen[key] = struct{}{}
+	}
+
+	var fresh []Posting
+	for _, result := range results {
+		for _, vacancy := range result.Vacancies {
+			key := vacancyKey(result.ParserName, vacancy.ID)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			search.Seen = append(search.Seen, key)
+			if !baseline {
+				fresh = append(fresh, newPosting(result.ParserName, vacancy))
+			}
+		}
+	}
+
+	search.Unread = append(search.Unread, fresh...)
+	if limit > 0 {
+		if len(search.Seen) > limit {
+			search.Seen = append([]string(nil), search.Seen[len(search.Seen)-limit:]...)
+		}
+		if len(search.Unread) > limit {
+			search.Unread = append([]Posting(nil), search.Unread[len(search.Unread)-limit:]...)
+		}
+	}
+	return fresh
+}
+
+// функция получения кратких данных вакансии
+func newPosting(source string, vacancy models.Vacancy) Posting {
+	posting := Posting{
+		Source:  source,
+		ID:      vacancy.ID,
+		Name:    vacancy.Job,
+		Company: vacancy.Company,
+		Area:    vacancy.Area,
+		URL:     vacancy.URL,
+	}
+	if vacancy.Salary != nil {
+		posting.Salary = *vacancy.Salary
+	}
+	return posting
+}
+
+// функция формирования ключа вакансии: ID уникальны только в пределах источника
+func vacancyKey(source, vacancyID string) string {
+	return source + "_" + vacancyID
+}
+
+// метод копирования поиска (наружу отдаём копии, чтобы не держать мьютекс)
+func (search *Search) clone() Search {
+	copied := *search
+	copied.Sources = append([]string(nil), search.Sources...)
+	copied.Params.Sources = append([]string(nil), search.Params.Sources...)
+	copied.Unread = append([]Posting(nil), search.Unread...)
+	copied.Seen = append([]string(nil), search.Seen...)
+	return copied
+}
@@ -0,0 +1,87 @@
+package savedsearch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// минимальный интервал запуска: чаще сохранённый поиск только нагружает источники
+const minEvery = time.Minute
+
+// Schedule - расписание сохранённого поиска: через равные интервалы ("every 2h") или ежедневно в заданное время ("daily 09:00")
+type Schedule struct {
+	Every time.Duration // интервал между запусками (0 - ежедневно в At)
+	At    time.Duration // время запуска от начала суток (для ежедневного расписания)
+}
+
+// ParseSchedule разбирает расписание: "every <длительность>" или "daily ЧЧ:ММ"
+func ParseSchedule(s string) (Schedule, error) {
+	kind, value, _ := strings.Cut(strings.TrimSpace(strings.ToLower(s)), " ")
+	value = strings.TrimSpace(value)
+
+	switch kind {
+	case "every":
+		every, err := time.ParseDuration(value)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("savedsearch: schedule %q: %w", s, err)
+		}
+		if every < minEvery {
+			return Schedule{}, fmt.Errorf("savedsearch: schedule %q: interval must be at least %v", s, minEvery)
+		}
+		return Schedule{Every: every}, nil
+	case "daily":
+		at, err := time.Parse("15:04", value)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("savedsearch: schedule %q: expected time as HH:MM", s)
+		}
+		return Schedule{At: time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute}, nil
+	default:
+		return Schedule{}, fmt.Errorf("savedsearch: schedule %q: expected \"every <duration>\" or \"daily HH:MM\"", s)
+	}
+}
+
+// Next возвращает время следующего запуска после from (в локальном времени)
+func (s Schedule) Next(from time.Time) time.Time {
+	if s.Every > 0 {
+		return from.Add(s.Every)
+	}
+
+	year, month, day := from.Date()
+	next := time.Date(year, month, day, 0, 0, 0, 0, from.Location()).Add(s.At)
+	if !next.After(from) {
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, from.Location()).Add(s.At)
+	}
+	return next
+}
+
+// String возвращает расписание в том же виде, в котором его принимает ParseSchedule
+func (s Schedule) String() string {
+	if s.Every > 0 {
+		// 2h0m0s -> 2h, 30m0s -> 30m
+		every := s.Every.String()
+		if strings.HasSuffix(every, "m0s") {
+			every = strings.TrimSuffix(every, "0s")
+		}
+		if strings.HasSuffix(every, "h0m") {
+			every = strings.TrimSuffix(every, "0m")
+		}
+		return "every " + every
+	}
+	return fmt.Sprintf("daily %02d:%02d", int(s.At.Hours()), int(s.At.Minutes())%60)
+}
+
+// MarshalText сохраняет расписание строкой (в файле сохранённых поисков)
+func (s Schedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText читает расписание из строки
+func (s *Schedule) UnmarshalText(text []byte) error {
+	parsed, err := ParseSchedule(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
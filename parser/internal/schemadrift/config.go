@@ -0,0 +1,27 @@
+package schemadrift
+
+import "time"
+
+// Config - конфигурация обнаружения дрейфа схемы для одного парсера
+type Config struct {
+	Enabled              bool          `yaml:"enabled"`
+	SampleInterval       time.Duration `yaml:"sample_interval"`         // как часто ответ эндпоинта проверяется на дрейф (первый ответ проверяется всегда)
+	MaxItems             int           `yaml:"max_items"`               // сколько элементов каждого массива проверяется (ограничение нагрузки на больших страницах)
+	MaxFindings          int           `yaml:"max_findings"`            // максимальное количество находок каждого вида в отчёте
+	BaselineSamples      int           `yaml:"baseline_samples"`        // по скольким первым ответам собираются "лишние" поля, которые источник отдаёт всегда (их не считаем новыми)
+	NewFieldTTL          time.Duration `yaml:"new_field_ttl"`           // через сколько новое поле пропадает из отчёта, если перестало встречаться в ответах
+	FailHealthOnCritical bool          `yaml:"fail_health_on_critical"` // считать парсер нездоровым, если пропали критичные поля
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Enabled:              true,
+		SampleInterval:       10 * time.Minute,
+		MaxItems:             20,
+		MaxFindings:          50,
+		BaselineSamples:      5,
+		NewFieldTTL:          24 * time.Hour,
+		FailHealthOnCritical: false,
+	}
+}
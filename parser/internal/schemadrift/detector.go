@@ -0,0 +1,301 @@
+package schemadrift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TypeChange - поле пришло с другим типом
+type TypeChange struct {
+	Path     string
+	Expected Kind
+	Actual   Kind
+	Critical bool
+}
+
+// Report - результат проверки ответов одного эндпоинта
+type Report struct {
+	Schema          string       // имя эндпоинта
+	CheckedAt       time.Time    // время последней проверки
+	Checks          int          // сколько ответов проверено
+	NewFields       []string     // неизвестные поля, появившиеся после сбора базовой линии (и ещё встречающиеся)
+	MissingFields   []string     // пропавшие обязательные поля
+	CriticalMissing []string     // пропавшие (или пришедшие null) критичные поля
+	TypeChanges     []TypeChange // поля, сменившие тип
+}
+
+// HasDrift сообщает, есть ли в отчёте хоть одна находка
+func (r Report) HasDrift() bool {
+	return len(r.NewFields) > 0 || len(r.MissingFields) > 0 || len(r.CriticalMissing) > 0 || len(r.TypeChanges) > 0
+}
+
+// HasCritical сообщает, пропали ли (или сменили тип) критичные поля
+func (r Report) HasCritical() bool {
+	if len(r.CriticalMissing) > 0 {
+		return true
+	}
+	for _, change := range r.TypeChanges {
+		if change.Critical {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary возвращает краткое описание находок в одну строку
+func (r Report) Summary() string {
+	var parts []string
+	if len(r.CriticalMissing) > 0 {
+		parts = append(parts, "нет критичных полей: "+strings.Join(r.CriticalMissing, ", "))
+	}
+	if len(r.MissingFields) > 0 {
+		parts = append(parts, "нет полей: "+strings.Join(r.MissingFields, ", "))
+	}
+	if len(r.TypeChanges) > 0 {
+		changes := make([]string, 0, len(r.TypeChanges))
+		for _, change := range r.TypeChanges {
+			changes = append(changes, fmt.Sprintf("%s (%s -> %s)", change.Path, change.Expected, change.Actual))
+		}
+		parts = append(parts, "сменился тип: "+strings.Join(changes, ", "))
+	}
+	if len(r.NewFields) > 0 {
+		parts = append(parts, "новые поля: "+strings.Join(r.NewFields, ", "))
+	}
+	if len(parts) == 0 {
+		return "схема не изменилась"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// находки одной проверки
+type findings struct {
+	unknown     map[string]struct{}
+	missing     map[string]bool // путь -> критичное ли поле
+	typeChanges map[string]TypeChange
+}
+
+// Detector - детектор дрейфа схемы одного эндпоинта
+type Detector struct {
+	schema *Schema
+	config Config
+
+	mu          sync.Mutex
+	lastSample  time.Time
+	baseline    map[string]struct{}  // неизвестные поля из первых baseline_samples ответов (их не считаем новыми)
+	newFields   map[string]time.Time // новые неизвестные поля -> когда последний раз встречались в ответе
+	report      Report
+	fingerprint string // отпечаток последнего отчёта, чтобы сообщать только об изменениях
+}
+
+// NewDetector создаёт детектор для схемы
+func NewDetector(schema *Schema, config Config) *Detector {
+	if config.MaxItems <= 0 {
+		config.MaxItems = DefaultConfig().MaxItems
+	}
+	if config.MaxFindings <= 0 {
+		config.MaxFindings = DefaultConfig().MaxFindings
+	}
+	if config.BaselineSamples <= 0 {
+		config.BaselineSamples = DefaultConfig().BaselineSamples
+	}
+	if config.NewFieldTTL <= 0 {
+		config.NewFieldTTL = DefaultConfig().NewFieldTTL
+	}
+
+	return &Detector{
+		schema:    schema,
+		config:    config,
+		baseline:  make(map[string]struct{}),
+		newFields: make(map[string]time.Time),
+		report:    Report{Schema: schema.Name},
+	}
+}
+
+// ShouldSample сообщает, нужно ли проверить очередной ответ (и резервирует проверку, чтобы параллельные запросы не проверялись все разом)
+func (d *Detector) ShouldSample(now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.lastSample.IsZero() && now.Sub(d.lastSample) < d.config.SampleInterval {
+		return false
+	}
+	d.lastSample = now
+	return true
+}
+
+// Check проверяет тело ответа на соответствие схеме
+// возвращает актуальный отчёт и признак того, что находки изменились с прошлой проверки
+// тело, которое не является JSON, не проверяется - такую ошибку вернёт сам разбор ответа
+func (d *Detector) Check(body []byte) (Report, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return d.Report(), false
+	}
+
+	found := &findings{
+		unknown:     make(map[string]struct{}),
+		missing:     make(map[string]bool),
+		typeChanges: make(map[string]TypeChange),
+	}
+	compare(value, d.schema.Root, "", found, d.config.MaxItems)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if d.report.Checks < d.config.BaselineSamples {
+		// первые проверки - запоминаем, какие "лишние" поля источник отдаёт (необязательные поля встречаются не в каждом ответе)
+		for path := range found.unknown {
+			d.baseline[path] = struct{}{}
+		}
+	} else {
+		for path := range found.unknown {
+			if _, known := d.baseline[path]; known {
+				continue
+			}
+			if _, seen := d.newFields[path]; seen || len(d.newFields) < d.config.MaxFindings {
+				d.newFields[path] = now
+			}
+		}
+	}
+	// новые поля, которые давно не встречались (источник откатил изменение), из отчёта убираем
+	for path, lastSeen := range d.newFields {
+		if now.Sub(lastSeen) > d.config.NewFieldTTL {
+			delete(d.newFields, path)
+		}
+	}
+
+	report := Report{
+		Schema:    d.schema.Name,
+		CheckedAt: now,
+		Checks:    d.report.Checks + 1,
+		NewFields: limit(sortedKeys(d.newFields), d.config.MaxFindings),
+	}
+	for _, path := range sortedKeys(found.missing) {
+		if found.missing[path] {
+			report.CriticalMissing = append(report.CriticalMissing, path)
+		} else {
+			report.MissingFields = append(report.MissingFields, path)
+		}
+	}
+	report.CriticalMissing = limit(report.CriticalMissing, d.config.MaxFindings)
+	report.MissingFields = limit(report.MissingFields, d.config.MaxFindings)
+	for _, path := range sortedKeys(found.typeChanges) {
+		if len(report.TypeChanges) == d.config.MaxFindings {
+			break
+		}
+		report.TypeChanges = append(report.TypeChanges, found.typeChanges[path])
+	}
+
+	d.report = report
+	fingerprint := report.Summary()
+	changed := fingerprint != d.fingerprint
+	d.fingerprint = fingerprint
+
+	return report, changed
+}
+
+// Report возвращает последний отчёт
+func (d *Detector) Report() Report {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.report
+}
+
+// функция рекурсивного сравнения JSON значения с ожидаемым описанием
+func compare(value interface{}, field *Field, path string, found *findings, maxItems int) {
+	// null допустим для любого поля (HH.ru, например, отдаёт salary: null) - критичные null поля проверяет родительский объект
+	if field.Kind == KindAny || value == nil {
+		return
+	}
+
+	actual := kindOf(value)
+	if actual != field.Kind {
+		found.typeChanges[displayPath(path)] = TypeChange{Path: displayPath(path), Expected: field.Kind, Actual: actual, Critical: field.Critical}
+		return
+	}
+
+	switch field.Kind {
+	case KindObject:
+		object := value.(map[string]interface{})
+		for name, expected := range field.Fields {
+			fieldValue, ok := object[name]
+			if !ok || (fieldValue == nil && expected.Critical) {
+				if expected.Required {
+					found.missing[joinPath(path, name)] = expected.Critical
+				}
+				continue
+			}
+			compare(fieldValue, expected, joinPath(path, name), found, maxItems)
+		}
+		for name := range object {
+			if _, known := field.Fields[name]; !known {
+				found.unknown[joinPath(path, name)] = struct{}{}
+			}
+		}
+	case KindArray:
+		items := value.([]interface{})
+		for i := 0; i < len(items) && i < maxItems; i++ {
+			compare(items[i], field.Items, path+"[]", found, maxItems)
+		}
+	}
+}
+
+// функция определения типа декодированного JSON значения
+func kindOf(value interface{}) Kind {
+	switch value.(type) {
+	case map[string]interface{}:
+		return KindObject
+	case []interface{}:
+		return KindArray
+	case string:
+		return KindString
+	case json.Number, float64:
+		return KindNumber
+	case bool:
+		return KindBool
+	default:
+		return KindAny
+	}
+}
+
+// функции формирования пути поля вида items[].salary.from
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return path
+}
+
+// функция получения отсортированных ключей мапы
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// функция ограничения длины списка находок
+func limit(values []string, maxLen int) []string {
+	if len(values) > maxLen {
+		return values[:maxLen]
+	}
+	return values
+}
@@ -0,0 +1,104 @@
+package schemadrift
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status - состояние схем всех эндпоинтов одного парсера (попадает в ParserStatus)
+type Status struct {
+	Reports     []Report // отчёты по эндпоинтам (отсортированы по имени)
+	FailsHealth bool     // пропали критичные поля и конфиг требует считать парсер нездоровым
+}
+
+// HasDrift сообщает, есть ли находки хотя бы по одному эндпоинту
+func (s Status) HasDrift() bool {
+	for _, report := range s.Reports {
+		if report.HasDrift() {
+			return true
+		}
+	}
+	return false
+}
+
+// Monitor - набор детекторов дрейфа для эндпоинтов одного парсера
+type Monitor struct {
+	name   string // имя парсера (для логов)
+	config Config
+
+	mu        sync.Mutex
+	detectors map[string]*Detector // имя схемы -> детектор
+}
+
+// NewMonitor создаёт монитор дрейфа схем для парсера
+func NewMonitor(name string, config Config) *Monitor {
+	return &Monitor{
+		name:      name,
+		config:    config,
+		detectors: make(map[string]*Detector),
+	}
+}
+
+// метод получения (или создания) детектора для схемы
+func (m *Monitor) detector(schema *Schema) *Detector {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	detector, ok := m.detectors[schema.Name]
+	if !ok {
+		detector = NewDetector(schema, m.config)
+		m.detectors[schema.Name] = detector
+	}
+	return detector
+}
+
+// ShouldSample сообщает, нужно ли проверять очередной ответ эндпоинта
+func (m *Monitor) ShouldSample(schema *Schema) bool {
+	if m == nil || !m.config.Enabled || schema == nil {
+		return false
+	}
+	return m.detector(schema).ShouldSample(time.Now())
+}
+
+// Check проверяет тело ответа эндпоинта; об изменившихся находках сообщает в лог
+func (m *Monitor) Check(schema *Schema, body []byte) Report {
+	report, changed := m.detector(schema).Check(body)
+	if changed {
+		if report.HasDrift() {
+			fmt.Printf("⚠️  [%s] дрейф схемы ответа (%s): %s\n", m.name, schema.Name, report.Summary())
+		} else if report.Checks > 1 {
+			fmt.Printf("✅ [%s] схема ответа (%s) снова совпадает с ожидаемой\n", m.name, schema.Name)
+		}
+	}
+	return report
+}
+
+// Status возвращает состояние схем всех проверенных эндпоинтов
+func (m *Monitor) Status() Status {
+	if m == nil {
+		return Status{}
+	}
+
+	m.mu.Lock()
+	detectors := make([]*Detector, 0, len(m.detectors))
+	for _, detector := range m.detectors {
+		detectors = append(detectors, detector)
+	}
+	m.mu.Unlock()
+
+	var status Status
+	for _, detector := range detectors {
+		report := detector.Report()
+		status.Reports = append(status.Reports, report)
+		if m.config.FailHealthOnCritical && report.HasCritical() {
+			status.FailsHealth = true
+		}
+	}
+	sort.Slice(status.Reports, func(i, j int) bool {
+		return status.Reports[i].Schema < status.Reports[j].Schema
+	})
+
+	return status
+}
@@ -0,0 +1,113 @@
+// обнаружение "дрейфа" схемы ответов источников: новые неизвестные поля, пропавшие обязательные поля, смена типов
+// ожидаемая схема строится из моделей ответа (json теги + тег drift:"required" / drift:"critical")
+package schemadrift
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Kind - тип JSON значения
+type Kind string
+
+const (
+	KindObject Kind = "object"
+	KindArray  Kind = "array"
+	KindString Kind = "string"
+	KindNumber Kind = "number"
+	KindBool   Kind = "bool"
+	KindAny    Kind = "any" // interface{} и map - содержимое не проверяем
+)
+
+// Field - ожидаемое описание JSON значения
+type Field struct {
+	Kind     Kind
+	Required bool              // поле должно присутствовать в объекте
+	Critical bool              // без поля парсер не может выдать осмысленный результат (ID, название и т.п.)
+	Fields   map[string]*Field // поля объекта (для KindObject)
+	Items    *Field            // элементы массива (для KindArray)
+}
+
+// Schema - ожидаемая схема ответа одного эндпоинта источника
+type Schema struct {
+	Name string // имя эндпоинта (search, details), под этим именем находки попадают в статус парсера
+	Root *Field
+}
+
+// FromModel строит схему по модели ответа
+// поля без json тега (или с тегом "-") не входят в схему; обязательность задаётся тегом drift:"required" или drift:"critical"
+func FromModel(name string, model interface{}) *Schema {
+	return &Schema{
+		Name: name,
+		Root: fieldFromType(reflect.TypeOf(model), map[reflect.Type]bool{}),
+	}
+}
+
+// функция построения описания поля по типу Go
+// visiting защищает от бесконечной рекурсии на самоссылающихся типах
+func fieldFromType(t reflect.Type, visiting map[reflect.Type]bool) *Field {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Field{Kind: KindString}
+	case reflect.Bool:
+		return &Field{Kind: KindBool}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return &Field{Kind: KindNumber}
+	case reflect.Slice, reflect.Array:
+		return &Field{Kind: KindArray, Items: fieldFromType(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Field{Kind: KindAny}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		field := &Field{Kind: KindObject, Fields: make(map[string]*Field)}
+		collectStructFields(t, field.Fields, visiting)
+		return field
+	default:
+		return &Field{Kind: KindAny}
+	}
+}
+
+// функция сбора полей структуры (встроенные структуры без json тега раскрываются, как это делает encoding/json)
+func collectStructFields(t reflect.Type, fields map[string]*Field, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, hasTag := structField.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+
+		if structField.Anonymous && !hasTag {
+			embedded := structField.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				collectStructFields(embedded, fields, visiting)
+			}
+			continue
+		}
+		if !structField.IsExported() || name == "-" || !hasTag {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+
+		field := fieldFromType(structField.Type, visiting)
+		switch structField.Tag.Get("drift") {
+		case "critical":
+			field.Critical = true
+			field.Required = true
+		case "required":
+			field.Required = true
+		}
+		fields[name] = field
+	}
+}
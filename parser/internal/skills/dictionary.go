@@ -0,0 +1,100 @@
+// курируемый словарь навыков (тех-стек) с синонимами
+// используется для нормализации key_skills от HH.ru и для извлечения навыков из описаний вакансий
+package skills
+
+// словарь: каноническое имя навыка -> список синонимов (в нижнем регистре)
+// каноническое имя - это то, что попадает в теги вакансии и по чему идёт фильтрация по стеку
+var dictionary = map[string][]string{
+	// языки программирования
+	"Go":         {"go", "golang", "go lang", "go-lang", "го", "голанг"},
+	"Python":     {"python", "питон", "python3"},
+	"Java":       {"java", "джава"},
+	"Kotlin":     {"kotlin", "котлин"},
+	"JavaScript": {"javascript", "js", "ecmascript", "es6"},
+	"TypeScript": {"typescript", "ts"},
+	"C++":        {"c++", "cpp", "си++"},
+	"C#":         {"c#", "csharp", "c sharp"},
+	"PHP":        {"php"},
+	"Rust":       {"rust", "раст"},
+	"Ruby":       {"ruby"},
+	"Scala":      {"scala"},
+	"Swift":      {"swift"},
+	"1C":         {"1с", "1c", "1с:предприятие"},
+
+	// фреймворки и рантаймы
+	"Node.js": {"node.js", "nodejs", "node"},
+	"React":   {"react", "react.js", "reactjs"},
+	"Vue.js":  {"vue", "vue.js", "vuejs"},
+	"Angular": {"angular"},
+	"Django":  {"django"},
+	"FastAPI": {"fastapi"},
+	"Spring":  {"spring", "spring boot", "springboot"},
+	".NET":    {".net", "dotnet", "asp.net"},
+	"gRPC":    {"grpc"},
+	"GraphQL": {"graphql"},
+
+	// базы данных и хранилища
+	"PostgreSQL":    {"postgresql", "postgres", "постгрес", "pgsql"},
+	"MySQL":         {"mysql"},
+	"MongoDB":       {"mongodb", "mongo"},
+	"Redis":         {"redis"},
+	"ClickHouse":    {"clickhouse"},
+	"Elasticsearch": {"elasticsearch", "elastic"},
+	"SQL":           {"sql"},
+
+	// брокеры сообщений
+	"Kafka":    {"kafka", "apache kafka"},
+	"RabbitMQ": {"rabbitmq", "rabbit"},
+	"NATS":     {"nats"},
+
+	// инфраструктура
+	"Docker":     {"docker", "докер"},
+	"Kubernetes": {"kubernetes", "k8s", "кубернетес"},
+	"Linux":      {"linux", "линукс"},
+	"Git":        {"git"},
+	"CI/CD":      {"ci/cd", "cicd", "gitlab ci", "github actions", "jenkins"},
+	"Terraform":  {"terraform"},
+	"Ansible":    {"ansible"},
+	"AWS":        {"aws", "amazon web services"},
+	"Prometheus": {"prometheus"},
+	"Grafana":    {"grafana"},
+
+	// подходы и протоколы
+	"REST":          {"rest", "rest api", "restful"},
+	"Microservices": {"microservices", "микросервисы", "микросервисная архитектура"},
+}
+
+// короткие синонимы, которые в обычном тексте часто встречаются не как технологии ("go to", "the rest", "spring sale")
+// в key_skills они нормализуются как обычно, а в описании засчитываются только рядом с тех-контекстом
+var ambiguousSynonyms = map[string]struct{}{
+	"go":      {},
+	"го":      {},
+	"rest":    {},
+	"node":    {},
+	"js":      {},
+	"ts":      {},
+	"spring":  {},
+	"elastic": {},
+	"rabbit":  {},
+}
+
+// начала слов, которые рядом с коротким синонимом указывают, что речь о технологии
+var techContextPrefixes = []string{
+	"разработ", "программист", "developer", "development", "engineer", "инженер",
+	"backend", "бэкенд", "бекенд", "frontend", "фронтенд", "fullstack", "фулстек",
+	"язык", "language", "стек", "stack", "api", "фреймворк", "framework", "сервис", "service", "опыт", "знани",
+}
+
+// обратный индекс: синоним -> каноническое имя навыка (строится один раз при инициализации пакета)
+var synonymIndex = buildSynonymIndex()
+
+// функция построения обратного индекса синонимов
+func buildSynonymIndex() map[string]string {
+	index := make(map[string]string)
+	for canonical, synonyms := range dictionary {
+		for _, synonym := range synonyms {
+			index[synonym] = canonical
+		}
+	}
+	return index
+}
@@ -0,0 +1,159 @@
+package skills
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// максимальное количество слов в синониме из словаря (например: "микросервисная архитектура", "amazon web services")
+const maxSynonymWords = 3
+
+// в пределах скольких слов от короткого синонима ищется тех-контекст
+const contextWindow = 3
+
+// Normalize приводит название навыка к каноническому имени из словаря
+// если навык в словаре не найден - возвращается исходная строка без лишних пробелов
+func Normalize(name string) string {
+	trimmed := strings.TrimSpace(name)
+	if canonical, ok := synonymIndex[strings.ToLower(trimmed)]; ok {
+		return canonical
+	}
+	return trimmed
+}
+
+// NormalizeAll нормализует список навыков (например, key_skills от HH.ru), убирает дубликаты и пустые значения
+func NormalizeAll(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	result := make([]string, 0, len(names))
+
+	for _, name := range names {
+		normalized := Normalize(name)
+		if normalized == "" {
+			continue
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		result = append(result, normalized)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// Extract извлекает навыки из произвольного текста (описания вакансии) по словарю синонимов
+// HTML теги из описания предварительно удаляются, результат - отсортированный список канонических имён
+func Extract(text string) []string {
+	tokens := tokenize(stripTags(text))
+
+	found := make(map[string]struct{})
+
+	// проходим по тексту окнами от 1 до maxSynonymWords слов и ищем совпадения в обратном индексе
+	for i := range tokens {
+		for n := 1; n <= maxSynonymWords && i+n <= len(tokens); n++ {
+			phrase := strings.Join(tokens[i:i+n], " ")
+			canonical, ok := synonymIndex[phrase]
+			if !ok {
+				continue
+			}
+			if _, ambiguous := ambiguousSynonyms[phrase]; ambiguous && !hasTechContext(tokens, i) {
+				continue
+			}
+			found[canonical] = struct{}{}
+		}
+	}
+
+	result := make([]string, 0, len(found))
+	for canonical := range found {
+		result = append(result, canonical)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// функция проверки тех-контекста вокруг слова tokens[pos]: рядом есть другой навык из словаря
+// (не короткий синоним) или слово вроде "разработчик", "backend", "стек", "API"
+func hasTechContext(tokens []string, pos int) bool {
+	from, to := max(0, pos-contextWindow), min(len(tokens), pos+contextWindow+1)
+	for i := from; i < to; i++ {
+		if i == pos {
+			continue
+		}
+		token := tokens[i]
+		if _, ok := synonymIndex[token]; ok {
+			if _, ambiguous := ambiguousSynonyms[token]; !ambiguous {
+				return true
+			}
+		}
+		for _, prefix := range techContextPrefixes {
+			if strings.HasPrefix(token, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Merge объединяет несколько списков навыков в один отсортированный список без дубликатов
+func Merge(lists ...[]string) []string {
+	var all []string
+	for _, list := range lists {
+		all = append(all, list...)
+	}
+	return NormalizeAll(all)
+}
+
+// функция разбиения текста на слова в нижнем регистре
+// внутри слова допускаются символы, которые встречаются в названиях технологий: c++, c#, node.js, ci/cd, 1с:предприятие
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+		switch r {
+		case '+', '#', '.', '/', ':', '-':
+			return false
+		}
+		return true
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		// убираем знаки препинания на концах слова (конец предложения, перечисления);
+		// одна точка в начале слова перед буквой - часть названия (.net), её оставляем
+		token := strings.TrimLeft(strings.TrimRight(field, ".:-/"), ":-/")
+		if dotted := strings.TrimLeft(token, "."); dotted != token {
+			if r := []rune(dotted); len(r) > 0 && unicode.IsLetter(r[0]) {
+				dotted = "." + dotted
+			}
+			token = strings.TrimLeft(dotted, ":-/")
+		}
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// функция удаления HTML тегов из строки (описания вакансий приходят в HTML)
+func stripTags(text string) string {
+	var result strings.Builder
+	var inTag bool
+
+	for _, ch := range text {
+		switch {
+		case ch == '<':
+			inTag = true
+			result.WriteRune(' ')
+		case ch == '>':
+			inTag = false
+		case !inTag:
+			result.WriteRune(ch)
+		}
+	}
+
+	return result.String()
+}
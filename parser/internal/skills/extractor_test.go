@@ -0,0 +1,37 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: ".NET at the start", text: ".NET разработчик", want: []string{".NET"}},
+		{name: ".NET after a comma", text: "Стек: C#, .NET, SQL.", want: []string{".NET", "C#", "SQL"}},
+		{name: "ASP.NET", text: "опыт с ASP.NET", want: []string{".NET"}},
+		{name: "C# at the end of a sentence", text: "Пишем на C#.", want: []string{"C#"}},
+		{name: "C++ in a list", text: "Требования: C++, Python", want: []string{"C++", "Python"}},
+		{name: "leading dots before a digit are punctuation", text: "версии ...3 и выше, Go разработчик", want: []string{"Go"}},
+		{name: "html is stripped", text: "<p><strong>Docker</strong> и <em>Kubernetes</em></p>", want: []string{"Docker", "Kubernetes"}},
+		{name: "multi-word synonym", text: "работа с Apache Kafka", want: []string{"Kafka"}},
+		{name: "ambiguous go outside tech context", text: "Let's go to the office", want: []string{}},
+		{name: "ambiguous go near a skill", text: "Go, PostgreSQL", want: []string{"Go", "PostgreSQL"}},
+		{name: "ambiguous go near a context word", text: "backend на Go", want: []string{"Go"}},
+		{name: "ambiguous rest outside tech context", text: "the rest of the team", want: []string{}},
+		{name: "ambiguous spring near framework", text: "фреймворк Spring", want: []string{"Spring"}},
+		{name: "ambiguous spring alone", text: "spring sale", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
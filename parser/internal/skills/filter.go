@@ -0,0 +1,64 @@
+package skills
+
+import (
+	"parser/internal/domain/models"
+	"strings"
+)
+
+// ParseStack разбирает строку стека, введённую пользователем через запятую ("golang, postgres, k8s"),
+// в список канонических имён навыков
+func ParseStack(input string) []string {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	return NormalizeAll(strings.Split(input, ","))
+}
+
+// HasStack проверяет, что у вакансии есть все навыки из заданного стека
+func HasStack(vacancySkills []string, stack []string) bool {
+	if len(stack) == 0 {
+		return true
+	}
+
+	present := make(map[string]struct{}, len(vacancySkills))
+	for _, skill := range vacancySkills {
+		present[strings.ToLower(skill)] = struct{}{}
+	}
+
+	for _, required := range stack {
+		if _, ok := present[strings.ToLower(Normalize(required))]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterVacancies оставляет только те вакансии, у которых есть все навыки из заданного стека
+func FilterVacancies(vacancies []models.Vacancy, stack []string) []models.Vacancy {
+	if len(stack) == 0 {
+		return vacancies
+	}
+
+	filtered := make([]models.Vacancy, 0, len(vacancies))
+	for _, vacancy := range vacancies {
+		if HasStack(vacancy.Skills, stack) {
+			filtered = append(filtered, vacancy)
+		}
+	}
+	return filtered
+}
+
+// FilterResults применяет фильтр по стеку к результатам поиска каждого парсера
+// возвращает новый слайс, исходные результаты (например, из кэша) не изменяются
+func FilterResults(results []models.SearchVacanciesResult, stack []string) []models.SearchVacanciesResult {
+	if len(stack) == 0 {
+		return results
+	}
+
+	filtered := make([]models.SearchVacanciesResult, len(results))
+	for i, result := range results {
+		filtered[i] = result
+		filtered[i].Vacancies = FilterVacancies(result.Vacancies, stack)
+	}
+	return filtered
+}
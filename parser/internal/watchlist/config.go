@@ -0,0 +1,25 @@
+package watchlist
+
+import "time"
+
+// Config - конфигурация отслеживания вакансий
+type Config struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"` // как часто перепроверяются отслеживаемые вакансии
+	// как часто перепроверяются закрытые вакансии (в архиве или удалённые у источника) - их переоткрывают редко
+	ArchivedInterval time.Duration `yaml:"archived_interval"`
+	MaxItems         int           `yaml:"max_items"`    // максимальное количество отслеживаемых вакансий
+	HistorySize      int           `yaml:"history_size"` // сколько последних событий хранится по каждой вакансии
+	StoragePath      string        `yaml:"storage_path"` // файл, в котором список переживает перезапуск ("" - только в памяти)
+}
+
+// DefaultConfig возвращает конфигурацию по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		Enabled:          true,
+		Interval:         30 * time.Minute,
+		ArchivedInterval: 24 * time.Hour,
+		MaxItems:         200,
+		HistorySize:      50,
+	}
+}
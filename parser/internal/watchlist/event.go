@@ -0,0 +1,118 @@
+package watchlist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/pkg"
+	"time"
+)
+
+// EventType - вид изменения отслеживаемой вакансии
+type EventType string
+
+const (
+	EventArchived           EventType = "archived"            // вакансия закрыта (в архиве или удалена у источника)
+	EventRestored           EventType = "restored"            // закрытая вакансия снова открыта
+	EventSalaryChanged      EventType = "salary_changed"      // изменилась зарплата
+	EventDescriptionChanged EventType = "description_changed" // отредактировано описание
+)
+
+// Event - изменение отслеживаемой вакансии
+type Event struct {
+	Type      EventType `json:"type"`
+	Source    string    `json:"source"`
+	VacancyID string    `json:"vacancy_id"`
+	Name      string    `json:"name"`          // название вакансии на момент события
+	Old       string    `json:"old,omitempty"` // прежнее значение (для зарплаты - строкой)
+	New       string    `json:"new,omitempty"` // новое значение
+	At        time.Time `json:"at"`
+}
+
+// String возвращает описание события для вывода в консоль
+func (e Event) String() string {
+	switch e.Type {
+	case EventArchived:
+		return fmt.Sprintf("вакансия %s:%s «%s» закрыта (%s)", e.Source, e.VacancyID, e.Name, e.New)
+	case EventRestored:
+		return fmt.Sprintf("вакансия %s:%s «%s» снова открыта", e.Source, e.VacancyID, e.Name)
+	case EventSalaryChanged:
+		return fmt.Sprintf("у вакансии %s:%s «%s» изменилась зарплата: %s -> %s", e.Source, e.VacancyID, e.Name, e.Old, e.New)
+	case EventDescriptionChanged:
+		return fmt.Sprintf("у вакансии %s:%s «%s» изменилось описание", e.Source, e.VacancyID, e.Name)
+	default:
+		return fmt.Sprintf("вакансия %s:%s «%s»: %s", e.Source, e.VacancyID, e.Name, e.Type)
+	}
+}
+
+// Snapshot - состояние вакансии при последней успешной проверке (с ним сравнивается следующая)
+type Snapshot struct {
+	Name            string        `json:"name"`
+	Company         string        `json:"company"`
+	URL             string        `json:"url"`
+	Salary          models.Salary `json:"salary"`
+	DescriptionHash string        `json:"description_hash"` // само описание не храним, для обнаружения правок достаточно хэша
+	Archived        bool          `json:"archived"`
+	ArchivedReason  string        `json:"archived_reason,omitempty"`
+}
+
+// функция построения снимка из деталей вакансии
+func newSnapshot(details models.SearchVacancyDetailesResult) Snapshot {
+	snapshot := Snapshot{
+		Name:            details.Name,
+		Company:         details.Employer.Name,
+		URL:             details.Url,
+		Salary:          details.Salary,
+		DescriptionHash: hashDescription(details.Description),
+		Archived:        details.Archived,
+	}
+	if details.Archived {
+		snapshot.ArchivedReason = "в архиве у источника"
+	}
+	return snapshot
+}
+
+// функция хэширования описания
+func hashDescription(description string) string {
+	sum := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(sum[:8])
+}
+
+// функция форматирования зарплаты для событий
+func formatSalary(salary models.Salary) string {
+	if salary.From == 0 && salary.To == 0 {
+		return "не указана"
+	}
+	return pkg.FormatSalary(salary.From, salary.To, salary.Currency)
+}
+
+// функция сравнения двух снимков вакансии, возвращает события изменений
+// если вакансия закрыта, зарплату и описание не сравниваем: при 404 их просто нет
+func diff(prev, next Snapshot, source, vacancyID string, at time.Time) []Event {
+	newEvent := func(eventType EventType, old, new string) Event {
+		name := next.Name
+		if name == "" {
+			name = prev.Name
+		}
+		return Event{Type: eventType, Source: source, VacancyID: vacancyID, Name: name, Old: old, New: new, At: at}
+	}
+
+	var events []Event
+	switch {
+	case !prev.Archived && next.Archived:
+		return append(events, newEvent(EventArchived, "", next.ArchivedReason))
+	case prev.Archived && next.Archived:
+		return nil
+	case prev.Archived && !next.Archived:
+		events = append(events, newEvent(EventRestored, "", ""))
+	}
+
+	if prev.Salary != next.Salary {
+		events = append(events, newEvent(EventSalaryChanged, formatSalary(prev.Salary), formatSalary(next.Salary)))
+	}
+	if prev.DescriptionHash != next.DescriptionHash {
+		events = append(events, newEvent(EventDescriptionChanged, "", ""))
+	}
+	return events
+}
@@ -0,0 +1,79 @@
+package watchlist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// формат файла списка отслеживания
+type storageFile struct {
+	Items []Item `json:"items"`
+}
+
+// метод загрузки списка из файла (отсутствующий файл - пустой список)
+func (w *Watchlist) load() error {
+	if w.config.StoragePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(w.config.StoragePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("watchlist: read %s: %w", w.config.StoragePath, err)
+	}
+
+	var file storageFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("watchlist: parse %s: %w", w.config.StoragePath, err)
+	}
+
+	for i := range file.Items {
+		item := file.Items[i]
+		w.items[itemKey(item.Source, item.VacancyID)] = &item
+	}
+	return nil
+}
+
+// метод сохранения списка в файл, ошибка сохранения не мешает работе в памяти
+func (w *Watchlist) persist() {
+	if w.config.StoragePath == "" {
+		return
+	}
+
+	if err := w.save(); err != nil {
+		fmt.Printf("⚠️  Не удалось сохранить список отслеживания: %v\n", err)
+	}
+}
+
+// метод записи файла: сначала во временный, затем переименование, чтобы не оставить обрезанный файл
+func (w *Watchlist) save() error {
+	w.saveMu.Lock()
+	defer w.saveMu.Unlock()
+
+	file := storageFile{Items: w.List()}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(w.config.StoragePath), 0o755); err != nil {
+		return err
+	}
+
+	tmp := w.config.StoragePath + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.config.StoragePath)
+}
@@ -0,0 +1,304 @@
+// отслеживание конкретных вакансий: фоновая перепроверка деталей по расписанию,
+// обнаружение закрытия вакансии, изменения зарплаты и правок описания, история изменений
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"parser/internal/domain/models"
+	"parser/internal/pipeline"
+	"sort"
+	"sync"
+	"time"
+)
+
+// таймаут проверки одной вакансии
+const checkTimeout = 30 * time.Second
+
+var (
+	ErrAlreadyWatched = errors.New("watchlist: vacancy is already watched")
+	ErrNotWatched     = errors.New("watchlist: vacancy is not watched")
+	ErrLimitReached   = errors.New("watchlist: watched vacancies limit reached")
+)
+
+// Fetcher - функция получения актуальных деталей вакансии у источника (в обход кэша деталей)
+// background - фоновая перепроверка списка (а не добавление вакансии пользователем), её можно выполнять с низким приоритетом
+type Fetcher func(ctx context.Context, source, vacancyID string, background bool) (models.SearchVacancyDetailesResult, error)
+
+// Handler - обработчик событий изменения вакансий
+type Handler func(Event)
+
+// Item - отслеживаемая вакансия
+type Item struct {
+	Source    string    `json:"source"`
+	VacancyID string    `json:"vacancy_id"`
+	AddedAt   time.Time `json:"added_at"`
+	LastCheck time.Time `json:"last_check"`
+	LastError string    `json:"last_error,omitempty"` // ошибка последней проверки (кроме 404 - это закрытие вакансии)
+	Snapshot  *Snapshot `json:"snapshot,omitempty"`   // nil - вакансия ещё ни разу не была получена
+	History   []Event   `json:"history,omitempty"`    // последние события, от старых к новым
+}
+
+// Watchlist - список отслеживаемых вакансий
+type Watchlist struct {
+	config Config
+	fetch  Fetcher
+
+	mu       sync.Mutex
+	items    map[string]*Item // ключ - источник_ID
+	handlers []Handler
+
+	checkMu  sync.Mutex // проверки всего списка не пересекаются
+	saveMu   sync.Mutex // запись файла списка не пересекается
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New создаёт список отслеживания и загружает сохранённый список из файла (если он задан в конфиге)
+func New(config Config, fetch Fetcher) (*Watchlist, error) {
+	w := &Watchlist{
+		config:   config,
+		fetch:    fetch,
+		items:    make(map[string]*Item),
+		stopChan: make(chan struct{}),
+	}
+
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Subscribe добавляет обработчик событий изменения вакансий
+func (w *Watchlist) Subscribe(handler Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Add добавляет вакансию в список и сразу запоминает её текущее состояние
+// вакансия, которую не удалось получить у источника, в список не добавляется
+func (w *Watchlist) Add(ctx context.Context, source, vacancyID string) (Item, error) {
+	key := itemKey(source, vacancyID)
+
+	w.mu.Lock()
+	if _, ok := w.items[key]; ok {
+		w.mu.Unlock()
+		return Item{}, ErrAlreadyWatched
+	}
+	if w.config.MaxItems > 0 && len(w.items) >= w.config.MaxItems {
+		w.mu.Unlock()
+		return Item{}, fmt.Errorf("%w (%d)", ErrLimitReached, w.config.MaxItems)
+	}
+	w.mu.Unlock()
+
+	details, err := w.fetch(ctx, source, vacancyID, false)
+	if err != nil {
+		return Item{}, err
+	}
+
+	snapshot := newSnapshot(details)
+	now := time.Now()
+	item := &Item{Source: source, VacancyID: vacancyID, AddedAt: now, LastCheck: now, Snapshot: &snapshot}
+
+	w.mu.Lock()
+	if _, ok := w.items[key]; ok {
+		w.mu.Unlock()
+		return Item{}, ErrAlreadyWatched
+	}
+	w.items[key] = item
+	copied := item.clone()
+	w.mu.Unlock()
+
+	w.persist()
+	return copied, nil
+}
+
+// Remove убирает вакансию из списка
+func (w *Watchlist) Remove(source, vacancyID string) error {
+	w.mu.Lock()
+	key := itemKey(source, vacancyID)
+	if _, ok := w.items[key]; !ok {
+		w.mu.Unlock()
+		return ErrNotWatched
+	}
+	delete(w.items, key)
+	w.mu.Unlock()
+
+	w.persist()
+	return nil
+}
+
+// List возвращает копию списка, отсортированную по времени добавления
+func (w *Watchlist) List() []Item {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	items := make([]Item, 0, len(w.items))
+	for _, item := range w.items {
+		items = append(items, item.clone())
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].AddedAt.Before(items[j].AddedAt)
+	})
+	return items
+}
+
+// History возвращает историю изменений вакансии
+func (w *Watchlist) History(source, vacancyID string) ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	item, ok := w.items[itemKey(source, vacancyID)]
+	if !ok {
+		return nil, ErrNotWatched
+	}
+	return append([]Event(nil), item.History...), nil
+}
+
+// Start запускает фоновую перепроверку списка с интервалом из конфига
+func (w *Watchlist) Start() {
+	if w.config.Interval <= 0 {
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.CheckAll(context.Background())
+			case <-w.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop останавливает фоновую перепроверку
+func (w *Watchlist) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChan)
+	})
+	w.wg.Wait()
+}
+
+// CheckAll перепроверяет вакансии списка, возвращает обнаруженные изменения
+// вакансии проверяются последовательно: частоту запросов всё равно ограничивает rate limiter источника
+// закрытые вакансии перепроверяются не чаще archived_interval: каждый раз запрашивать удалённую вакансию незачем
+func (w *Watchlist) CheckAll(ctx context.Context) []Event {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	var events []Event
+	now := time.Now()
+	for _, item := range w.List() {
+		if !w.due(item, now) {
+			continue
+		}
+		select {
+		case <-w.stopChan:
+			return events
+		default:
+		}
+		if ctx.Err() != nil {
+			return events
+		}
+
+		events = append(events, w.check(ctx, item.Source, item.VacancyID)...)
+	}
+
+	// сохраняем время проверки и историю, даже если изменений не было
+	w.persist()
+	return events
+}
+
+// метод проверки одной вакансии: запрос актуальных деталей, сравнение со снимком, запись событий в историю
+func (w *Watchlist) check(ctx context.Context, source, vacancyID string) []Event {
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	details, err := w.fetch(checkCtx, source, vacancyID, true)
+	now := time.Now()
+
+	w.mu.Lock()
+	item, ok := w.items[itemKey(source, vacancyID)]
+	if !ok {
+		// вакансию убрали из списка, пока шёл запрос
+		w.mu.Unlock()
+		return nil
+	}
+	item.LastCheck = now
+
+	var next Snapshot
+	switch {
+	case err == nil:
+		item.LastError = ""
+		next = newSnapshot(details)
+	case pipeline.IsNotFound(err) && item.Snapshot != nil:
+		// источник больше не отдаёт вакансию - считаем её закрытой, остальные поля оставляем прежними
+		item.LastError = ""
+		next = *item.Snapshot
+		next.Archived = true
+		next.ArchivedReason = "удалена у источника (404)"
+	default:
+		item.LastError = err.Error()
+		w.mu.Unlock()
+		return nil
+	}
+
+	var events []Event
+	if item.Snapshot != nil {
+		events = diff(*item.Snapshot, next, source, vacancyID, now)
+	}
+	item.Snapshot = &next
+	item.History = append(item.History, events...)
+	if limit := w.config.HistorySize; limit > 0 && len(item.History) > limit {
+		item.History = append([]Event(nil), item.History[len(item.History)-limit:]...)
+	}
+	handlers := append([]Handler(nil), w.handlers...)
+	w.mu.Unlock()
+
+	// обработчики вызываются без мьютекса: им можно обращаться к списку
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+	return events
+}
+
+// метод проверки, пора ли перепроверять вакансию (закрытые - с интервалом archived_interval)
+func (w *Watchlist) due(item Item, now time.Time) bool {
+	if item.Snapshot == nil || !item.Snapshot.Archived {
+		return true
+	}
+	interval := w.config.ArchivedInterval
+	if interval <= 0 {
+		interval = DefaultConfig().ArchivedInterval
+	}
+	return now.Sub(item.LastCheck) >= interval
+}
+
+// функция формирования ключа вакансии: ID уникальны только в пределах источника
+func itemKey(source, vacancyID string) string {
+	return source + "_" + vacancyID
+}
+
+// метод копирования элемента (наружу отдаём копии, чтобы не держать мьютекс)
+func (item *Item) clone() Item {
+	copied := *item
+	if item.Snapshot != nil {
+		snapshot := *item.Snapshot
+		copied.Snapshot = &snapshot
+	}
+	copied.History = append([]Event(nil), item.History...)
+	return copied
+}
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"os"
+	"strings"
+)
+
+// ExpandTemplate подставляет в шаблон переменные вида $VAR, ${VAR} и ${VAR:-значение по умолчанию}
+// сначала переменная ищется в local (переменные конкретного источника), затем в окружении процесса
+// возвращает результат и список переменных, для которых не нашлось ни значения, ни значения по умолчанию
+func ExpandTemplate(template string, local map[string]string) (string, []string) {
+	var missing []string
+
+	result := os.Expand(template, func(expr string) string {
+		name, fallback, hasFallback := strings.Cut(expr, ":-")
+
+		if value, ok := local[name]; ok && value != "" {
+			return value
+		}
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return value
+		}
+		if hasFallback {
+			return fallback
+		}
+
+		missing = append(missing, name)
+		return ""
+	})
+
+	return result, missing
+}
@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SanitizeText готовит произвольный текст от внешнего источника (например, тело ответа с ошибкой) к выводу в логи:
+// убирает управляющие символы, схлопывает пробелы и обрезает строку до maxLen байт (по границе руны)
+func SanitizeText(text string, maxLen int) string {
+	var builder strings.Builder
+	builder.Grow(min(len(text), maxLen))
+
+	lastSpace := false
+	for _, r := range text {
+		// невалидные байты и управляющие символы (в том числе escape-последовательности терминала) заменяем пробелом
+		if r == utf8.RuneError || unicode.IsControl(r) || unicode.IsSpace(r) {
+			if !lastSpace {
+				builder.WriteByte(' ')
+				lastSpace = true
+			}
+			continue
+		}
+		lastSpace = false
+		builder.WriteRune(r)
+	}
+
+	result := strings.TrimSpace(builder.String())
+	if maxLen <= 0 || len(result) <= maxLen {
+		return result
+	}
+
+	// обрезаем по границе руны, чтобы не получить невалидный UTF-8
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(result[cut]) {
+		cut--
+	}
+	return result[:cut] + "…(truncated)"
+}